@@ -0,0 +1,291 @@
+package Cache
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+/**
+ * The interval between two sweeps of the expired items.
+ *
+ * @var time.Duration
+ */
+const arrayStoreSweepInterval = time.Minute
+
+type arrayStoreItem struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+/**
+ * Determine if the item has expired.
+ *
+ * @return bool
+ */
+func (this arrayStoreItem) expired() bool {
+	return !this.expiresAt.IsZero() && !time.Now().Before(this.expiresAt)
+}
+
+type ArrayStore struct {
+	/**
+	 * The array of stored values.
+	 *
+	 * @var map[string]arrayStoreItem
+	 */
+	storage map[string]arrayStoreItem
+
+	/**
+	 * The time the expired items were last swept.
+	 *
+	 * @var time.Time
+	 */
+	sweptAt time.Time
+
+	lock sync.Mutex
+}
+
+/**
+ * Create a new in-memory cache store.
+ *
+ * @return *ArrayStore
+ */
+func NewArrayStore() (this *ArrayStore) {
+	this = &ArrayStore{}
+	this.storage = map[string]arrayStoreItem{}
+	this.sweptAt = time.Now()
+	return this
+}
+
+/**
+ * Retrieve an item from the cache by key.
+ *
+ * @param  string  key
+ * @return interface{}
+ */
+func (this *ArrayStore) Get(key string) interface{} {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if item, ok := this.get(key); ok {
+		return item.value
+	}
+	return nil
+}
+
+/**
+ * Store an item in the cache for a given number of seconds.
+ *
+ * @param  string  key
+ * @param  interface{}  value
+ * @param  int  seconds
+ * @return bool
+ */
+func (this *ArrayStore) Put(key string, value interface{}, seconds int) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.sweep()
+
+	this.storage[key] = arrayStoreItem{value: value, expiresAt: this.calculateExpiration(seconds)}
+	return true
+}
+
+/**
+ * Store an item in the cache if the key does not exist.
+ *
+ * @param  string  key
+ * @param  interface{}  value
+ * @param  int  seconds
+ * @return bool
+ */
+func (this *ArrayStore) Add(key string, value interface{}, seconds int) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.sweep()
+
+	if _, ok := this.get(key); ok {
+		return false
+	}
+	this.storage[key] = arrayStoreItem{value: value, expiresAt: this.calculateExpiration(seconds)}
+	return true
+}
+
+/**
+ * Increment the value of an item in the cache.
+ *
+ * @param  string  key
+ * @param  int  value
+ * @return int
+ */
+func (this *ArrayStore) Increment(key string, value ...int) int {
+	value = append(value, 1)
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	item, ok := this.get(key)
+	if !ok {
+		this.sweep()
+		item = arrayStoreItem{value: 0}
+	}
+	incremented := toInt(item.value) + value[0]
+	item.value = incremented
+	this.storage[key] = item
+
+	return incremented
+}
+
+/**
+ * Increment the value of an item in the cache unless it has reached the
+ * given limit, as one step. An item that does not exist yet is stored for
+ * the given number of seconds.
+ *
+ * @param  string  key
+ * @param  int  limit
+ * @param  int  seconds
+ * @return int, bool
+ */
+func (this *ArrayStore) IncrementWithin(key string, limit int, seconds int) (int, bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	item, ok := this.get(key)
+	if !ok {
+		this.sweep()
+		item = arrayStoreItem{value: 0, expiresAt: this.calculateExpiration(seconds)}
+	}
+	if current := toInt(item.value); current >= limit {
+		return current, false
+	}
+	incremented := toInt(item.value) + 1
+	item.value = incremented
+	this.storage[key] = item
+
+	return incremented, true
+}
+
+/**
+ * Decrement the value of an item in the cache.
+ *
+ * @param  string  key
+ * @param  int  value
+ * @return int
+ */
+func (this *ArrayStore) Decrement(key string, value ...int) int {
+	value = append(value, 1)
+	return this.Increment(key, -value[0])
+}
+
+/**
+ * Store an item in the cache indefinitely.
+ *
+ * @param  string  key
+ * @param  interface{}  value
+ * @return bool
+ */
+func (this *ArrayStore) Forever(key string, value interface{}) bool {
+	return this.Put(key, value, 0)
+}
+
+/**
+ * Remove an item from the cache.
+ *
+ * @param  string  key
+ * @return bool
+ */
+func (this *ArrayStore) Forget(key string) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if _, ok := this.storage[key]; ok {
+		delete(this.storage, key)
+		return true
+	}
+	return false
+}
+
+/**
+ * Remove all items from the cache.
+ *
+ * @return bool
+ */
+func (this *ArrayStore) Flush() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.storage = map[string]arrayStoreItem{}
+	this.sweptAt = time.Now()
+	return true
+}
+
+/**
+ * Get an item that has not expired, removing it when it has.
+ *
+ * @param  string  key
+ * @return arrayStoreItem, bool
+ */
+func (this *ArrayStore) get(key string) (arrayStoreItem, bool) {
+	item, ok := this.storage[key]
+	if ok && item.expired() {
+		delete(this.storage, key)
+		return item, false
+	}
+	return item, ok
+}
+
+/**
+ * Remove the expired items, at most once per sweep interval, so items that
+ * are never read again don't pile up. The cost of a sweep is spread over
+ * the writes made in between.
+ *
+ * @return void
+ */
+func (this *ArrayStore) sweep() {
+	now := time.Now()
+	if now.Sub(this.sweptAt) < arrayStoreSweepInterval {
+		return
+	}
+	this.sweptAt = now
+
+	for key, item := range this.storage {
+		if item.expired() {
+			delete(this.storage, key)
+		}
+	}
+}
+
+/**
+ * Get the expiration time of the key, a zero time never expires.
+ *
+ * @param  int  seconds
+ * @return time.Time
+ */
+func (this *ArrayStore) calculateExpiration(seconds int) time.Time {
+	if seconds <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(time.Duration(seconds) * time.Second)
+}
+
+/**
+ * Convert a cached value to an integer.
+ *
+ * @param  interface{}  value
+ * @return int
+ */
+func toInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	case string:
+		i, _ := strconv.Atoi(v)
+		return i
+	}
+	return 0
+}
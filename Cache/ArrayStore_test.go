@@ -0,0 +1,29 @@
+package Cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpiredItemsAreSweptOnWrite(t *testing.T) {
+	store := NewArrayStore()
+	for _, key := range []string{"a", "b", "c"} {
+		store.storage[key] = arrayStoreItem{value: 1, expiresAt: time.Now().Add(-time.Second)}
+	}
+	store.Put("live", 1, 60)
+
+	// no sweep happens before the interval has passed
+	if len(store.storage) != 4 {
+		t.Fatalf("expected 4 items before the sweep, got %d", len(store.storage))
+	}
+
+	store.sweptAt = time.Now().Add(-arrayStoreSweepInterval)
+	store.Put("other", 1, 60)
+
+	if len(store.storage) != 2 {
+		t.Fatalf("expected the expired items to be swept, got %d items", len(store.storage))
+	}
+	if store.Get("live") != 1 || store.Get("other") != 1 {
+		t.Fatal("a live item was swept")
+	}
+}
@@ -0,0 +1,32 @@
+package Cache
+
+import (
+	CacheContract "github.com/larisgo/framework/Contracts/Cache"
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Support"
+)
+
+type CacheServiceProvider struct {
+	*Support.ServiceProvider
+}
+
+func NewCacheServiceProvider(app Foundation.Application) (this *CacheServiceProvider) {
+	this = &CacheServiceProvider{ServiceProvider: Support.NewServiceProvider(app)}
+	return this
+}
+
+/**
+ * Register the service provider.
+ *
+ * @return void
+ */
+func (this *CacheServiceProvider) Register() {
+	this.App.Singleton("cache.store", func(app Container.Container) interface{} {
+		return NewArrayStore()
+	})
+
+	this.App.Singleton("limiter", func(app Container.Container) interface{} {
+		return NewRateLimiter(app.Make("cache.store").(CacheContract.Store))
+	})
+}
@@ -0,0 +1,241 @@
+package Cache
+
+import (
+	"github.com/larisgo/framework/Cache/RateLimiting"
+	CacheContract "github.com/larisgo/framework/Contracts/Cache"
+	"github.com/larisgo/framework/Http"
+	"sync"
+	"time"
+)
+
+type RateLimiter struct {
+	/**
+	 * The cache store implementation.
+	 *
+	 * @var CacheContract.Store
+	 */
+	cache CacheContract.Store
+
+	/**
+	 * The configured limit object resolvers.
+	 *
+	 * @var map[string]func(*Http.Request) *RateLimiting.Limit
+	 */
+	limiters map[string]func(*Http.Request) *RateLimiting.Limit
+
+	lock sync.Mutex
+
+	/**
+	 * Serialises the hits of stores that can't count atomically.
+	 *
+	 * @var sync.Mutex
+	 */
+	hits sync.Mutex
+}
+
+/**
+ * Create a new rate limiter instance.
+ *
+ * @param  CacheContract.Store  cache
+ * @return *RateLimiter
+ */
+func NewRateLimiter(cache CacheContract.Store) (this *RateLimiter) {
+	this = &RateLimiter{}
+	this.cache = cache
+	this.limiters = map[string]func(*Http.Request) *RateLimiting.Limit{}
+	return this
+}
+
+/**
+ * Register a named limiter configuration.
+ *
+ * @param  string  name
+ * @param  func(*Http.Request) *RateLimiting.Limit  callback
+ * @return this
+ */
+func (this *RateLimiter) For(name string, callback func(*Http.Request) *RateLimiting.Limit) *RateLimiter {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.limiters[name] = callback
+
+	return this
+}
+
+/**
+ * Get the given named rate limiter.
+ *
+ * @param  string  name
+ * @return func(*Http.Request) *RateLimiting.Limit
+ */
+func (this *RateLimiter) Limiter(name string) func(*Http.Request) *RateLimiting.Limit {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	return this.limiters[name]
+}
+
+/**
+ * Attempts to execute a callback if it's not limited.
+ *
+ * @param  string  key
+ * @param  int  maxAttempts
+ * @param  func()  callback
+ * @param  int  decaySeconds
+ * @return bool
+ */
+func (this *RateLimiter) Attempt(key string, maxAttempts int, callback func(), decaySeconds ...int) bool {
+	decaySeconds = append(decaySeconds, 60)
+
+	if !this.Acquire(key, maxAttempts, decaySeconds[0]) {
+		return false
+	}
+
+	callback()
+
+	return true
+}
+
+/**
+ * Hit the given key unless it has been "accessed" too many times already.
+ * Checking the attempts and counting the hit is one atomic step, so
+ * concurrent callers never get past the limit together.
+ *
+ * @param  string  key
+ * @param  int  maxAttempts
+ * @param  int  decaySeconds
+ * @return bool
+ */
+func (this *RateLimiter) Acquire(key string, maxAttempts int, decaySeconds ...int) bool {
+	decaySeconds = append(decaySeconds, 60)
+
+	if store, ok := this.cache.(CacheContract.CounterStore); ok {
+		if _, ok := store.IncrementWithin(key, maxAttempts, decaySeconds[0]); !ok {
+			return false
+		}
+		this.cache.Add(key+":timer", this.availableAt(decaySeconds[0]), decaySeconds[0])
+
+		return true
+	}
+
+	this.hits.Lock()
+	defer this.hits.Unlock()
+
+	if this.TooManyAttempts(key, maxAttempts) {
+		return false
+	}
+	this.Hit(key, decaySeconds[0])
+
+	return true
+}
+
+/**
+ * Determine if the given key has been "accessed" too many times.
+ *
+ * @param  string  key
+ * @param  int  maxAttempts
+ * @return bool
+ */
+func (this *RateLimiter) TooManyAttempts(key string, maxAttempts int) bool {
+	if this.Attempts(key) >= maxAttempts {
+		if this.cache.Get(key+":timer") != nil {
+			return true
+		}
+
+		this.ResetAttempts(key)
+	}
+
+	return false
+}
+
+/**
+ * Increment the counter for a given key for a given decay time.
+ *
+ * @param  string  key
+ * @param  int  decaySeconds
+ * @return int
+ */
+func (this *RateLimiter) Hit(key string, decaySeconds ...int) int {
+	decaySeconds = append(decaySeconds, 60)
+
+	this.cache.Add(key+":timer", this.availableAt(decaySeconds[0]), decaySeconds[0])
+
+	added := this.cache.Add(key, 0, decaySeconds[0])
+
+	hits := this.cache.Increment(key)
+
+	if !added && hits == 1 {
+		this.cache.Put(key, 1, decaySeconds[0])
+	}
+
+	return hits
+}
+
+/**
+ * Get the number of attempts for the given key.
+ *
+ * @param  string  key
+ * @return int
+ */
+func (this *RateLimiter) Attempts(key string) int {
+	return toInt(this.cache.Get(key))
+}
+
+/**
+ * Reset the number of attempts for the given key.
+ *
+ * @param  string  key
+ * @return bool
+ */
+func (this *RateLimiter) ResetAttempts(key string) bool {
+	return this.cache.Forget(key)
+}
+
+/**
+ * Get the number of retries left for the given key.
+ *
+ * @param  string  key
+ * @param  int  maxAttempts
+ * @return int
+ */
+func (this *RateLimiter) RemainingAttempts(key string, maxAttempts int) int {
+	if remaining := maxAttempts - this.Attempts(key); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+/**
+ * Clear the hits and lockout timer for the given key.
+ *
+ * @param  string  key
+ * @return void
+ */
+func (this *RateLimiter) Clear(key string) {
+	this.ResetAttempts(key)
+
+	this.cache.Forget(key + ":timer")
+}
+
+/**
+ * Get the number of seconds until the "key" is accessible again.
+ *
+ * @param  string  key
+ * @return int
+ */
+func (this *RateLimiter) AvailableIn(key string) int {
+	if available := toInt(this.cache.Get(key+":timer")) - int(time.Now().Unix()); available > 0 {
+		return available
+	}
+	return 0
+}
+
+/**
+ * Get the UNIX timestamp at which the given number of seconds have passed.
+ *
+ * @param  int  seconds
+ * @return int
+ */
+func (this *RateLimiter) availableAt(seconds int) int {
+	return int(time.Now().Unix()) + seconds
+}
@@ -0,0 +1,47 @@
+package Cache
+
+import (
+	CacheContract "github.com/larisgo/framework/Contracts/Cache"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type plainStore struct {
+	CacheContract.Store
+}
+
+func acquireConcurrently(limiter *RateLimiter, callers int, maxAttempts int) int64 {
+	var acquired int64
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if limiter.Acquire("key", maxAttempts, 60) {
+				atomic.AddInt64(&acquired, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	return acquired
+}
+
+func TestConcurrentCallersNeverGetPastTheLimit(t *testing.T) {
+	for name, store := range map[string]CacheContract.Store{
+		"counter store": NewArrayStore(),
+		"plain store":   plainStore{NewArrayStore()},
+	} {
+		limiter := NewRateLimiter(store)
+
+		if acquired := acquireConcurrently(limiter, 100, 10); acquired != 10 {
+			t.Fatalf("%s: expected 10 callers to get through, got %d", name, acquired)
+		}
+		if attempts := limiter.Attempts("key"); attempts != 10 {
+			t.Fatalf("%s: expected 10 attempts, got %d", name, attempts)
+		}
+		if limiter.AvailableIn("key") <= 0 {
+			t.Fatalf("%s: expected the key to be locked out", name)
+		}
+	}
+}
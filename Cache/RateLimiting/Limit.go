@@ -0,0 +1,141 @@
+package RateLimiting
+
+import (
+	"github.com/larisgo/framework/Http"
+)
+
+type Limit struct {
+	/**
+	 * The rate limit signature key.
+	 *
+	 * @var string
+	 */
+	Key string
+
+	/**
+	 * The maximum number of attempts allowed within the given number of seconds.
+	 *
+	 * @var int
+	 */
+	MaxAttempts int
+
+	/**
+	 * The number of seconds until the rate limit is reset.
+	 *
+	 * @var int
+	 */
+	DecaySeconds int
+
+	/**
+	 * The response generator callback.
+	 *
+	 * @var func(*Http.Request, map[string][]string) *Http.Response
+	 */
+	ResponseCallback func(*Http.Request, map[string][]string) *Http.Response
+}
+
+/**
+ * Create a new limit instance.
+ *
+ * @param  string  key
+ * @param  int  maxAttempts
+ * @param  int  decaySeconds
+ * @return *Limit
+ */
+func NewLimit(key string, maxAttempts int, decaySeconds int) (this *Limit) {
+	this = &Limit{}
+	this.Key = key
+	this.MaxAttempts = maxAttempts
+	this.DecaySeconds = decaySeconds
+	return this
+}
+
+/**
+ * Create a new rate limit using seconds as decay time.
+ *
+ * @param  int  decaySeconds
+ * @param  int  maxAttempts
+ * @return *Limit
+ */
+func PerSecond(maxAttempts int, decaySeconds ...int) *Limit {
+	decaySeconds = append(decaySeconds, 1)
+	return NewLimit("", maxAttempts, decaySeconds[0])
+}
+
+/**
+ * Create a new rate limit.
+ *
+ * @param  int  maxAttempts
+ * @param  int  decayMinutes
+ * @return *Limit
+ */
+func PerMinute(maxAttempts int, decayMinutes ...int) *Limit {
+	decayMinutes = append(decayMinutes, 1)
+	return NewLimit("", maxAttempts, 60*decayMinutes[0])
+}
+
+/**
+ * Create a new rate limit using hours as decay time.
+ *
+ * @param  int  maxAttempts
+ * @param  int  decayHours
+ * @return *Limit
+ */
+func PerHour(maxAttempts int, decayHours ...int) *Limit {
+	decayHours = append(decayHours, 1)
+	return NewLimit("", maxAttempts, 60*60*decayHours[0])
+}
+
+/**
+ * Create a new rate limit using days as decay time.
+ *
+ * @param  int  maxAttempts
+ * @param  int  decayDays
+ * @return *Limit
+ */
+func PerDay(maxAttempts int, decayDays ...int) *Limit {
+	decayDays = append(decayDays, 1)
+	return NewLimit("", maxAttempts, 60*60*24*decayDays[0])
+}
+
+/**
+ * Create a new unlimited rate limit.
+ *
+ * @return *Limit
+ */
+func None() *Limit {
+	return NewLimit("", 0, 0)
+}
+
+/**
+ * Determine if the limit does not restrict any attempt.
+ *
+ * @return bool
+ */
+func (this *Limit) Unlimited() bool {
+	return this.MaxAttempts <= 0
+}
+
+/**
+ * Set the key of the rate limit.
+ *
+ * @param  string  key
+ * @return this
+ */
+func (this *Limit) By(key string) *Limit {
+	this.Key = key
+
+	return this
+}
+
+/**
+ * Set the callback that should generate the response when the limit is exceeded.
+ *
+ * @param  func(*Http.Request, map[string][]string) *Http.Response  callback
+ * @return this
+ */
+func (this *Limit) Response(callback func(*Http.Request, map[string][]string) *Http.Response) *Limit {
+	this.ResponseCallback = callback
+
+	return this
+}
@@ -0,0 +1,68 @@
+package Concurrency
+
+import (
+	"context"
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	"github.com/larisgo/framework/Contracts/Container"
+	DebugContract "github.com/larisgo/framework/Contracts/Debug"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Support"
+	"runtime"
+	"time"
+)
+
+type ConcurrencyServiceProvider struct {
+	*Support.ServiceProvider
+}
+
+func NewConcurrencyServiceProvider(app Foundation.Application) (this *ConcurrencyServiceProvider) {
+	this = &ConcurrencyServiceProvider{ServiceProvider: Support.NewServiceProvider(app)}
+	return this
+}
+
+/**
+ * Register the service provider.
+ *
+ * @return void
+ */
+func (this *ConcurrencyServiceProvider) Register() {
+	this.App.Singleton("pool", func(app Container.Container) interface{} {
+		reporter, _ := app.Make("exception.handler").(DebugContract.ExceptionHandler)
+
+		return NewPool(this.getConfig("size", runtime.NumCPU()*4), reporter)
+	})
+
+	// The background work still running is given the time configured through
+	// "concurrency.drain_timeout" to finish while the application terminates.
+	this.App.Terminating(func() {
+		if !this.App.Resolved("pool") {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(this.getConfig("drain_timeout", 30))*time.Second)
+		defer cancel()
+
+		this.App.Make("pool").(*Pool).Shutdown(ctx)
+	})
+}
+
+/**
+ * Get an integer from the "concurrency" configuration.
+ *
+ * @param  string  key
+ * @param  int  _default
+ * @return int
+ */
+func (this *ConcurrencyServiceProvider) getConfig(key string, _default int) int {
+	if config, ok := this.App.Make("config").(RepositoryContract.Repository); ok {
+		switch value := config.Get("concurrency." + key).(type) {
+		case int:
+			return value
+		case int64:
+			return int(value)
+		case float64:
+			return int(value)
+		}
+	}
+	return _default
+}
@@ -0,0 +1,41 @@
+package Concurrency_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/larisgo/framework/Concurrency"
+	"github.com/larisgo/framework/Config"
+	"github.com/larisgo/framework/Foundation"
+)
+
+func TestTerminateDrainsThePool(t *testing.T) {
+	basePath, err := ioutil.TempDir("", "concurrency")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(basePath)
+
+	app := Foundation.NewApplication(basePath)
+	app.Instance("config", Config.NewRepository())
+
+	var finished int64
+	pool := app.Make("pool").(*Concurrency.Pool)
+	pool.Go(func(context.Context) {
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt64(&finished, 1)
+	})
+
+	app.Terminate()
+
+	if atomic.LoadInt64(&finished) != 1 {
+		t.Error("the application terminated before the pool was drained")
+	}
+	if pool.TryGo(func(context.Context) {}) {
+		t.Error("the pool still takes tasks after the application terminated")
+	}
+}
@@ -0,0 +1,231 @@
+package Concurrency
+
+import (
+	"context"
+	"fmt"
+	DebugContract "github.com/larisgo/framework/Contracts/Debug"
+	"github.com/larisgo/framework/Errors"
+	"os"
+	"runtime/debug"
+	"sync"
+)
+
+/**
+ * A pool running background work with bounded concurrency. The tasks share
+ * the context of the pool, which is cancelled once the pool shuts down.
+ */
+type Pool struct {
+	/**
+	 * The number of tasks that may run at the same time.
+	 *
+	 * @var int
+	 */
+	size int
+
+	/**
+	 * The slots taken by the running tasks.
+	 *
+	 * @var chan struct{}
+	 */
+	slots chan struct{}
+
+	/**
+	 * The context of the tasks.
+	 *
+	 * @var context.Context
+	 */
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	/**
+	 * The exception handler panics of the tasks are reported to.
+	 *
+	 * @var DebugContract.ExceptionHandler
+	 */
+	reporter DebugContract.ExceptionHandler
+
+	/**
+	 * Indicates if the pool has stopped taking tasks.
+	 *
+	 * @var bool
+	 */
+	closed bool
+
+	tasks sync.WaitGroup
+	lock  sync.RWMutex
+}
+
+/**
+ * Create a new pool instance.
+ *
+ * @param  int  size
+ * @param  DebugContract.ExceptionHandler  reporter
+ * @return *Pool
+ */
+func NewPool(size int, reporter DebugContract.ExceptionHandler) (this *Pool) {
+	if size < 1 {
+		size = 1
+	}
+
+	this = &Pool{}
+	this.size = size
+	this.slots = make(chan struct{}, size)
+	this.ctx, this.cancel = context.WithCancel(context.Background())
+	this.reporter = reporter
+	return this
+}
+
+/**
+ * Run the task in the background, waiting for a free slot when the pool is
+ * busy. An error is returned when the pool has shut down.
+ *
+ * @param  func(context.Context)  task
+ * @return error
+ */
+func (this *Pool) Go(task func(context.Context)) error {
+	if !this.add() {
+		return Errors.NewRuntimeException("The pool has been shut down.")
+	}
+
+	select {
+	case this.slots <- struct{}{}:
+	case <-this.ctx.Done():
+		this.tasks.Done()
+		return Errors.NewRuntimeException("The pool has been shut down.")
+	}
+
+	go this.run(task)
+
+	return nil
+}
+
+/**
+ * Run the task in the background when a slot is free right away.
+ *
+ * @param  func(context.Context)  task
+ * @return bool
+ */
+func (this *Pool) TryGo(task func(context.Context)) bool {
+	if !this.add() {
+		return false
+	}
+
+	select {
+	case this.slots <- struct{}{}:
+	default:
+		this.tasks.Done()
+		return false
+	}
+
+	go this.run(task)
+
+	return true
+}
+
+/**
+ * Count a task in, unless the pool has shut down.
+ *
+ * @return bool
+ */
+func (this *Pool) add() bool {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	if this.closed {
+		return false
+	}
+	this.tasks.Add(1)
+
+	return true
+}
+
+/**
+ * Run a task in a slot, reporting its panic.
+ *
+ * @param  func(context.Context)  task
+ * @return void
+ */
+func (this *Pool) run(task func(context.Context)) {
+	defer func() {
+		<-this.slots
+		this.tasks.Done()
+	}()
+	defer func() {
+		if err := recover(); err != nil {
+			this.report(err)
+		}
+	}()
+
+	task(this.ctx)
+}
+
+/**
+ * Report the panic of a task.
+ *
+ * @param  interface{}  err
+ * @return void
+ */
+func (this *Pool) report(err interface{}) {
+	if this.reporter != nil {
+		this.reporter.Report(err)
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "%+v\n%s\n", err, debug.Stack())
+}
+
+/**
+ * Get the number of tasks that may run at the same time.
+ *
+ * @return int
+ */
+func (this *Pool) Size() int {
+	return this.size
+}
+
+/**
+ * Get the number of running tasks.
+ *
+ * @return int
+ */
+func (this *Pool) Running() int {
+	return len(this.slots)
+}
+
+/**
+ * Get the context of the tasks.
+ *
+ * @return context.Context
+ */
+func (this *Pool) Context() context.Context {
+	return this.ctx
+}
+
+/**
+ * Stop taking tasks and wait for the running ones to finish. When the given
+ * context is done first, the context of the tasks is cancelled and the error
+ * of the given context is returned without waiting any longer.
+ *
+ * @param  context.Context  ctx
+ * @return error
+ */
+func (this *Pool) Shutdown(ctx context.Context) error {
+	this.lock.Lock()
+	this.closed = true
+	this.lock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		this.tasks.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		this.cancel()
+		return nil
+	case <-ctx.Done():
+		this.cancel()
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,116 @@
+package Concurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordingReporter struct {
+	lock     sync.Mutex
+	reported []interface{}
+}
+
+func (this *recordingReporter) Report(err interface{}) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.reported = append(this.reported, err)
+}
+
+func (this *recordingReporter) ShouldReport(interface{}) bool {
+	return true
+}
+
+func TestPoolBoundsTheRunningTasks(t *testing.T) {
+	pool := NewPool(2, nil)
+	release := make(chan struct{})
+	var running, highest int64
+
+	for i := 0; i < 6; i++ {
+		go pool.Go(func(context.Context) {
+			current := atomic.AddInt64(&running, 1)
+			for {
+				seen := atomic.LoadInt64(&highest)
+				if current <= seen || atomic.CompareAndSwapInt64(&highest, seen, current) {
+					break
+				}
+			}
+			<-release
+			atomic.AddInt64(&running, -1)
+		})
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	if pool.TryGo(func(context.Context) {}) {
+		t.Error("a task was started while every slot was taken")
+	}
+	close(release)
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if highest > 2 {
+		t.Errorf("expected at most 2 tasks at once, got %d", highest)
+	}
+}
+
+func TestShutdownDrainsTheRunningTasks(t *testing.T) {
+	pool := NewPool(4, nil)
+	var finished int64
+	for i := 0; i < 4; i++ {
+		pool.Go(func(context.Context) {
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt64(&finished, 1)
+		})
+	}
+
+	if err := pool.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if finished != 4 {
+		t.Errorf("expected the 4 tasks to finish before the shutdown returned, %d did", finished)
+	}
+	if pool.Go(func(context.Context) {}) == nil || pool.TryGo(func(context.Context) {}) {
+		t.Error("a task was accepted after the shutdown")
+	}
+}
+
+func TestShutdownCancelsTheTasksWhenItTimesOut(t *testing.T) {
+	pool := NewPool(1, nil)
+	cancelled := make(chan struct{})
+	pool.Go(func(ctx context.Context) {
+		<-ctx.Done()
+		close(cancelled)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := pool.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected the deadline to be exceeded, got %v", err)
+	}
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("the context of the task was not cancelled")
+	}
+}
+
+func TestPanicOfTaskIsReported(t *testing.T) {
+	reporter := &recordingReporter{}
+	pool := NewPool(1, reporter)
+	pool.Go(func(context.Context) {
+		panic("boom")
+	})
+	pool.Shutdown(context.Background())
+
+	if len(reporter.reported) != 1 || reporter.reported[0] != "boom" {
+		t.Errorf("expected the panic to be reported, got %v", reporter.reported)
+	}
+	if pool.Running() != 0 {
+		t.Error("the slot of the panicking task was not freed")
+	}
+}
@@ -0,0 +1,242 @@
+package Console
+
+import (
+	"flag"
+	"fmt"
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Errors"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+)
+
+/**
+ * The console application bootstrappers.
+ *
+ * @var []func(*Application)
+ */
+var bootstrappers []func(*Application)
+
+type Application struct {
+	/**
+	 * The container instance.
+	 *
+	 * @var Container.Container
+	 */
+	container Container.Container
+
+	/**
+	 * The version of the application.
+	 *
+	 * @var string
+	 */
+	version string
+
+	/**
+	 * The registered commands keyed by name.
+	 *
+	 * @var map[string]CommandT
+	 */
+	commands map[string]CommandT
+
+	/**
+	 * The output streams of the application.
+	 *
+	 * @var io.Writer
+	 */
+	Output      io.Writer
+	ErrorOutput io.Writer
+}
+
+/**
+ * Create a new console application.
+ *
+ * @param  Container.Container  container
+ * @param  string  version
+ * @return *Application
+ */
+func NewApplication(container Container.Container, version string) (this *Application) {
+	this = &Application{}
+	this.container = container
+	this.version = version
+	this.commands = map[string]CommandT{}
+	this.Output = os.Stdout
+	this.ErrorOutput = os.Stderr
+
+	this.bootstrap()
+
+	return this
+}
+
+/**
+ * Register a console "starting" bootstrapper.
+ *
+ * @param  func(*Application)  callback
+ * @return void
+ */
+func Starting(callback func(*Application)) {
+	bootstrappers = append(bootstrappers, callback)
+}
+
+/**
+ * Clear the console application bootstrappers.
+ *
+ * @return void
+ */
+func ForgetBootstrappers() {
+	bootstrappers = nil
+}
+
+/**
+ * Bootstrap the console application.
+ *
+ * @return void
+ */
+func (this *Application) bootstrap() {
+	for _, bootstrapper := range bootstrappers {
+		bootstrapper(this)
+	}
+}
+
+/**
+ * Add a command to the console.
+ *
+ * @param  CommandT  command
+ * @return CommandT
+ */
+func (this *Application) Add(command CommandT) CommandT {
+	command.SetApplication(this)
+	this.commands[command.GetName()] = command
+
+	return command
+}
+
+/**
+ * Add a command, resolving its dependencies through the container.
+ *
+ * @param  interface{}  command
+ * @return CommandT
+ */
+func (this *Application) Resolve(command interface{}) CommandT {
+	if abstract, ok := command.(string); ok {
+		command = this.container.Make(abstract)
+	} else if this.container != nil {
+		command = this.container.Build(command, reflect.TypeOf(command).String())
+	}
+
+	if _command, ok := command.(CommandT); ok {
+		return this.Add(_command)
+	}
+
+	panic(Errors.NewInvalidArgumentException(fmt.Sprintf("Command [%T] is not a console command.", command)))
+}
+
+/**
+ * Resolve an array of commands through the container.
+ *
+ * @param  interface{}  commands
+ * @return this
+ */
+func (this *Application) ResolveCommands(commands ...interface{}) *Application {
+	for _, command := range commands {
+		this.Resolve(command)
+	}
+
+	return this
+}
+
+/**
+ * Determine if the given command exists.
+ *
+ * @param  string  name
+ * @return bool
+ */
+func (this *Application) Has(name string) bool {
+	_, ok := this.commands[name]
+	return ok
+}
+
+/**
+ * Get all of the registered commands keyed by name.
+ *
+ * @return map[string]CommandT
+ */
+func (this *Application) All() map[string]CommandT {
+	return this.commands
+}
+
+/**
+ * Run the console application with the given arguments.
+ *
+ * The first argument is the name of the command to run, the remaining
+ * arguments are parsed as the command's options and arguments.
+ *
+ * @param  []string  args
+ * @return int
+ */
+func (this *Application) Run(args []string) int {
+	if len(args) == 0 || args[0] == "list" {
+		this.renderList()
+		return 0
+	}
+
+	return this.Call(args[0], args[1:]...)
+}
+
+/**
+ * Run a console command by name.
+ *
+ * @param  string  name
+ * @param  string  args
+ * @return int
+ */
+func (this *Application) Call(name string, args ...string) (status int) {
+	command, ok := this.commands[name]
+	if !ok {
+		fmt.Fprintf(this.ErrorOutput, "Command \"%s\" is not defined.\n", name)
+		return 1
+	}
+
+	input := flag.NewFlagSet(name, flag.ContinueOnError)
+	input.SetOutput(this.ErrorOutput)
+	if c, ok := command.(ConfigureT); ok {
+		c.Configure(input)
+	}
+	if err := input.Parse(args); err != nil {
+		return 1
+	}
+	command.SetInput(input)
+
+	defer func() {
+		if err := recover(); err != nil {
+			fmt.Fprintf(this.ErrorOutput, "%+v\n", err)
+			status = 1
+		}
+	}()
+
+	return command.Handle()
+}
+
+/**
+ * Render the list of available commands.
+ *
+ * @return void
+ */
+func (this *Application) renderList() {
+	fmt.Fprintf(this.Output, "larisgo framework %s\n\nAvailable commands:\n", this.version)
+
+	names := []string{}
+	width := 0
+	for name, _ := range this.commands {
+		names = append(names, name)
+		if len(name) > width {
+			width = len(name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fmt.Fprintf(this.Output, "  %-*s  %s\n", width, name, this.commands[name].GetDescription())
+	}
+}
@@ -0,0 +1,181 @@
+package Console
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+type CommandT interface {
+	GetName() string
+	GetDescription() string
+	SetApplication(*Application)
+	SetInput(*flag.FlagSet)
+	Handle() int
+}
+
+type ConfigureT interface {
+	Configure(*flag.FlagSet)
+}
+
+type Command struct {
+	/**
+	 * The name of the console command.
+	 *
+	 * @var string
+	 */
+	Name string
+
+	/**
+	 * The console command description.
+	 *
+	 * @var string
+	 */
+	Description string
+
+	/**
+	 * The output streams of the command.
+	 *
+	 * @var io.Writer
+	 */
+	Output      io.Writer
+	ErrorOutput io.Writer
+
+	application *Application
+	input       *flag.FlagSet
+}
+
+func NewCommand(name string, description string) (this *Command) {
+	this = &Command{}
+	this.Name = name
+	this.Description = description
+	this.Output = os.Stdout
+	this.ErrorOutput = os.Stderr
+	return this
+}
+
+/**
+ * Get the name of the console command.
+ *
+ * @return string
+ */
+func (this *Command) GetName() string {
+	return this.Name
+}
+
+/**
+ * Get the console command description.
+ *
+ * @return string
+ */
+func (this *Command) GetDescription() string {
+	return this.Description
+}
+
+/**
+ * Set the console application instance.
+ *
+ * @param  Console.Application  application
+ * @return void
+ */
+func (this *Command) SetApplication(application *Application) {
+	this.application = application
+}
+
+/**
+ * Get the console application instance.
+ *
+ * @return *Console.Application
+ */
+func (this *Command) GetApplication() *Application {
+	return this.application
+}
+
+/**
+ * Set the parsed input of the command.
+ *
+ * @param  flag.FlagSet  input
+ * @return void
+ */
+func (this *Command) SetInput(input *flag.FlagSet) {
+	this.input = input
+}
+
+/**
+ * Get all of the arguments passed to the command.
+ *
+ * @return []string
+ */
+func (this *Command) Arguments() []string {
+	if this.input == nil {
+		return []string{}
+	}
+	return this.input.Args()
+}
+
+/**
+ * Get the value of a command argument.
+ *
+ * @param  int  index
+ * @param  string  default
+ * @return string
+ */
+func (this *Command) Argument(index int, _default ...string) string {
+	_default = append(_default, "")
+	if arguments := this.Arguments(); index < len(arguments) {
+		return arguments[index]
+	}
+	return _default[0]
+}
+
+/**
+ * Call another console command.
+ *
+ * @param  string  command
+ * @param  string  arguments
+ * @return int
+ */
+func (this *Command) Call(command string, arguments ...string) int {
+	return this.application.Call(command, arguments...)
+}
+
+/**
+ * Write a string as standard output.
+ *
+ * @param  string  message
+ * @return void
+ */
+func (this *Command) Line(message string) {
+	fmt.Fprintln(this.Output, message)
+}
+
+/**
+ * Write a string as information output.
+ *
+ * @param  string  message
+ * @return void
+ */
+func (this *Command) Info(message string) {
+	fmt.Fprintf(this.Output, "\033[32m%s\033[0m\n", message)
+}
+
+/**
+ * Write a string as comment output.
+ *
+ * @param  string  message
+ * @return void
+ */
+func (this *Command) Comment(message string) {
+	fmt.Fprintf(this.Output, "\033[33m%s\033[0m\n", message)
+}
+
+/**
+ * Write a string as error output.
+ *
+ * @param  string  message
+ * @return void
+ */
+func (this *Command) Error(message string) {
+	fmt.Fprintf(this.ErrorOutput, "\033[31m%s\033[0m\n", message)
+}
@@ -0,0 +1,17 @@
+package Cache
+
+type Store interface {
+	Get(string) interface{}
+	Put(string, interface{}, int) bool
+	Add(string, interface{}, int) bool
+	Increment(string, ...int) int
+	Decrement(string, ...int) int
+	Forever(string, interface{}) bool
+	Forget(string) bool
+	Flush() bool
+}
+
+type CounterStore interface {
+	Store
+	IncrementWithin(string, int, int) (int, bool)
+}
@@ -12,4 +12,6 @@ type Container interface {
 	Alias(string, string)
 
 	Build(interface{}, string) interface{}
+
+	Resolved(string) bool
 }
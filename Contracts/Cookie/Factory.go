@@ -0,0 +1,34 @@
+package Cookie
+
+import (
+	"net/http"
+)
+
+type Factory interface {
+	/**
+	 * Create a new cookie instance.
+	 *
+	 * @param  string  name
+	 * @param  string  value
+	 * @param  int  minutes
+	 * @return *http.Cookie
+	 */
+	Make(name string, value string, minutes int) *http.Cookie
+
+	/**
+	 * Create a cookie that lasts "forever" (five years).
+	 *
+	 * @param  string  name
+	 * @param  string  value
+	 * @return *http.Cookie
+	 */
+	Forever(name string, value string) *http.Cookie
+
+	/**
+	 * Expire the given cookie.
+	 *
+	 * @param  string  name
+	 * @return *http.Cookie
+	 */
+	Forget(name string) *http.Cookie
+}
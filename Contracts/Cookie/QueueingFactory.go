@@ -0,0 +1,33 @@
+package Cookie
+
+import (
+	"net/http"
+)
+
+type QueueingFactory interface {
+	Factory
+
+	/**
+	 * Queue a cookie to send with the next response.
+	 *
+	 * @param  *http.Cookie  cookie
+	 * @return void
+	 */
+	Queue(cookie *http.Cookie)
+
+	/**
+	 * Remove a cookie from the queue.
+	 *
+	 * @param  string  name
+	 * @param  string  path
+	 * @return void
+	 */
+	Unqueue(name string, path ...string)
+
+	/**
+	 * Get the cookies which have been queued for the next request.
+	 *
+	 * @return []*http.Cookie
+	 */
+	GetQueuedCookies() []*http.Cookie
+}
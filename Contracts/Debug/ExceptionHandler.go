@@ -0,0 +1,19 @@
+package Debug
+
+type ExceptionHandler interface {
+	/**
+	 * Report or log an exception.
+	 *
+	 * @param  interface{}  e
+	 * @return void
+	 */
+	Report(interface{})
+
+	/**
+	 * Determine if the exception should be reported.
+	 *
+	 * @param  interface{}  e
+	 * @return bool
+	 */
+	ShouldReport(interface{}) bool
+}
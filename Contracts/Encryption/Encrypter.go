@@ -0,0 +1,42 @@
+package Encryption
+
+type Encrypter interface {
+	/**
+	 * Encrypt the given value.
+	 *
+	 * @param  []byte  value
+	 * @return string, error
+	 */
+	Encrypt(value []byte) (string, error)
+
+	/**
+	 * Decrypt the given value.
+	 *
+	 * @param  string  payload
+	 * @return []byte, error
+	 */
+	Decrypt(payload string) ([]byte, error)
+
+	/**
+	 * Encrypt a string.
+	 *
+	 * @param  string  value
+	 * @return string, error
+	 */
+	EncryptString(value string) (string, error)
+
+	/**
+	 * Decrypt the given string.
+	 *
+	 * @param  string  payload
+	 * @return string, error
+	 */
+	DecryptString(payload string) (string, error)
+
+	/**
+	 * Get the encryption key that the encrypter is currently using.
+	 *
+	 * @return []byte
+	 */
+	GetKey() []byte
+}
@@ -0,0 +1,54 @@
+package Events
+
+type Dispatcher interface {
+	/**
+	 * Register an event listener with the dispatcher.
+	 *
+	 * @param  string|[]string|interface{}  events
+	 * @param  interface{}  listener
+	 * @return void
+	 */
+	Listen(interface{}, interface{})
+
+	/**
+	 * Determine if a given event has listeners.
+	 *
+	 * @param  string  eventName
+	 * @return bool
+	 */
+	HasListeners(string) bool
+
+	/**
+	 * Register an event subscriber with the dispatcher.
+	 *
+	 * @param  interface{}  subscriber
+	 * @return void
+	 */
+	Subscribe(interface{})
+
+	/**
+	 * Dispatch an event until the first non-nil response is returned.
+	 *
+	 * @param  string|interface{}  event
+	 * @param  interface{}  payload
+	 * @return interface{}
+	 */
+	Until(interface{}, ...interface{}) interface{}
+
+	/**
+	 * Dispatch an event and call the listeners.
+	 *
+	 * @param  string|interface{}  event
+	 * @param  interface{}  payload
+	 * @return []interface{}
+	 */
+	Dispatch(interface{}, ...interface{}) []interface{}
+
+	/**
+	 * Remove a set of listeners from the dispatcher.
+	 *
+	 * @param  string  event
+	 * @return void
+	 */
+	Forget(string)
+}
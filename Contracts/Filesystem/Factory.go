@@ -0,0 +1,11 @@
+package Filesystem
+
+type Factory interface {
+	/**
+	 * Get a filesystem implementation.
+	 *
+	 * @param  string  name
+	 * @return Filesystem
+	 */
+	Disk(...string) Filesystem
+}
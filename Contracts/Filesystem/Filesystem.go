@@ -0,0 +1,65 @@
+package Filesystem
+
+import (
+	"io"
+)
+
+type Filesystem interface {
+	/**
+	 * Determine if a file exists.
+	 *
+	 * @param  string  path
+	 * @return bool
+	 */
+	Exists(string) bool
+
+	/**
+	 * Get the contents of a file.
+	 *
+	 * @param  string  path
+	 * @return []byte, error
+	 */
+	Get(string) ([]byte, error)
+
+	/**
+	 * Write the contents of a file.
+	 *
+	 * @param  string  path
+	 * @param  []byte  contents
+	 * @return error
+	 */
+	Put(string, []byte) error
+
+	/**
+	 * Write the contents of a file from a stream.
+	 *
+	 * @param  string  path
+	 * @param  io.Reader  resource
+	 * @return error
+	 */
+	PutStream(string, io.Reader) error
+
+	/**
+	 * Delete the file at the given paths.
+	 *
+	 * @param  string  paths
+	 * @return error
+	 */
+	Delete(...string) error
+
+	/**
+	 * Get the file size of a given file.
+	 *
+	 * @param  string  path
+	 * @return int64, error
+	 */
+	Size(string) (int64, error)
+
+	/**
+	 * Create a directory.
+	 *
+	 * @param  string  path
+	 * @return error
+	 */
+	MakeDirectory(string) error
+}
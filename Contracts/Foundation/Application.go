@@ -21,6 +21,30 @@ type Application interface {
 	 */
 	BasePath(...string) string
 
+	/**
+	 * Get the path to the resources directory.
+	 *
+	 * @param  string  path
+	 * @return string
+	 */
+	ResourcePath(...string) string
+
+	/**
+	 * Get the path to the public directory.
+	 *
+	 * @param  string  path
+	 * @return string
+	 */
+	PublicPath(...string) string
+
+	/**
+	 * Get the path to the storage directory.
+	 *
+	 * @param  string  path
+	 * @return string
+	 */
+	StoragePath(...string) string
+
 	/**
 	 * Get or check the current application environment.
 	 *
@@ -98,8 +122,37 @@ type Application interface {
 	 */
 	Booted(func(interface{}))
 
+	/**
+	 * Register a terminating callback with the application.
+	 *
+	 * @param  callable  callback
+	 * @return void
+	 */
+	Terminating(func())
+
+	/**
+	 * Terminate the application.
+	 *
+	 * @return void
+	 */
+	Terminate()
+
 	RegisterConfiguredProviders()
 
+	/**
+	 * Determine if the application routes are cached.
+	 *
+	 * @return bool
+	 */
+	RoutesAreCached() bool
+
+	/**
+	 * Get the path to the routes cache file.
+	 *
+	 * @return string
+	 */
+	GetCachedRoutesPath() string
+
 	/**
 	 * Get the path to the cached services.php file.
 	 *
@@ -0,0 +1,57 @@
+package Queue
+
+import (
+	"time"
+)
+
+type FailedJob struct {
+	Id         string    `json:"id"`
+	Connection string    `json:"connection"`
+	Queue      string    `json:"queue"`
+	Payload    string    `json:"payload"`
+	Exception  string    `json:"exception"`
+	FailedAt   time.Time `json:"failed_at"`
+}
+
+type FailedJobProvider interface {
+	/**
+	 * Log a failed job into storage.
+	 *
+	 * @param  string  connection
+	 * @param  string  queue
+	 * @param  string  payload
+	 * @param  error  exception
+	 * @return string
+	 */
+	Log(string, string, string, error) string
+
+	/**
+	 * Get a list of all of the failed jobs, the oldest first.
+	 *
+	 * @return []FailedJob
+	 */
+	All() []FailedJob
+
+	/**
+	 * Get a single failed job.
+	 *
+	 * @param  string  id
+	 * @return FailedJob, bool
+	 */
+	Find(string) (FailedJob, bool)
+
+	/**
+	 * Delete a single failed job from storage.
+	 *
+	 * @param  string  id
+	 * @return bool
+	 */
+	Forget(string) bool
+
+	/**
+	 * Flush all of the failed jobs from storage.
+	 *
+	 * @return void
+	 */
+	Flush()
+}
@@ -0,0 +1,137 @@
+package Queue
+
+import (
+	"context"
+	"time"
+)
+
+type Job interface {
+	/**
+	 * Get the job identifier.
+	 *
+	 * @return string
+	 */
+	GetJobId() string
+
+	/**
+	 * Get the raw body of the job.
+	 *
+	 * @return string
+	 */
+	GetRawBody() string
+
+	/**
+	 * Fire the job.
+	 *
+	 * @param  context.Context  ctx
+	 * @return error
+	 */
+	Fire(context.Context) error
+
+	/**
+	 * Release the job back into the queue after the given delay.
+	 *
+	 * @param  time.Duration  delay
+	 * @return error
+	 */
+	Release(time.Duration) error
+
+	/**
+	 * Determine if the job was released back into the queue.
+	 *
+	 * @return bool
+	 */
+	IsReleased() bool
+
+	/**
+	 * Delete the job from the queue.
+	 *
+	 * @return error
+	 */
+	Delete() error
+
+	/**
+	 * Determine if the job has been deleted.
+	 *
+	 * @return bool
+	 */
+	IsDeleted() bool
+
+	/**
+	 * Determine if the job has been deleted or released.
+	 *
+	 * @return bool
+	 */
+	IsDeletedOrReleased() bool
+
+	/**
+	 * Get the number of times the job has been attempted.
+	 *
+	 * @return int
+	 */
+	Attempts() int
+
+	/**
+	 * Determine if the job has been marked as a failure.
+	 *
+	 * @return bool
+	 */
+	HasFailed() bool
+
+	/**
+	 * Mark the job as "failed".
+	 *
+	 * @return void
+	 */
+	MarkAsFailed()
+
+	/**
+	 * Delete the job, call the "failed" hook of the job and mark it as failed.
+	 *
+	 * @param  error  err
+	 * @return void
+	 */
+	Fail(error)
+
+	/**
+	 * Get the number of times to attempt a job, zero when the worker decides.
+	 *
+	 * @return int
+	 */
+	MaxTries() int
+
+	/**
+	 * Get the number of seconds to wait before retrying a job.
+	 *
+	 * @return []int
+	 */
+	Backoff() []int
+
+	/**
+	 * Get the time the job may run, zero when the worker decides.
+	 *
+	 * @return time.Duration
+	 */
+	Timeout() time.Duration
+
+	/**
+	 * Get the name of the queued job.
+	 *
+	 * @return string
+	 */
+	GetName() string
+
+	/**
+	 * Get the name of the connection the job belongs to.
+	 *
+	 * @return string
+	 */
+	GetConnectionName() string
+
+	/**
+	 * Get the name of the queue the job belongs to.
+	 *
+	 * @return string
+	 */
+	GetQueue() string
+}
@@ -0,0 +1,66 @@
+package Queue
+
+import (
+	"time"
+)
+
+type Queue interface {
+	/**
+	 * Get the size of the queue.
+	 *
+	 * @param  string  queue
+	 * @return int
+	 */
+	Size(...string) int
+
+	/**
+	 * Push a new job onto the queue.
+	 *
+	 * @param  ShouldQueue  job
+	 * @param  string  queue
+	 * @return string, error
+	 */
+	Push(ShouldQueue, ...string) (string, error)
+
+	/**
+	 * Push a raw payload onto the queue.
+	 *
+	 * @param  string  payload
+	 * @param  string  queue
+	 * @return string, error
+	 */
+	PushRaw(string, ...string) (string, error)
+
+	/**
+	 * Push a new job onto the queue after a delay.
+	 *
+	 * @param  time.Duration  delay
+	 * @param  ShouldQueue  job
+	 * @param  string  queue
+	 * @return string, error
+	 */
+	Later(time.Duration, ShouldQueue, ...string) (string, error)
+
+	/**
+	 * Pop the next job off of the queue, nil when the queue is empty.
+	 *
+	 * @param  string  queue
+	 * @return Job, error
+	 */
+	Pop(...string) (Job, error)
+
+	/**
+	 * Get the connection name for the queue.
+	 *
+	 * @return string
+	 */
+	GetConnectionName() string
+
+	/**
+	 * Set the connection name for the queue.
+	 *
+	 * @param  string  name
+	 * @return void
+	 */
+	SetConnectionName(string)
+}
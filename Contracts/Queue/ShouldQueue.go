@@ -0,0 +1,20 @@
+package Queue
+
+import (
+	"context"
+)
+
+/**
+ * A job that may be pushed onto a queue. The exported fields of the job are
+ * serialised into its payload, so they are all the job may rely on once it
+ * is taken off the queue by a worker.
+ */
+type ShouldQueue interface {
+	/**
+	 * Execute the job.
+	 *
+	 * @param  context.Context  ctx
+	 * @return error
+	 */
+	Handle(context.Context) error
+}
@@ -0,0 +1,254 @@
+package Cookie
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type CookieJar struct {
+	/**
+	 * The default path (if specified).
+	 *
+	 * @var string
+	 */
+	path string
+
+	/**
+	 * The default domain (if specified).
+	 *
+	 * @var string
+	 */
+	domain string
+
+	/**
+	 * The default secure setting (defaults to false).
+	 *
+	 * @var bool
+	 */
+	secure bool
+
+	/**
+	 * The default SameSite option (defaults to lax).
+	 *
+	 * @var http.SameSite
+	 */
+	sameSite http.SameSite
+
+	/**
+	 * All of the cookies queued for sending.
+	 *
+	 * @var []*http.Cookie
+	 */
+	queued []*http.Cookie
+
+	lock sync.Mutex
+}
+
+/**
+ * Create a new cookie jar instance.
+ *
+ * @return *CookieJar
+ */
+func NewCookieJar() (this *CookieJar) {
+	this = &CookieJar{}
+	this.path = "/"
+	this.sameSite = http.SameSiteLaxMode
+	this.queued = []*http.Cookie{}
+	return this
+}
+
+/**
+ * Create a new cookie instance.
+ *
+ * @param  string  name
+ * @param  string  value
+ * @param  int  minutes
+ * @return *http.Cookie
+ */
+func (this *CookieJar) Make(name string, value string, minutes int) *http.Cookie {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	cookie := &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     this.path,
+		Domain:   this.domain,
+		Secure:   this.secure,
+		HttpOnly: true,
+		SameSite: this.sameSite,
+	}
+
+	// A cookie without minutes lasts as long as the browser session, a cookie
+	// with negative minutes is expired and deleted by the browser at once.
+	if minutes != 0 {
+		cookie.Expires = time.Now().Add(time.Duration(minutes) * time.Minute)
+		cookie.MaxAge = minutes * 60
+		if minutes < 0 {
+			cookie.MaxAge = -1
+		}
+	}
+
+	return cookie
+}
+
+/**
+ * Create a cookie that lasts "forever" (five years).
+ *
+ * @param  string  name
+ * @param  string  value
+ * @return *http.Cookie
+ */
+func (this *CookieJar) Forever(name string, value string) *http.Cookie {
+	return this.Make(name, value, 2628000)
+}
+
+/**
+ * Expire the given cookie.
+ *
+ * @param  string  name
+ * @return *http.Cookie
+ */
+func (this *CookieJar) Forget(name string) *http.Cookie {
+	return this.Make(name, "", -2628000)
+}
+
+/**
+ * Determine if a cookie has been queued.
+ *
+ * @param  string  key
+ * @param  string  path
+ * @return bool
+ */
+func (this *CookieJar) HasQueued(key string, path ...string) bool {
+	return this.Queued(key, path...) != nil
+}
+
+/**
+ * Get a queued cookie instance.
+ *
+ * @param  string  key
+ * @param  string  path
+ * @return *http.Cookie
+ */
+func (this *CookieJar) Queued(key string, path ...string) *http.Cookie {
+	path = append(path, "")
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	for i := len(this.queued) - 1; i >= 0; i-- {
+		if cookie := this.queued[i]; cookie.Name == key && (path[0] == "" || cookie.Path == path[0]) {
+			return cookie
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Queue a cookie to send with the next response. A cookie queued again with
+ * the same name and path replaces the one queued before.
+ *
+ * @param  *http.Cookie  cookie
+ * @return void
+ */
+func (this *CookieJar) Queue(cookie *http.Cookie) {
+	this.Unqueue(cookie.Name, cookie.Path)
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.queued = append(this.queued, cookie)
+}
+
+/**
+ * Queue a cookie to expire with the next response.
+ *
+ * @param  string  name
+ * @return void
+ */
+func (this *CookieJar) Expire(name string) {
+	this.Queue(this.Forget(name))
+}
+
+/**
+ * Remove a cookie from the queue.
+ *
+ * @param  string  name
+ * @param  string  path
+ * @return void
+ */
+func (this *CookieJar) Unqueue(name string, path ...string) {
+	path = append(path, "")
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	queued := []*http.Cookie{}
+	for _, cookie := range this.queued {
+		if cookie.Name != name || (path[0] != "" && cookie.Path != path[0]) {
+			queued = append(queued, cookie)
+		}
+	}
+	this.queued = queued
+}
+
+/**
+ * Set the default path and domain for the jar.
+ *
+ * @param  string  path
+ * @param  string  domain
+ * @param  bool  secure
+ * @param  string  sameSite
+ * @return *CookieJar
+ */
+func (this *CookieJar) SetDefaultPathAndDomain(path string, domain string, secure bool, sameSite ...string) *CookieJar {
+	sameSite = append(sameSite, "lax")
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.path, this.domain, this.secure = path, domain, secure
+
+	switch strings.ToLower(sameSite[0]) {
+	case "strict":
+		this.sameSite = http.SameSiteStrictMode
+	case "none":
+		this.sameSite = http.SameSiteNoneMode
+	case "":
+		this.sameSite = http.SameSiteDefaultMode
+	default:
+		this.sameSite = http.SameSiteLaxMode
+	}
+
+	return this
+}
+
+/**
+ * Get the cookies which have been queued for the next request.
+ *
+ * @return []*http.Cookie
+ */
+func (this *CookieJar) GetQueuedCookies() []*http.Cookie {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	return append([]*http.Cookie{}, this.queued...)
+}
+
+/**
+ * Flush the cookies which have been queued for the next request.
+ *
+ * @return *CookieJar
+ */
+func (this *CookieJar) FlushQueuedCookies() *CookieJar {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.queued = []*http.Cookie{}
+
+	return this
+}
@@ -0,0 +1,57 @@
+package Cookie
+
+import (
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	"github.com/larisgo/framework/Contracts/Container"
+	CookieContract "github.com/larisgo/framework/Contracts/Cookie"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Support"
+)
+
+type CookieServiceProvider struct {
+	*Support.ServiceProvider
+}
+
+func NewCookieServiceProvider(app Foundation.Application) (this *CookieServiceProvider) {
+	this = &CookieServiceProvider{ServiceProvider: Support.NewServiceProvider(app)}
+	return this
+}
+
+/**
+ * Register the service provider.
+ *
+ * @return void
+ */
+func (this *CookieServiceProvider) Register() {
+	this.App.Singleton("cookie", func(app Container.Container) interface{} {
+		return this.newCookieJar(app)
+	})
+
+	// The jars queuing cookies are not shared, each request makes a jar of its
+	// own to keep the cookies of concurrent requests apart.
+	this.App.Singleton("cookie.jar", func(app Container.Container) interface{} {
+		return func() CookieContract.QueueingFactory {
+			return this.newCookieJar(app)
+		}
+	})
+}
+
+/**
+ * Create a cookie jar with the defaults from the "session" configuration.
+ *
+ * @param  Container.Container  app
+ * @return *CookieJar
+ */
+func (this *CookieServiceProvider) newCookieJar(app Container.Container) *CookieJar {
+	jar := NewCookieJar()
+
+	if config, ok := app.Make("config").(RepositoryContract.Repository); ok {
+		path, _ := config.Get("session.path", "/").(string)
+		domain, _ := config.Get("session.domain", "").(string)
+		secure, _ := config.Get("session.secure", false).(bool)
+		sameSite, _ := config.Get("session.same_site", "lax").(string)
+		jar.SetDefaultPathAndDomain(path, domain, secure, sameSite)
+	}
+
+	return jar
+}
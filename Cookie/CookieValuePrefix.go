@@ -0,0 +1,61 @@
+package Cookie
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"strings"
+)
+
+type cookieValuePrefix struct {
+}
+
+var CookieValuePrefix func() *cookieValuePrefix = func() *cookieValuePrefix {
+	return &cookieValuePrefix{}
+}
+
+/**
+ * Create a new cookie value prefix for the given cookie name.
+ *
+ * @param  string  cookieName
+ * @param  []byte  key
+ * @return string
+ */
+func (this *cookieValuePrefix) Create(cookieName string, key []byte) string {
+	mac := hmac.New(sha1.New, key)
+	mac.Write([]byte(cookieName + "v2"))
+
+	return hex.EncodeToString(mac.Sum(nil)) + "|"
+}
+
+/**
+ * Remove the cookie value prefix.
+ *
+ * @param  string  cookieValue
+ * @return string
+ */
+func (this *cookieValuePrefix) Remove(cookieValue string) string {
+	if len(cookieValue) < 41 {
+		return ""
+	}
+
+	return cookieValue[41:]
+}
+
+/**
+ * Validate a cookie value contains a valid prefix. If it does, return the
+ * cookie value with the prefix removed.
+ *
+ * @param  string  cookieName
+ * @param  string  cookieValue
+ * @param  []byte  key
+ * @return string, bool
+ */
+func (this *cookieValuePrefix) Validate(cookieName string, cookieValue string, key []byte) (string, bool) {
+	prefix := this.Create(cookieName, key)
+	if !strings.HasPrefix(cookieValue, prefix) || !hmac.Equal([]byte(cookieValue[:len(prefix)]), []byte(prefix)) {
+		return "", false
+	}
+
+	return this.Remove(cookieValue), true
+}
@@ -0,0 +1,27 @@
+package Middleware
+
+import (
+	"github.com/larisgo/framework/Http"
+)
+
+type AddQueuedCookiesToResponse struct {
+}
+
+/**
+ * Handle an incoming request.
+ *
+ * @param  Http.Request  request
+ * @param  Http.Next  next
+ * @return Http.Response
+ */
+func (this *AddQueuedCookiesToResponse) Handle(request *Http.Request, next Http.Next, parameters ...string) *Http.Response {
+	response := next(request)
+
+	if jar := request.CookieJar(); jar != nil {
+		for _, cookie := range jar.GetQueuedCookies() {
+			response.WithCookie(cookie)
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,149 @@
+package Middleware
+
+import (
+	"github.com/larisgo/framework/Contracts/Encryption"
+	"github.com/larisgo/framework/Cookie"
+	"github.com/larisgo/framework/Http"
+	"net/http"
+)
+
+type EncryptCookies struct {
+	Encrypter Encryption.Encrypter `inject:"encrypter"`
+
+	/**
+	 * The names of the cookies that should not be encrypted.
+	 *
+	 * @var []string
+	 */
+	Except []string
+}
+
+/**
+ * Disable encryption for the given cookie name(s).
+ *
+ * @param  string  name
+ * @return void
+ */
+func (this *EncryptCookies) DisableFor(name ...string) {
+	this.Except = append(this.Except, name...)
+}
+
+/**
+ * Handle an incoming request.
+ *
+ * @param  Http.Request  request
+ * @param  Http.Next  next
+ * @return Http.Response
+ */
+func (this *EncryptCookies) Handle(request *Http.Request, next Http.Next, parameters ...string) *Http.Response {
+	return this.encrypt(next(this.decrypt(request)))
+}
+
+/**
+ * Decrypt the cookies on the request. Cookies that fail to decrypt, or were
+ * encrypted for another cookie name, are removed from the request.
+ *
+ * @param  Http.Request  request
+ * @return Http.Request
+ */
+func (this *EncryptCookies) decrypt(request *Http.Request) *Http.Request {
+	for _, name := range request.Cookies.Keys() {
+		if this.IsDisabled(name) {
+			continue
+		}
+
+		values := []string{}
+		for _, value := range request.Cookies.Gets(name) {
+			if decrypted, ok := this.decryptCookie(name, value); ok {
+				values = append(values, decrypted)
+			}
+		}
+
+		request.Cookies.Remove(name)
+		for _, value := range values {
+			request.Cookies.Add(name, value)
+		}
+	}
+
+	return request
+}
+
+/**
+ * Decrypt the given cookie and return the value.
+ *
+ * @param  string  name
+ * @param  string  cookie
+ * @return string, bool
+ */
+func (this *EncryptCookies) decryptCookie(name string, cookie string) (string, bool) {
+	decrypted, err := this.Encrypter.DecryptString(cookie)
+	if err != nil {
+		return "", false
+	}
+
+	return Cookie.CookieValuePrefix().Validate(name, decrypted, this.Encrypter.GetKey())
+}
+
+/**
+ * Encrypt the cookies on an outgoing response.
+ *
+ * @param  Http.Response  response
+ * @return Http.Response
+ */
+func (this *EncryptCookies) encrypt(response *Http.Response) *Http.Response {
+	for _, cookie := range response.GetCookies() {
+		if this.IsDisabled(cookie.Name) {
+			continue
+		}
+
+		response.WithCookie(this.duplicate(cookie, this.encryptValue(cookie)))
+	}
+
+	return response
+}
+
+/**
+ * Encrypt the value of the given cookie, bound to the name of the cookie.
+ *
+ * @param  *http.Cookie  cookie
+ * @return string
+ */
+func (this *EncryptCookies) encryptValue(cookie *http.Cookie) string {
+	encrypted, err := this.Encrypter.EncryptString(Cookie.CookieValuePrefix().Create(cookie.Name, this.Encrypter.GetKey()) + cookie.Value)
+	if err != nil {
+		panic(err)
+	}
+
+	return encrypted
+}
+
+/**
+ * Duplicate a cookie with a new value. The cookies queued in a jar are shared,
+ * so they are never changed in place.
+ *
+ * @param  *http.Cookie  cookie
+ * @param  string  value
+ * @return *http.Cookie
+ */
+func (this *EncryptCookies) duplicate(cookie *http.Cookie, value string) *http.Cookie {
+	duplicate := *cookie
+	duplicate.Value = value
+
+	return &duplicate
+}
+
+/**
+ * Determine whether encryption has been disabled for the given cookie.
+ *
+ * @param  string  name
+ * @return bool
+ */
+func (this *EncryptCookies) IsDisabled(name string) bool {
+	for _, except := range this.Except {
+		if except == name {
+			return true
+		}
+	}
+
+	return false
+}
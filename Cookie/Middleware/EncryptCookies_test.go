@@ -0,0 +1,84 @@
+package Middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larisgo/framework/Encryption"
+	"github.com/larisgo/framework/Http"
+)
+
+func newEncryptCookies() *EncryptCookies {
+	middleware := &EncryptCookies{Encrypter: Encryption.NewEncrypter(Encryption.GenerateKey("aes-256-gcm"))}
+	middleware.DisableFor("plain")
+	return middleware
+}
+
+func newCookieRequest(cookies map[string]string) *Http.Request {
+	request := httptest.NewRequest("GET", "/", nil)
+	for name, value := range cookies {
+		request.AddCookie(&http.Cookie{Name: name, Value: value})
+	}
+
+	return Http.NewRequest(nil, httptest.NewRecorder(), request)
+}
+
+func TestCookiesAreEncryptedAndDecrypted(t *testing.T) {
+	middleware := newEncryptCookies()
+	queued := &http.Cookie{Name: "session", Value: "abc", Path: "/"}
+
+	var received *Http.Request
+	response := middleware.Handle(newCookieRequest(map[string]string{
+		"session": middleware.encryptValue(&http.Cookie{Name: "session", Value: "abc"}),
+		"plain":   "visible",
+		"forged":  "not encrypted",
+	}), func(request *Http.Request) *Http.Response {
+		received = request
+		return Http.NewResponse("", Http.HTTP_OK).WithCookie(queued).WithCookie(&http.Cookie{Name: "plain", Value: "visible"})
+	})
+
+	if value := received.Cookie("session"); value != "abc" {
+		t.Errorf("expected the decrypted session, got %q", value)
+	}
+	if value := received.Cookie("plain"); value != "visible" {
+		t.Errorf("expected the cookie excluded from encryption, got %q", value)
+	}
+	if received.HasCookie("forged") {
+		t.Error("a cookie that failed to decrypt reached the application")
+	}
+
+	for _, cookie := range response.GetCookies() {
+		switch cookie.Name {
+		case "session":
+			if cookie.Value == "abc" {
+				t.Error("the session cookie was sent in clear")
+			}
+			if value, ok := middleware.decryptCookie("session", cookie.Value); !ok || value != "abc" {
+				t.Errorf("unexpected session cookie %q", value)
+			}
+		case "plain":
+			if cookie.Value != "visible" {
+				t.Error("a cookie excluded from encryption was encrypted")
+			}
+		}
+	}
+	if queued.Value != "abc" {
+		t.Error("the queued cookie was changed in place")
+	}
+}
+
+func TestEncryptedCookieIsBoundToItsName(t *testing.T) {
+	middleware := newEncryptCookies()
+	encrypted := middleware.encryptValue(&http.Cookie{Name: "role", Value: "admin"})
+
+	var received *Http.Request
+	middleware.Handle(newCookieRequest(map[string]string{"session": encrypted}), func(request *Http.Request) *Http.Response {
+		received = request
+		return Http.NewResponse("", Http.HTTP_OK)
+	})
+
+	if received.HasCookie("session") {
+		t.Error("a cookie encrypted for another name was accepted")
+	}
+}
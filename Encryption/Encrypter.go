@@ -0,0 +1,231 @@
+package Encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"strings"
+)
+
+/**
+ * The supported cipher algorithms and their key lengths.
+ *
+ * @var map[string]int
+ */
+var supportedCiphers = map[string]int{
+	"aes-128-gcm": 16,
+	"aes-256-gcm": 32,
+}
+
+type Encrypter struct {
+	/**
+	 * The encryption key.
+	 *
+	 * @var []byte
+	 */
+	key []byte
+
+	/**
+	 * The algorithm used for encryption.
+	 *
+	 * @var string
+	 */
+	cipher string
+
+	aead cipher.AEAD
+}
+
+/**
+ * The JSON payload an encrypted value is sent as.
+ */
+type payload struct {
+	Iv    string `json:"iv"`
+	Value string `json:"value"`
+	Mac   string `json:"mac"`
+	Tag   string `json:"tag"`
+}
+
+/**
+ * Create a new encrypter instance.
+ *
+ * @param  []byte  key
+ * @param  string  cipher
+ * @return *Encrypter
+ *
+ * @throws Errors.RuntimeException
+ */
+func NewEncrypter(key []byte, _cipher ...string) (this *Encrypter) {
+	_cipher = append(_cipher, "aes-256-gcm")
+
+	if !Supported(key, _cipher[0]) {
+		ciphers := []string{}
+		for name := range supportedCiphers {
+			ciphers = append(ciphers, name)
+		}
+		panic(Errors.NewRuntimeException(fmt.Sprintf("Unsupported cipher or incorrect key length. Supported ciphers are: %s.", strings.Join(ciphers, ", "))))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(Errors.NewRuntimeException(err.Error()))
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(Errors.NewRuntimeException(err.Error()))
+	}
+
+	this = &Encrypter{}
+	this.key = key
+	this.cipher = strings.ToLower(_cipher[0])
+	this.aead = aead
+	return this
+}
+
+/**
+ * Determine if the given key and cipher combination is valid.
+ *
+ * @param  []byte  key
+ * @param  string  cipher
+ * @return bool
+ */
+func Supported(key []byte, cipher string) bool {
+	length, ok := supportedCiphers[strings.ToLower(cipher)]
+
+	return ok && len(key) == length
+}
+
+/**
+ * Create a new encryption key for the given cipher.
+ *
+ * @param  string  cipher
+ * @return []byte
+ */
+func GenerateKey(cipher string) []byte {
+	length, ok := supportedCiphers[strings.ToLower(cipher)]
+	if !ok {
+		length = 32
+	}
+
+	key := make([]byte, length)
+	if _, err := rand.Read(key); err != nil {
+		panic(Errors.NewRuntimeException(err.Error()))
+	}
+
+	return key
+}
+
+/**
+ * Encrypt the given value.
+ *
+ * @param  []byte  value
+ * @return string, error
+ */
+func (this *Encrypter) Encrypt(value []byte) (string, error) {
+	iv := make([]byte, this.aead.NonceSize())
+	if _, err := rand.Read(iv); err != nil {
+		return "", Errors.NewEncryptException("Could not encrypt the data.")
+	}
+
+	// The authentication tag is appended to the sealed value, we will send it
+	// apart from the value just like the payloads of the other GCM ciphers.
+	sealed := this.aead.Seal(nil, iv, value, nil)
+	tagStart := len(sealed) - this.aead.Overhead()
+
+	data, err := json.Marshal(payload{
+		Iv:    base64.StdEncoding.EncodeToString(iv),
+		Value: base64.StdEncoding.EncodeToString(sealed[:tagStart]),
+		Tag:   base64.StdEncoding.EncodeToString(sealed[tagStart:]),
+	})
+	if err != nil {
+		return "", Errors.NewEncryptException("Could not encrypt the data.")
+	}
+
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+/**
+ * Encrypt a string.
+ *
+ * @param  string  value
+ * @return string, error
+ */
+func (this *Encrypter) EncryptString(value string) (string, error) {
+	return this.Encrypt([]byte(value))
+}
+
+/**
+ * Decrypt the given value.
+ *
+ * @param  string  payload
+ * @return []byte, error
+ */
+func (this *Encrypter) Decrypt(payload string) ([]byte, error) {
+	iv, value, tag, err := this.getJsonPayload(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	decrypted, err := this.aead.Open(nil, iv, append(value, tag...), nil)
+	if err != nil {
+		return nil, Errors.NewDecryptException("Could not decrypt the data.")
+	}
+
+	return decrypted, nil
+}
+
+/**
+ * Decrypt the given string.
+ *
+ * @param  string  payload
+ * @return string, error
+ */
+func (this *Encrypter) DecryptString(payload string) (string, error) {
+	decrypted, err := this.Decrypt(payload)
+
+	return string(decrypted), err
+}
+
+/**
+ * Get the decoded parts of the JSON payload.
+ *
+ * @param  string  data
+ * @return []byte, []byte, []byte, error
+ */
+func (this *Encrypter) getJsonPayload(data string) (iv []byte, value []byte, tag []byte, err error) {
+	invalid := Errors.NewDecryptException("The payload is invalid.")
+
+	decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, nil, nil, invalid
+	}
+
+	var _payload payload
+	if json.Unmarshal(decoded, &_payload) != nil {
+		return nil, nil, nil, invalid
+	}
+
+	if iv, err = base64.StdEncoding.DecodeString(_payload.Iv); err != nil || len(iv) != this.aead.NonceSize() {
+		return nil, nil, nil, invalid
+	}
+	if value, err = base64.StdEncoding.DecodeString(_payload.Value); err != nil {
+		return nil, nil, nil, invalid
+	}
+	if tag, err = base64.StdEncoding.DecodeString(_payload.Tag); err != nil || len(tag) != this.aead.Overhead() {
+		return nil, nil, nil, invalid
+	}
+
+	return iv, value, tag, nil
+}
+
+/**
+ * Get the encryption key that the encrypter is currently using.
+ *
+ * @return []byte
+ */
+func (this *Encrypter) GetKey() []byte {
+	return this.key
+}
@@ -0,0 +1,83 @@
+package Encryption
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/larisgo/framework/Errors"
+)
+
+func TestEncryptionRoundTrip(t *testing.T) {
+	for _, cipher := range []string{"aes-128-gcm", "AES-256-GCM"} {
+		encrypter := NewEncrypter(GenerateKey(cipher), cipher)
+
+		first, err := encrypter.EncryptString("secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, _ := encrypter.EncryptString("secret")
+		if first == second {
+			t.Errorf("%s: the same value was encrypted twice the same way", cipher)
+		}
+
+		for _, payload := range []string{first, second} {
+			if decrypted, err := encrypter.DecryptString(payload); err != nil || decrypted != "secret" {
+				t.Errorf("%s: unexpected decrypted value %q, %v", cipher, decrypted, err)
+			}
+		}
+	}
+}
+
+func TestTamperedPayloadIsRejected(t *testing.T) {
+	encrypter := NewEncrypter(GenerateKey("aes-256-gcm"))
+	encrypted, _ := encrypter.EncryptString("secret")
+
+	decoded, _ := base64.StdEncoding.DecodeString(encrypted)
+	fields := map[string]string{}
+	json.Unmarshal(decoded, &fields)
+
+	tamper := func(field string) string {
+		raw, _ := base64.StdEncoding.DecodeString(fields[field])
+		raw[0] ^= 1
+		copied := map[string]string{}
+		for key, value := range fields {
+			copied[key] = value
+		}
+		copied[field] = base64.StdEncoding.EncodeToString(raw)
+		data, _ := json.Marshal(copied)
+		return base64.StdEncoding.EncodeToString(data)
+	}
+
+	other, _ := NewEncrypter(GenerateKey("aes-256-gcm")).EncryptString("secret")
+
+	payloads := map[string]string{
+		"value":         tamper("value"),
+		"tag":           tamper("tag"),
+		"iv":            tamper("iv"),
+		"not base64":    "%%%",
+		"not json":      base64.StdEncoding.EncodeToString([]byte("secret")),
+		"short iv":      base64.StdEncoding.EncodeToString([]byte(`{"iv":"AAAA","value":"","tag":""}`)),
+		"other key":     other,
+		"empty payload": "",
+	}
+	for name, payload := range payloads {
+		_, err := encrypter.Decrypt(payload)
+		if _, ok := err.(Errors.DecryptException); !ok {
+			t.Errorf("%s: expected a decrypt exception, got %v", name, err)
+		}
+	}
+}
+
+func TestUnsupportedKeyIsRejected(t *testing.T) {
+	if !Supported(make([]byte, 16), "aes-128-gcm") || Supported(make([]byte, 16), "aes-256-gcm") || Supported(make([]byte, 32), "des") {
+		t.Error("unexpected Supported result")
+	}
+
+	defer func() {
+		if _, ok := recover().(Errors.RuntimeException); !ok {
+			t.Error("expected a runtime exception")
+		}
+	}()
+	NewEncrypter([]byte("short"))
+}
@@ -0,0 +1,65 @@
+package Encryption
+
+import (
+	"encoding/base64"
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Support"
+	"strings"
+)
+
+type EncryptionServiceProvider struct {
+	*Support.ServiceProvider
+}
+
+func NewEncryptionServiceProvider(app Foundation.Application) (this *EncryptionServiceProvider) {
+	this = &EncryptionServiceProvider{ServiceProvider: Support.NewServiceProvider(app)}
+	return this
+}
+
+/**
+ * Register the service provider.
+ *
+ * @return void
+ */
+func (this *EncryptionServiceProvider) Register() {
+	this.App.Singleton("encrypter", func(app Container.Container) interface{} {
+		config, _ := app.Make("config").(RepositoryContract.Repository)
+		if config == nil {
+			panic(Errors.NewRuntimeException("No application encryption key has been specified."))
+		}
+
+		cipher, _ := config.Get("app.cipher", "aes-256-gcm").(string)
+
+		return NewEncrypter(this.parseKey(config), cipher)
+	})
+}
+
+/**
+ * Parse the encryption key from the "app.key" configuration.
+ *
+ * @param  RepositoryContract.Repository  config
+ * @return []byte
+ *
+ * @throws Errors.RuntimeException
+ */
+func (this *EncryptionServiceProvider) parseKey(config RepositoryContract.Repository) []byte {
+	key, _ := config.Get("app.key").(string)
+	if key == "" {
+		panic(Errors.NewRuntimeException("No application encryption key has been specified."))
+	}
+
+	// Keys made with random bytes are kept base64 encoded and marked by a prefix
+	// so they are told apart from plain keys.
+	if strings.HasPrefix(key, "base64:") {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(key, "base64:"))
+		if err != nil {
+			panic(Errors.NewRuntimeException("The application encryption key is not valid base64."))
+		}
+		return decoded
+	}
+
+	return []byte(key)
+}
@@ -0,0 +1,41 @@
+package Errors
+
+type ConflictingHeadersException struct {
+	message    string
+	code       int
+	statusCode int
+	headers    map[string][]string
+}
+
+func NewConflictingHeadersException(message string, headers map[string][]string, code ...int) Exception {
+	code = append(code, 0)
+	if headers == nil {
+		headers = map[string][]string{}
+	}
+	return ConflictingHeadersException{
+		message:    message,
+		code:       code[0],
+		statusCode: 400,
+		headers:    headers,
+	}
+}
+
+func (this ConflictingHeadersException) GetMessage() string {
+	return this.message
+}
+
+func (this ConflictingHeadersException) Error() string {
+	return this.GetMessage()
+}
+
+func (this ConflictingHeadersException) GetCode() int {
+	return this.code
+}
+
+func (this ConflictingHeadersException) GetStatusCode() int {
+	return this.statusCode
+}
+
+func (this ConflictingHeadersException) GetHeaders() map[string][]string {
+	return this.headers
+}
@@ -0,0 +1,25 @@
+package Errors
+
+type DecryptException struct {
+	message string
+	code    int
+}
+
+func NewDecryptException(message string, code ...int) Exception {
+	code = append(code, 0)
+	return DecryptException{
+		message: message,
+		code:    code[0],
+	}
+}
+func (this DecryptException) GetMessage() string {
+	return this.message
+}
+
+func (this DecryptException) Error() string {
+	return this.GetMessage()
+}
+
+func (this DecryptException) GetCode() int {
+	return this.code
+}
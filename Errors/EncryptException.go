@@ -0,0 +1,25 @@
+package Errors
+
+type EncryptException struct {
+	message string
+	code    int
+}
+
+func NewEncryptException(message string, code ...int) Exception {
+	code = append(code, 0)
+	return EncryptException{
+		message: message,
+		code:    code[0],
+	}
+}
+func (this EncryptException) GetMessage() string {
+	return this.message
+}
+
+func (this EncryptException) Error() string {
+	return this.GetMessage()
+}
+
+func (this EncryptException) GetCode() int {
+	return this.code
+}
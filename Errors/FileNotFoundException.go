@@ -0,0 +1,25 @@
+package Errors
+
+type FileNotFoundException struct {
+	message string
+	code    int
+}
+
+func NewFileNotFoundException(message string, code ...int) Exception {
+	code = append(code, 0)
+	return FileNotFoundException{
+		message: message,
+		code:    code[0],
+	}
+}
+func (this FileNotFoundException) GetMessage() string {
+	return this.message
+}
+
+func (this FileNotFoundException) Error() string {
+	return this.GetMessage()
+}
+
+func (this FileNotFoundException) GetCode() int {
+	return this.code
+}
@@ -0,0 +1,19 @@
+package Errors
+
+type HttpExceptionInterface interface {
+	Exception
+
+	/**
+	 * Returns the status code.
+	 *
+	 * @return int An HTTP response status code
+	 */
+	GetStatusCode() int
+
+	/**
+	 * Returns response headers.
+	 *
+	 * @return array Response headers
+	 */
+	GetHeaders() map[string][]string
+}
@@ -1,7 +1,7 @@
 package Errors
 
 import (
-	"bytes"
+	"sort"
 	"strings"
 )
 
@@ -13,14 +13,13 @@ type MethodNotAllowedHttpException struct {
 }
 
 func join(v map[string]bool, splite string) string {
-	var buf bytes.Buffer
+	methods := []string{}
 	for k, _ := range v {
-		if buf.Len() > 0 {
-			buf.WriteString(splite)
-		}
-		buf.WriteString(strings.ToUpper(k))
+		methods = append(methods, strings.ToUpper(k))
 	}
-	return buf.String()
+	sort.Strings(methods)
+
+	return strings.Join(methods, splite)
 }
 
 func NewMethodNotAllowedHttpException(allow map[string]bool, message string, code ...int) Exception {
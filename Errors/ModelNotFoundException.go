@@ -0,0 +1,26 @@
+package Errors
+
+type ModelNotFoundException struct {
+	message string
+	code    int
+}
+
+func NewModelNotFoundException(message string, code ...int) Exception {
+	code = append(code, 0)
+	return ModelNotFoundException{
+		message: message,
+		code:    code[0],
+	}
+}
+
+func (this ModelNotFoundException) GetMessage() string {
+	return this.message
+}
+
+func (this ModelNotFoundException) Error() string {
+	return this.GetMessage()
+}
+
+func (this ModelNotFoundException) GetCode() int {
+	return this.code
+}
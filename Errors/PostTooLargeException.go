@@ -0,0 +1,41 @@
+package Errors
+
+type PostTooLargeException struct {
+	message    string
+	code       int
+	statusCode int
+	headers    map[string][]string
+}
+
+func NewPostTooLargeException(message string, headers map[string][]string, code ...int) Exception {
+	code = append(code, 0)
+	if headers == nil {
+		headers = map[string][]string{}
+	}
+	return PostTooLargeException{
+		message:    message,
+		code:       code[0],
+		statusCode: 413,
+		headers:    headers,
+	}
+}
+
+func (this PostTooLargeException) GetMessage() string {
+	return this.message
+}
+
+func (this PostTooLargeException) Error() string {
+	return this.GetMessage()
+}
+
+func (this PostTooLargeException) GetCode() int {
+	return this.code
+}
+
+func (this PostTooLargeException) GetStatusCode() int {
+	return this.statusCode
+}
+
+func (this PostTooLargeException) GetHeaders() map[string][]string {
+	return this.headers
+}
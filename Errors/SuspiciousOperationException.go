@@ -0,0 +1,41 @@
+package Errors
+
+type SuspiciousOperationException struct {
+	message    string
+	code       int
+	statusCode int
+	headers    map[string][]string
+}
+
+func NewSuspiciousOperationException(message string, headers map[string][]string, code ...int) Exception {
+	code = append(code, 0)
+	if headers == nil {
+		headers = map[string][]string{}
+	}
+	return SuspiciousOperationException{
+		message:    message,
+		code:       code[0],
+		statusCode: 400,
+		headers:    headers,
+	}
+}
+
+func (this SuspiciousOperationException) GetMessage() string {
+	return this.message
+}
+
+func (this SuspiciousOperationException) Error() string {
+	return this.GetMessage()
+}
+
+func (this SuspiciousOperationException) GetCode() int {
+	return this.code
+}
+
+func (this SuspiciousOperationException) GetStatusCode() int {
+	return this.statusCode
+}
+
+func (this SuspiciousOperationException) GetHeaders() map[string][]string {
+	return this.headers
+}
@@ -0,0 +1,41 @@
+package Errors
+
+type ThrottleRequestsException struct {
+	message    string
+	code       int
+	statusCode int
+	headers    map[string][]string
+}
+
+func NewThrottleRequestsException(message string, headers map[string][]string, code ...int) Exception {
+	code = append(code, 0)
+	if headers == nil {
+		headers = map[string][]string{}
+	}
+	return ThrottleRequestsException{
+		message:    message,
+		code:       code[0],
+		statusCode: 429,
+		headers:    headers,
+	}
+}
+
+func (this ThrottleRequestsException) GetMessage() string {
+	return this.message
+}
+
+func (this ThrottleRequestsException) Error() string {
+	return this.GetMessage()
+}
+
+func (this ThrottleRequestsException) GetCode() int {
+	return this.code
+}
+
+func (this ThrottleRequestsException) GetStatusCode() int {
+	return this.statusCode
+}
+
+func (this ThrottleRequestsException) GetHeaders() map[string][]string {
+	return this.headers
+}
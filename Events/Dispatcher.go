@@ -0,0 +1,512 @@
+package Events
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Support"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+/**
+ * A listener as it is stored by the dispatcher.
+ */
+type Listener func(event string, payload interface{}) interface{}
+
+/**
+ * A subscriber registers several listeners at once.
+ */
+type Subscriber interface {
+	Subscribe(*Dispatcher)
+}
+
+type Dispatcher struct {
+	/**
+	 * The IoC container instance.
+	 *
+	 * @var Container.Container
+	 */
+	container Container.Container
+
+	/**
+	 * The registered event listeners.
+	 *
+	 * @var map[string][]Listener
+	 */
+	listeners map[string][]Listener
+
+	/**
+	 * The wildcard listeners.
+	 *
+	 * @var map[string][]Listener
+	 */
+	wildcards map[string][]Listener
+
+	/**
+	 * The order the wildcard listeners were registered in.
+	 *
+	 * @var []string
+	 */
+	wildcardsOrder []string
+
+	/**
+	 * The cached wildcard listeners.
+	 *
+	 * @var map[string][]Listener
+	 */
+	wildcardsCache map[string][]Listener
+
+	lock sync.RWMutex
+}
+
+/**
+ * Create a new event dispatcher instance.
+ *
+ * @param  Container.Container  container
+ * @return *Dispatcher
+ */
+func NewDispatcher(container Container.Container) (this *Dispatcher) {
+	this = &Dispatcher{}
+	this.container = container
+	this.listeners = map[string][]Listener{}
+	this.wildcards = map[string][]Listener{}
+	this.wildcardsCache = map[string][]Listener{}
+	return this
+}
+
+/**
+ * Get the name of an event, the name of its type for typed events, e.g.
+ * "github.com/larisgo/framework/Routing.RouteMatched".
+ *
+ * @param  string|interface{}  event
+ * @return string
+ */
+func NameOf(event interface{}) string {
+	if name, ok := event.(string); ok {
+		return name
+	}
+
+	Type := reflect.TypeOf(event)
+	if Type == nil {
+		return ""
+	}
+	for Type.Kind() == reflect.Ptr {
+		Type = Type.Elem()
+	}
+	if Type.PkgPath() == "" {
+		return Type.String()
+	}
+	return Type.PkgPath() + "." + Type.Name()
+}
+
+/**
+ * Register an event listener with the dispatcher.
+ *
+ * @param  string|[]string|interface{}  events
+ * @param  interface{}  listener
+ * @return void
+ */
+func (this *Dispatcher) Listen(events interface{}, listener interface{}) {
+	names := []string{}
+	switch _events := events.(type) {
+	case []string:
+		names = _events
+	case []interface{}:
+		for _, event := range _events {
+			names = append(names, NameOf(event))
+		}
+	default:
+		names = append(names, NameOf(events))
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	for _, event := range names {
+		if strings.Contains(event, "*") {
+			this.setupWildcardListen(event, listener)
+		} else {
+			this.listeners[event] = append(this.listeners[event], this.MakeListener(listener, false))
+		}
+	}
+}
+
+/**
+ * Setup a wildcard listener callback.
+ *
+ * @param  string  event
+ * @param  interface{}  listener
+ * @return void
+ */
+func (this *Dispatcher) setupWildcardListen(event string, listener interface{}) {
+	if _, ok := this.wildcards[event]; !ok {
+		this.wildcardsOrder = append(this.wildcardsOrder, event)
+	}
+	this.wildcards[event] = append(this.wildcards[event], this.MakeListener(listener, true))
+
+	this.wildcardsCache = map[string][]Listener{}
+}
+
+/**
+ * Determine if a given event has listeners.
+ *
+ * @param  string  eventName
+ * @return bool
+ */
+func (this *Dispatcher) HasListeners(eventName string) bool {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	if len(this.listeners[eventName]) > 0 || len(this.wildcards[eventName]) > 0 {
+		return true
+	}
+
+	return this.hasWildcardListeners(eventName)
+}
+
+/**
+ * Determine if the given event has any wildcard listeners.
+ *
+ * @param  string  eventName
+ * @return bool
+ */
+func (this *Dispatcher) HasWildcardListeners(eventName string) bool {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	return this.hasWildcardListeners(eventName)
+}
+
+func (this *Dispatcher) hasWildcardListeners(eventName string) bool {
+	for _, key := range this.wildcardsOrder {
+		if Support.Str().Is(eventName, []string{key}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+ * Register an event subscriber with the dispatcher.
+ *
+ * @param  interface{}  subscriber
+ * @return void
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *Dispatcher) Subscribe(subscriber interface{}) {
+	_subscriber, ok := this.resolve(subscriber).(Subscriber)
+	if !ok {
+		panic(Errors.NewInvalidArgumentException(fmt.Sprintf("Event subscriber [%T] must have a Subscribe(*Events.Dispatcher) method.", subscriber)))
+	}
+
+	_subscriber.Subscribe(this)
+}
+
+/**
+ * Fire an event until the first non-nil response is returned.
+ *
+ * @param  string|interface{}  event
+ * @param  interface{}  payload
+ * @return interface{}
+ */
+func (this *Dispatcher) Until(event interface{}, payload ...interface{}) interface{} {
+	if responses := this.dispatch(event, payload, true); len(responses) > 0 {
+		return responses[0]
+	}
+	return nil
+}
+
+/**
+ * Fire an event and call the listeners.
+ *
+ * An event is either a name with an optional payload, or a typed event that
+ * is its own payload. A listener returning false stops the propagation.
+ *
+ * @param  string|interface{}  event
+ * @param  interface{}  payload
+ * @return []interface{}
+ */
+func (this *Dispatcher) Dispatch(event interface{}, payload ...interface{}) []interface{} {
+	return this.dispatch(event, payload, false)
+}
+
+/**
+ * Fire an event and call the listeners.
+ *
+ * @param  string|interface{}  event
+ * @param  []interface{}  payload
+ * @param  bool  halt
+ * @return []interface{}
+ */
+func (this *Dispatcher) dispatch(event interface{}, payload []interface{}, halt bool) []interface{} {
+	// When the given "event" is actually an object we will assume it is an event
+	// object and use the type as the event name and this event itself as the
+	// payload to the handler, which makes object based events quite simple.
+	eventName, _payload := this.parseEventAndPayload(event, payload)
+
+	responses := []interface{}{}
+
+	for _, listener := range this.GetListeners(eventName) {
+		response := listener(eventName, _payload)
+
+		// If a response is returned from the listener and event halting is enabled
+		// we will just return this response, and not call the rest of the event
+		// listeners. Otherwise we will add the response on the response list.
+		if halt && response != nil {
+			return []interface{}{response}
+		}
+
+		// If a boolean false is returned from a listener, we will stop propagating
+		// the event to any further listeners down in the chain, else we keep on
+		// looping through the listeners and firing every one in our sequence.
+		if stop, ok := response.(bool); ok && !stop {
+			break
+		}
+
+		responses = append(responses, response)
+	}
+
+	if halt {
+		return nil
+	}
+
+	return responses
+}
+
+/**
+ * Parse the given event and payload and prepare them for dispatching.
+ *
+ * @param  string|interface{}  event
+ * @param  []interface{}  payload
+ * @return string, interface{}
+ */
+func (this *Dispatcher) parseEventAndPayload(event interface{}, payload []interface{}) (string, interface{}) {
+	if name, ok := event.(string); ok {
+		switch len(payload) {
+		case 0:
+			return name, nil
+		case 1:
+			return name, payload[0]
+		}
+		return name, payload
+	}
+
+	return NameOf(event), event
+}
+
+/**
+ * Get all of the listeners for a given event name.
+ *
+ * @param  string  eventName
+ * @return []Listener
+ */
+func (this *Dispatcher) GetListeners(eventName string) []Listener {
+	this.lock.RLock()
+	listeners := append([]Listener{}, this.listeners[eventName]...)
+	wildcards, cached := this.wildcardsCache[eventName]
+	this.lock.RUnlock()
+
+	if !cached {
+		wildcards = this.getWildcardListeners(eventName)
+	}
+
+	return append(listeners, wildcards...)
+}
+
+/**
+ * Get the wildcard listeners for the event.
+ *
+ * @param  string  eventName
+ * @return []Listener
+ */
+func (this *Dispatcher) getWildcardListeners(eventName string) []Listener {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	wildcards := []Listener{}
+	for _, key := range this.wildcardsOrder {
+		if Support.Str().Is(eventName, []string{key}) {
+			wildcards = append(wildcards, this.wildcards[key]...)
+		}
+	}
+	this.wildcardsCache[eventName] = wildcards
+
+	return wildcards
+}
+
+/**
+ * Register an event listener with the dispatcher.
+ *
+ * A listener is a function taking the event, or the event name and payload
+ * for wildcard listeners, a struct with a Handle method, or the name of a
+ * listener bound in the container. Pointers to structs are built through the
+ * container so their dependencies get injected.
+ *
+ * Listeners are resolved once, when the first event reaches them, so that
+ * events dispatched concurrently never inject the same listener twice.
+ *
+ * @param  interface{}  listener
+ * @param  bool  wildcard
+ * @return Listener
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *Dispatcher) MakeListener(listener interface{}, wildcard bool) Listener {
+	if _listener, ok := listener.(func(string, interface{}) interface{}); ok {
+		return Listener(_listener)
+	}
+	if _listener, ok := listener.(Listener); ok {
+		return _listener
+	}
+
+	if _, ok := listener.(string); !ok {
+		this.handlerOf(listener)
+	}
+
+	var (
+		lock    sync.Mutex
+		handler reflect.Value
+	)
+	resolve := func() reflect.Value {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if !handler.IsValid() {
+			handler = this.handlerOf(this.resolve(listener))
+		}
+		return handler
+	}
+
+	return func(event string, payload interface{}) interface{} {
+		return this.callListener(resolve(), event, payload, wildcard)
+	}
+}
+
+/**
+ * Get the function that handles the events of a listener.
+ *
+ * @param  interface{}  listener
+ * @return reflect.Value
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *Dispatcher) handlerOf(listener interface{}) reflect.Value {
+	value := reflect.ValueOf(listener)
+	if value.Kind() == reflect.Func {
+		return value
+	}
+	if value.IsValid() {
+		if method := value.MethodByName("Handle"); method.IsValid() {
+			return method
+		}
+	}
+
+	panic(Errors.NewInvalidArgumentException(fmt.Sprintf("Event listener [%T] must be a function or have a Handle method.", listener)))
+}
+
+/**
+ * Call the handler of a listener with the event.
+ *
+ * @param  reflect.Value  handler
+ * @param  string  event
+ * @param  interface{}  payload
+ * @param  bool  wildcard
+ * @return interface{}
+ */
+func (this *Dispatcher) callListener(handler reflect.Value, event string, payload interface{}, wildcard bool) interface{} {
+	Type := handler.Type()
+
+	arguments := []reflect.Value{}
+	switch Type.NumIn() {
+	case 2:
+		if !this.accepts(Type.In(1), payload) {
+			return nil
+		}
+		arguments = append(arguments, reflect.ValueOf(event), this.argument(Type.In(1), payload))
+	case 1:
+		// A typed listener only hears the events its argument can hold, which
+		// matters for wildcard listeners that match several kinds of events.
+		if !this.accepts(Type.In(0), payload) {
+			return nil
+		}
+		arguments = append(arguments, this.argument(Type.In(0), payload))
+	}
+
+	if results := handler.Call(arguments); len(results) > 0 {
+		return results[0].Interface()
+	}
+	return nil
+}
+
+/**
+ * Determine if an argument of the given type can hold the payload.
+ *
+ * @param  reflect.Type  Type
+ * @param  interface{}  payload
+ * @return bool
+ */
+func (this *Dispatcher) accepts(Type reflect.Type, payload interface{}) bool {
+	return payload == nil || reflect.TypeOf(payload).AssignableTo(Type)
+}
+
+/**
+ * Get the argument of the given type holding the payload.
+ *
+ * @param  reflect.Type  Type
+ * @param  interface{}  payload
+ * @return reflect.Value
+ */
+func (this *Dispatcher) argument(Type reflect.Type, payload interface{}) reflect.Value {
+	if payload == nil {
+		return reflect.Zero(Type)
+	}
+	return reflect.ValueOf(payload)
+}
+
+/**
+ * Resolve a listener or a subscriber through the container.
+ *
+ * @param  interface{}  listener
+ * @return interface{}
+ */
+func (this *Dispatcher) resolve(listener interface{}) interface{} {
+	if this.container == nil {
+		return listener
+	}
+	if name, ok := listener.(string); ok {
+		return this.container.Make(name)
+	}
+	if Type := reflect.TypeOf(listener); Type != nil && Type.Kind() == reflect.Ptr && Type.Elem().Kind() == reflect.Struct {
+		return this.container.Build(listener, Type.String())
+	}
+	return listener
+}
+
+/**
+ * Remove a set of listeners from the dispatcher.
+ *
+ * @param  string  event
+ * @return void
+ */
+func (this *Dispatcher) Forget(event string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if strings.Contains(event, "*") {
+		delete(this.wildcards, event)
+		for i, key := range this.wildcardsOrder {
+			if key == event {
+				this.wildcardsOrder = append(this.wildcardsOrder[:i], this.wildcardsOrder[i+1:]...)
+				break
+			}
+		}
+	} else {
+		delete(this.listeners, event)
+	}
+
+	this.wildcardsCache = map[string][]Listener{}
+}
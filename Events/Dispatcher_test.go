@@ -0,0 +1,169 @@
+package Events
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/larisgo/framework/Errors"
+)
+
+type userRegistered struct {
+	Name string
+}
+
+type registrationMailer struct {
+	sent []string
+}
+
+func (this *registrationMailer) Handle(event *userRegistered) {
+	this.sent = append(this.sent, event.Name)
+}
+
+type userSubscriber struct {
+	heard []string
+}
+
+func (this *userSubscriber) Subscribe(events *Dispatcher) {
+	events.Listen("user.login", func(string, interface{}) interface{} {
+		this.heard = append(this.heard, "login")
+		return nil
+	})
+	events.Listen("user.logout", func(string, interface{}) interface{} {
+		this.heard = append(this.heard, "logout")
+		return nil
+	})
+}
+
+func TestTypedEventIsDispatchedToItsListeners(t *testing.T) {
+	events := NewDispatcher(nil)
+	mailer := &registrationMailer{}
+	events.Listen(&userRegistered{}, mailer)
+	events.Listen(&userRegistered{}, func(event *userRegistered) string {
+		return "hello " + event.Name
+	})
+
+	responses := events.Dispatch(&userRegistered{Name: "taylor"})
+
+	if !reflect.DeepEqual(mailer.sent, []string{"taylor"}) {
+		t.Errorf("expected the struct listener to be called, got %v", mailer.sent)
+	}
+	if !reflect.DeepEqual(responses, []interface{}{nil, "hello taylor"}) {
+		t.Errorf("unexpected responses %v", responses)
+	}
+	if name := NameOf(&userRegistered{}); name != "github.com/larisgo/framework/Events.userRegistered" {
+		t.Errorf("unexpected event name %s", name)
+	}
+}
+
+func TestNamedEventPassesItsPayload(t *testing.T) {
+	events := NewDispatcher(nil)
+	var received interface{}
+	events.Listen([]string{"order.shipped", "order.paid"}, func(event string, payload interface{}) interface{} {
+		received = payload
+		return event
+	})
+
+	if responses := events.Dispatch("order.paid", 42); !reflect.DeepEqual(responses, []interface{}{"order.paid"}) {
+		t.Errorf("unexpected responses %v", responses)
+	}
+	if received != 42 {
+		t.Errorf("expected the payload 42, got %v", received)
+	}
+	if !events.HasListeners("order.shipped") || events.HasListeners("order.cancelled") {
+		t.Error("unexpected listeners")
+	}
+}
+
+func TestWildcardListenersHearMatchingEvents(t *testing.T) {
+	events := NewDispatcher(nil)
+	heard := []string{}
+	events.Listen("user.*", func(event string, payload interface{}) interface{} {
+		heard = append(heard, event)
+		return nil
+	})
+
+	events.Dispatch("user.login")
+	events.Dispatch("order.paid")
+	events.Dispatch("user.logout")
+
+	if !reflect.DeepEqual(heard, []string{"user.login", "user.logout"}) {
+		t.Errorf("unexpected events %v", heard)
+	}
+	if !events.HasWildcardListeners("user.created") || events.HasWildcardListeners("order.paid") {
+		t.Error("unexpected wildcard listeners")
+	}
+
+	events.Forget("user.*")
+	events.Dispatch("user.login")
+	if len(heard) != 2 {
+		t.Errorf("a forgotten wildcard listener was called")
+	}
+}
+
+func TestUntilHaltsOnTheFirstResponse(t *testing.T) {
+	events := NewDispatcher(nil)
+	calls := 0
+	for _, response := range []interface{}{nil, "first", "second"} {
+		response := response
+		events.Listen("lookup", func(string, interface{}) interface{} {
+			calls++
+			return response
+		})
+	}
+
+	if response := events.Until("lookup"); response != "first" {
+		t.Errorf("expected the first response, got %v", response)
+	}
+	if calls != 2 {
+		t.Errorf("expected the listeners to stop after the first response, %d were called", calls)
+	}
+}
+
+func TestReturningFalseStopsThePropagation(t *testing.T) {
+	events := NewDispatcher(nil)
+	calls := 0
+	events.Listen("saving", func(string, interface{}) interface{} {
+		calls++
+		return false
+	})
+	events.Listen("saving", func(string, interface{}) interface{} {
+		calls++
+		return nil
+	})
+
+	if responses := events.Dispatch("saving"); len(responses) != 0 {
+		t.Errorf("unexpected responses %v", responses)
+	}
+	if calls != 1 {
+		t.Errorf("expected the propagation to stop, %d listeners were called", calls)
+	}
+}
+
+func TestSubscriberRegistersItsListeners(t *testing.T) {
+	events := NewDispatcher(nil)
+	subscriber := &userSubscriber{}
+	events.Subscribe(subscriber)
+
+	events.Dispatch("user.logout")
+	events.Dispatch("user.login")
+
+	if !reflect.DeepEqual(subscriber.heard, []string{"logout", "login"}) {
+		t.Errorf("unexpected events %v", subscriber.heard)
+	}
+}
+
+func TestInvalidListenerIsRejected(t *testing.T) {
+	for _, register := range []func(events *Dispatcher){
+		func(events *Dispatcher) { events.Listen("saving", 42) },
+		func(events *Dispatcher) { events.Subscribe(&userRegistered{}) },
+	} {
+		func() {
+			defer func() {
+				if _, ok := recover().(Errors.InvalidArgumentException); !ok {
+					t.Error("expected an invalid argument exception")
+				}
+			}()
+			register(NewDispatcher(nil))
+		}()
+	}
+}
@@ -0,0 +1,27 @@
+package Events
+
+import (
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Support"
+)
+
+type EventServiceProvider struct {
+	*Support.ServiceProvider
+}
+
+func NewEventServiceProvider(app Foundation.Application) (this *EventServiceProvider) {
+	this = &EventServiceProvider{ServiceProvider: Support.NewServiceProvider(app)}
+	return this
+}
+
+/**
+ * Register the service provider.
+ *
+ * @return void
+ */
+func (this *EventServiceProvider) Register() {
+	this.App.Singleton("events", func(app Container.Container) interface{} {
+		return NewDispatcher(app)
+	})
+}
@@ -0,0 +1,157 @@
+package Filesystem
+
+import (
+	"fmt"
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	FilesystemContract "github.com/larisgo/framework/Contracts/Filesystem"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Errors"
+	"sync"
+)
+
+/**
+ * Create a disk from its configuration.
+ */
+type Creator func(config map[string]interface{}) FilesystemContract.Filesystem
+
+type FilesystemManager struct {
+	/**
+	 * The application instance.
+	 *
+	 * @var Foundation.Application
+	 */
+	app Foundation.Application
+
+	/**
+	 * The array of resolved filesystem drivers.
+	 *
+	 * @var map[string]FilesystemContract.Filesystem
+	 */
+	disks map[string]FilesystemContract.Filesystem
+
+	/**
+	 * The registered custom driver creators.
+	 *
+	 * @var map[string]Creator
+	 */
+	customCreators map[string]Creator
+
+	lock sync.Mutex
+}
+
+/**
+ * Create a new filesystem manager instance.
+ *
+ * @param  Foundation.Application  app
+ * @return *FilesystemManager
+ */
+func NewFilesystemManager(app Foundation.Application) (this *FilesystemManager) {
+	this = &FilesystemManager{}
+	this.app = app
+	this.disks = map[string]FilesystemContract.Filesystem{}
+	this.customCreators = map[string]Creator{}
+	return this
+}
+
+/**
+ * Get a filesystem instance.
+ *
+ * @param  string  name
+ * @return FilesystemContract.Filesystem
+ */
+func (this *FilesystemManager) Disk(name ...string) FilesystemContract.Filesystem {
+	name = append(name, "")
+	if name[0] == "" {
+		name[0] = this.GetDefaultDriver()
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if _, ok := this.disks[name[0]]; !ok {
+		this.disks[name[0]] = this.resolve(name[0])
+	}
+
+	return this.disks[name[0]]
+}
+
+/**
+ * Resolve the given disk.
+ *
+ * @param  string  name
+ * @return FilesystemContract.Filesystem
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *FilesystemManager) resolve(name string) FilesystemContract.Filesystem {
+	config := this.getConfig(name)
+	if config == nil {
+		panic(Errors.NewInvalidArgumentException(fmt.Sprintf("Disk [%s] does not have a configured driver.", name)))
+	}
+
+	driver, _ := config["driver"].(string)
+	if creator, ok := this.customCreators[driver]; ok {
+		return creator(config)
+	}
+
+	if driver == "local" {
+		root, _ := config["root"].(string)
+		return NewLocalFilesystem(root)
+	}
+
+	panic(Errors.NewInvalidArgumentException(fmt.Sprintf("Driver [%s] is not supported.", driver)))
+}
+
+/**
+ * Get the filesystem connection configuration. The "local" and "public" disks
+ * are there even without a configuration.
+ *
+ * @param  string  name
+ * @return map[string]interface{}
+ */
+func (this *FilesystemManager) getConfig(name string) map[string]interface{} {
+	if config, ok := this.app.Make("config").(RepositoryContract.Repository); ok {
+		if disk, ok := config.Get("filesystems.disks." + name).(map[string]interface{}); ok {
+			return disk
+		}
+	}
+
+	switch name {
+	case "local":
+		return map[string]interface{}{"driver": "local", "root": this.app.StoragePath("app")}
+	case "public":
+		return map[string]interface{}{"driver": "local", "root": this.app.StoragePath("app/public")}
+	}
+
+	return nil
+}
+
+/**
+ * Get the default driver name.
+ *
+ * @return string
+ */
+func (this *FilesystemManager) GetDefaultDriver() string {
+	if config, ok := this.app.Make("config").(RepositoryContract.Repository); ok {
+		if name, ok := config.Get("filesystems.default").(string); ok && name != "" {
+			return name
+		}
+	}
+	return "local"
+}
+
+/**
+ * Register a custom driver creator.
+ *
+ * @param  string  driver
+ * @param  Creator  callback
+ * @return this
+ */
+func (this *FilesystemManager) Extend(driver string, callback Creator) *FilesystemManager {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.customCreators[driver] = callback
+
+	return this
+}
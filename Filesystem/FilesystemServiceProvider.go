@@ -0,0 +1,31 @@
+package Filesystem
+
+import (
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Support"
+)
+
+type FilesystemServiceProvider struct {
+	*Support.ServiceProvider
+}
+
+func NewFilesystemServiceProvider(app Foundation.Application) (this *FilesystemServiceProvider) {
+	this = &FilesystemServiceProvider{ServiceProvider: Support.NewServiceProvider(app)}
+	return this
+}
+
+/**
+ * Register the service provider.
+ *
+ * @return void
+ */
+func (this *FilesystemServiceProvider) Register() {
+	this.App.Singleton("filesystem", func(app Container.Container) interface{} {
+		return NewFilesystemManager(this.App)
+	})
+
+	this.App.Singleton("filesystem.disk", func(app Container.Container) interface{} {
+		return app.Make("filesystem").(*FilesystemManager).Disk()
+	})
+}
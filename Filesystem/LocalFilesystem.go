@@ -0,0 +1,195 @@
+package Filesystem
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/**
+ * A disk keeping its files in a directory of the local filesystem.
+ */
+type LocalFilesystem struct {
+	/**
+	 * The directory the paths of the disk are relative to.
+	 *
+	 * @var string
+	 */
+	root string
+}
+
+/**
+ * Create a new local filesystem instance.
+ *
+ * @param  string  root
+ * @return *LocalFilesystem
+ */
+func NewLocalFilesystem(root string) (this *LocalFilesystem) {
+	this = &LocalFilesystem{}
+	this.root = filepath.Clean(root)
+	return this
+}
+
+/**
+ * Get the full path of the file at the given path. Paths never leave the
+ * root of the disk.
+ *
+ * @param  string  path
+ * @return string
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *LocalFilesystem) Path(path string) string {
+	full, err := this.resolve(path)
+	if err != nil {
+		panic(err)
+	}
+	return full
+}
+
+/**
+ * Resolve the given path against the root of the disk.
+ *
+ * @param  string  path
+ * @return string, error
+ */
+func (this *LocalFilesystem) resolve(path string) (string, error) {
+	full := filepath.Join(this.root, filepath.FromSlash(path))
+	if full != this.root && !strings.HasPrefix(full, this.root+string(filepath.Separator)) {
+		return "", Errors.NewInvalidArgumentException(fmt.Sprintf("Path [%s] is outside of the root of the disk.", path))
+	}
+	return full, nil
+}
+
+/**
+ * Determine if a file exists.
+ *
+ * @param  string  path
+ * @return bool
+ */
+func (this *LocalFilesystem) Exists(path string) bool {
+	full, err := this.resolve(path)
+	if err != nil {
+		return false
+	}
+	_, err = os.Stat(full)
+	return err == nil
+}
+
+/**
+ * Get the contents of a file.
+ *
+ * @param  string  path
+ * @return []byte, error
+ */
+func (this *LocalFilesystem) Get(path string) ([]byte, error) {
+	full, err := this.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(full)
+}
+
+/**
+ * Write the contents of a file.
+ *
+ * @param  string  path
+ * @param  []byte  contents
+ * @return error
+ */
+func (this *LocalFilesystem) Put(path string, contents []byte) error {
+	return this.PutStream(path, strings.NewReader(string(contents)))
+}
+
+/**
+ * Write the contents of a file from a stream. The file is written next to
+ * its destination first, so it never appears half written.
+ *
+ * @param  string  path
+ * @param  io.Reader  resource
+ * @return error
+ */
+func (this *LocalFilesystem) PutStream(path string, resource io.Reader) error {
+	full, err := this.resolve(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(full), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(tmp, resource); err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), full)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+/**
+ * Delete the file at the given paths.
+ *
+ * @param  string  paths
+ * @return error
+ */
+func (this *LocalFilesystem) Delete(paths ...string) error {
+	for _, path := range paths {
+		full, err := this.resolve(path)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(full); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Get the file size of a given file.
+ *
+ * @param  string  path
+ * @return int64, error
+ */
+func (this *LocalFilesystem) Size(path string) (int64, error) {
+	full, err := this.resolve(path)
+	if err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(full)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+/**
+ * Create a directory.
+ *
+ * @param  string  path
+ * @return error
+ */
+func (this *LocalFilesystem) MakeDirectory(path string) error {
+	full, err := this.resolve(path)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(full, 0755)
+}
@@ -0,0 +1,95 @@
+package Filesystem
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/larisgo/framework/Errors"
+)
+
+func newTestDisk(t *testing.T) (*LocalFilesystem, func()) {
+	root, err := ioutil.TempDir("", "disk")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewLocalFilesystem(root), func() { os.RemoveAll(root) }
+}
+
+func TestLocalFilesystemReadsAndWritesFiles(t *testing.T) {
+	disk, cleanup := newTestDisk(t)
+	defer cleanup()
+
+	if err := disk.Put("avatars/me.txt", []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if !disk.Exists("avatars/me.txt") || disk.Exists("avatars/you.txt") {
+		t.Error("unexpected Exists result")
+	}
+	if content, err := disk.Get("avatars/me.txt"); err != nil || string(content) != "hello" {
+		t.Errorf("unexpected contents %q, %v", content, err)
+	}
+	if size, err := disk.Size("avatars/me.txt"); err != nil || size != 5 {
+		t.Errorf("unexpected size %d, %v", size, err)
+	}
+	if err := disk.PutStream("avatars/me.txt", strings.NewReader("bye")); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := disk.Get("avatars/me.txt"); string(content) != "bye" {
+		t.Errorf("expected the file to be replaced, got %q", content)
+	}
+
+	// no temporary file is left next to the written one
+	if files, _ := ioutil.ReadDir(disk.Path("avatars")); len(files) != 1 {
+		t.Errorf("expected a single file, got %d", len(files))
+	}
+
+	if err := disk.Delete("avatars/me.txt", "avatars/missing.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if disk.Exists("avatars/me.txt") {
+		t.Error("the file was not deleted")
+	}
+	if err := disk.MakeDirectory("a/b/c"); err != nil {
+		t.Fatal(err)
+	}
+	if info, err := os.Stat(filepath.Join(disk.Path(""), "a", "b", "c")); err != nil || !info.IsDir() {
+		t.Error("the directory was not made")
+	}
+}
+
+func TestLocalFilesystemNeverLeavesItsRoot(t *testing.T) {
+	disk, cleanup := newTestDisk(t)
+	defer cleanup()
+
+	for _, path := range []string{"../escape.txt", "a/../../escape.txt", "../" + filepath.Base(disk.Path("")) + "-other/x"} {
+		if err := disk.Put(path, []byte("x")); err == nil {
+			t.Errorf("%s was written outside of the root", path)
+		}
+		if _, err := disk.Get(path); err == nil {
+			t.Errorf("%s was read outside of the root", path)
+		}
+		if disk.Exists(path) {
+			t.Errorf("%s exists outside of the root", path)
+		}
+		if err := disk.Delete(path); err == nil {
+			t.Errorf("%s was deleted outside of the root", path)
+		}
+
+		func() {
+			defer func() {
+				if _, ok := recover().(Errors.InvalidArgumentException); !ok {
+					t.Errorf("expected Path(%q) to be rejected", path)
+				}
+			}()
+			disk.Path(path)
+		}()
+	}
+
+	if full := disk.Path("/etc/passwd"); !strings.HasPrefix(full, disk.Path("")+string(filepath.Separator)) {
+		t.Errorf("an absolute path left the root: %s", full)
+	}
+}
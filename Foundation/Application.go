@@ -2,12 +2,23 @@ package Foundation
 
 import (
 	"fmt"
+	"github.com/larisgo/framework/Cache"
+	"github.com/larisgo/framework/Concurrency"
 	"github.com/larisgo/framework/Container"
 	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	ContainerContract "github.com/larisgo/framework/Contracts/Container"
 	FoundationContract "github.com/larisgo/framework/Contracts/Foundation"
 	"github.com/larisgo/framework/Contracts/Service"
+	"github.com/larisgo/framework/Cookie"
+	"github.com/larisgo/framework/Encryption"
 	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Events"
+	"github.com/larisgo/framework/Filesystem"
+	"github.com/larisgo/framework/Foundation/Exceptions"
 	"github.com/larisgo/framework/Providers"
+	"github.com/larisgo/framework/Queue"
+	"github.com/larisgo/framework/View"
+	"os"
 	"path"
 	"path/filepath"
 	"reflect"
@@ -45,7 +56,7 @@ type Application struct {
 	 *
 	 * @var callable[]
 	 */
-	terminatingCallbacks []interface{}
+	terminatingCallbacks []func()
 
 	/**
 	 * All of the registered service providers.
@@ -104,7 +115,7 @@ func NewApplication(basePath string) (this *Application) {
 	this.version = VERSION
 	this.bootingCallbacks = []func(interface{}){}
 	this.bootedCallbacks = []func(interface{}){}
-	this.terminatingCallbacks = []interface{}{}
+	this.terminatingCallbacks = []func(){}
 	this.serviceProviders = []interface{}{}
 	this.loadedProviders = map[string]bool{}
 	this.deferredServices = []interface{}{}
@@ -139,6 +150,10 @@ func (this *Application) registerBaseBindings() {
 
 	this.Instance("container", this)
 
+	this.Singleton("exception.handler", func(app ContainerContract.Container) interface{} {
+		return Exceptions.NewHandler(app)
+	})
+
 	// this.instance(PackageManifest::class, new PackageManifest(
 	// new Filesystem, this.basePath(), this.getCachedPackagesPath()
 	// ));
@@ -150,7 +165,16 @@ func (this *Application) registerBaseBindings() {
  * @return void
  */
 func (this *Application) registerBaseServiceProviders() {
+	this.Register(Events.NewEventServiceProvider(this))
+	this.Register(Concurrency.NewConcurrencyServiceProvider(this))
 	this.Register(Providers.NewRoutingServiceProvider(this))
+	this.Register(Cache.NewCacheServiceProvider(this))
+	this.Register(View.NewViewServiceProvider(this))
+	this.Register(Queue.NewQueueServiceProvider(this))
+	this.Register(Filesystem.NewFilesystemServiceProvider(this))
+	this.Register(Encryption.NewEncryptionServiceProvider(this))
+	this.Register(Cookie.NewCookieServiceProvider(this))
+	this.Register(Providers.NewArtisanServiceProvider(this))
 }
 
 /**
@@ -467,13 +491,75 @@ func (this *Application) DatabasePath(_path ...string) string {
 	return filepath.Clean(path.Join(this.basePath, "database", _path[0]))
 }
 
+/**
+ * Get the path to the resources directory.
+ *
+ * @param  string  path
+ * @return string
+ */
+func (this *Application) ResourcePath(_path ...string) string {
+	_path = append(_path, "")
+	return filepath.Clean(path.Join(this.basePath, "resources", _path[0]))
+}
+
+/**
+ * Get the path to the public directory.
+ *
+ * @param  string  path Optionally, a path to append to the public path
+ * @return string
+ */
+func (this *Application) PublicPath(_path ...string) string {
+	_path = append(_path, "")
+	return filepath.Clean(path.Join(this.basePath, "public", _path[0]))
+}
+
+/**
+ * Get the path to the storage directory.
+ *
+ * @param  string  path Optionally, a path to append to the storage path
+ * @return string
+ */
+func (this *Application) StoragePath(_path ...string) string {
+	_path = append(_path, "")
+	return filepath.Clean(path.Join(this.basePath, "storage", _path[0]))
+}
+
+/**
+ * Determine if the application routes are cached.
+ *
+ * @return bool
+ */
+func (this *Application) RoutesAreCached() bool {
+	_, err := os.Stat(this.GetCachedRoutesPath())
+	return err == nil
+}
+
+/**
+ * Get the path to the routes cache file.
+ *
+ * @return string
+ */
+func (this *Application) GetCachedRoutesPath() string {
+	return this.BootstrapPath("cache/routes.json")
+}
+
+/**
+ * Register a terminating callback with the application.
+ *
+ * @param  callable  callback
+ * @return void
+ */
+func (this *Application) Terminating(callback func()) {
+	this.terminatingCallbacks = append(this.terminatingCallbacks, callback)
+}
+
 /**
  * Terminate the application.
  *
  * @return void
  */
 func (this *Application) Terminate() {
-	// foreach (this.terminatingCallbacks as terminating) {
-	//     this.call(terminating);
-	// }
+	for _, terminating := range this.terminatingCallbacks {
+		terminating()
+	}
 }
@@ -0,0 +1,32 @@
+package Foundation
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/larisgo/framework/Console"
+)
+
+func TestBaseProvidersRegisterTheFrameworkCommands(t *testing.T) {
+	basePath, err := ioutil.TempDir("", "foundation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(basePath)
+	defer Console.ForgetBootstrappers()
+
+	app := NewApplication(basePath)
+	artisan := Console.NewApplication(app, app.Version())
+	commands := artisan.All()
+
+	for _, name := range []string{"route:cache", "route:clear", "queue:work", "queue:retry", "queue:failed"} {
+		if _, ok := commands[name]; !ok {
+			t.Errorf("the %s command is not registered", name)
+		}
+	}
+
+	if code := artisan.Call("route:clear"); code != 0 {
+		t.Errorf("route:clear exited with %d", code)
+	}
+}
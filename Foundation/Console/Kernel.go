@@ -0,0 +1,102 @@
+package Console
+
+import (
+	"github.com/larisgo/framework/Console"
+	FoundationContract "github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Foundation"
+	"github.com/larisgo/framework/Foundation/Bootstrap"
+)
+
+type Kernel struct {
+	App           *Foundation.Application `inject:"app"`
+	artisan       *Console.Application
+	bootstrappers []FoundationContract.BootstrapT
+}
+
+func NewKernel() (this *Kernel) {
+	this = &Kernel{}
+
+	this.bootstrappers = []FoundationContract.BootstrapT{
+		// &Bootstrap.LoadEnvironmentVariables{},
+		&Bootstrap.LoadConfiguration{},
+		// &Bootstrap.HandleExceptions{},
+		&Bootstrap.RegisterFacades{},
+		&Bootstrap.RegisterProviders{},
+		&Bootstrap.BootProviders{},
+	}
+
+	return this
+}
+
+/**
+ * Bootstrap the application for artisan commands.
+ *
+ * @return void
+ */
+func (this *Kernel) Bootstrap() {
+	if !this.App.HasBeenBootstrapped() {
+		this.App.BootstrapWith(this.bootstrappers)
+	}
+}
+
+/**
+ * Run the console application.
+ *
+ * @param  []string  args
+ * @return int
+ */
+func (this *Kernel) Handle(args []string) int {
+	this.Bootstrap()
+
+	return this.getArtisan().Run(args)
+}
+
+/**
+ * Run an Artisan console command by name.
+ *
+ * @param  string  command
+ * @param  string  args
+ * @return int
+ */
+func (this *Kernel) Call(command string, args ...string) int {
+	this.Bootstrap()
+
+	return this.getArtisan().Call(command, args...)
+}
+
+/**
+ * Get all of the commands registered with the console.
+ *
+ * @return map[string]Console.CommandT
+ */
+func (this *Kernel) All() map[string]Console.CommandT {
+	this.Bootstrap()
+
+	return this.getArtisan().All()
+}
+
+/**
+ * Get the Artisan application instance.
+ *
+ * @return *Console.Application
+ */
+func (this *Kernel) getArtisan() *Console.Application {
+	if this.artisan == nil {
+		this.artisan = Console.NewApplication(this.App, this.App.Version())
+	}
+
+	return this.artisan
+}
+
+/**
+ * Terminate the application.
+ *
+ * @return void
+ */
+func (this *Kernel) Terminate() {
+	this.App.Terminate()
+}
+
+func (this *Kernel) GetApplication() *Foundation.Application {
+	return this.App
+}
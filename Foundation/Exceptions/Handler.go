@@ -0,0 +1,148 @@
+package Exceptions
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Errors"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+)
+
+type Handler struct {
+	/**
+	 * The container implementation.
+	 *
+	 * @var Container.Container
+	 */
+	container Container.Container
+
+	/**
+	 * The types of the exceptions that are not reported.
+	 *
+	 * @var []reflect.Type
+	 */
+	dontReport []reflect.Type
+
+	/**
+	 * The callbacks that report exceptions, a callback returning false stops
+	 * the exception from being logged.
+	 *
+	 * @var []func(interface{}) bool
+	 */
+	reportCallbacks []func(interface{}) bool
+
+	/**
+	 * The stream the exceptions are logged to.
+	 *
+	 * @var io.Writer
+	 */
+	Output io.Writer
+
+	lock sync.RWMutex
+}
+
+/**
+ * Create a new exception handler instance.
+ *
+ * @param  Container.Container  container
+ * @return *Handler
+ */
+func NewHandler(container Container.Container) (this *Handler) {
+	this = &Handler{}
+	this.container = container
+	this.dontReport = []reflect.Type{}
+	this.reportCallbacks = []func(interface{}) bool{}
+	this.Output = os.Stderr
+	return this
+}
+
+/**
+ * Register a reportable callback.
+ *
+ * @param  func(interface{}) bool  callback
+ * @return this
+ */
+func (this *Handler) Reportable(callback func(interface{}) bool) *Handler {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.reportCallbacks = append(this.reportCallbacks, callback)
+
+	return this
+}
+
+/**
+ * Indicate that the given exception types should not be reported.
+ *
+ * @param  interface{}  exceptions
+ * @return this
+ */
+func (this *Handler) DontReport(exceptions ...interface{}) *Handler {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	for _, exception := range exceptions {
+		this.dontReport = append(this.dontReport, reflect.TypeOf(exception))
+	}
+
+	return this
+}
+
+/**
+ * Report or log an exception.
+ *
+ * @param  interface{}  e
+ * @return void
+ */
+func (this *Handler) Report(e interface{}) {
+	if !this.ShouldReport(e) {
+		return
+	}
+
+	this.lock.RLock()
+	callbacks := this.reportCallbacks
+	this.lock.RUnlock()
+
+	for _, callback := range callbacks {
+		if !callback(e) {
+			return
+		}
+	}
+
+	fmt.Fprintf(this.Output, "[%s] %T: %+v\n", time.Now().Format("2006-01-02 15:04:05"), e, e)
+}
+
+/**
+ * Determine if the exception should be reported. HTTP exceptions and missing
+ * models describe the request rather than the application, so they are
+ * never reported.
+ *
+ * @param  interface{}  e
+ * @return bool
+ */
+func (this *Handler) ShouldReport(e interface{}) bool {
+	if e == nil {
+		return false
+	}
+	if _, ok := e.(Errors.HttpExceptionInterface); ok {
+		return false
+	}
+	if _, ok := e.(Errors.ModelNotFoundException); ok {
+		return false
+	}
+
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	Type := reflect.TypeOf(e)
+	for _, dontReport := range this.dontReport {
+		if Type == dontReport {
+			return false
+		}
+	}
+
+	return true
+}
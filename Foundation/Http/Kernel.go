@@ -1,20 +1,78 @@
 package Http
 
 import (
+	"context"
 	"fmt"
-	// "github.com/larisgo/framework/Errors"
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	DebugContract "github.com/larisgo/framework/Contracts/Debug"
+	EventsContract "github.com/larisgo/framework/Contracts/Events"
 	FoundationContract "github.com/larisgo/framework/Contracts/Foundation"
+	CookieMiddleware "github.com/larisgo/framework/Cookie/Middleware"
+	"github.com/larisgo/framework/Errors"
 	"github.com/larisgo/framework/Foundation"
 	"github.com/larisgo/framework/Foundation/Bootstrap"
 	"github.com/larisgo/framework/Http"
+	HttpMiddleware "github.com/larisgo/framework/Http/Middleware"
 	"github.com/larisgo/framework/Routing"
+	RoutingMiddleware "github.com/larisgo/framework/Routing/Middleware"
 	"net/http"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"syscall"
 )
 
 type Kernel struct {
 	App           *Foundation.Application `inject:"app"`
 	Router        *Routing.Router         `inject:"router"`
 	bootstrappers []FoundationContract.BootstrapT
+
+	/**
+	 * The application's middleware stack.
+	 *
+	 * These middleware are run during every request to your application.
+	 *
+	 * @var []interface{}
+	 */
+	Middleware []interface{}
+
+	/**
+	 * The application's route middleware groups.
+	 *
+	 * @var map[string][]interface{}
+	 */
+	MiddlewareGroups map[string][]interface{}
+
+	/**
+	 * The application's route middleware.
+	 *
+	 * These middleware may be assigned to groups or used individually.
+	 *
+	 * @var map[string]interface{}
+	 */
+	RouteMiddleware map[string]interface{}
+
+	/**
+	 * The callbacks to run when a request has been received.
+	 *
+	 * @var []func(*RequestReceived)
+	 */
+	receivingCallbacks []func(*RequestReceived)
+
+	/**
+	 * The callbacks to run when a request has been handled.
+	 *
+	 * @var []func(*RequestHandled)
+	 */
+	handledCallbacks []func(*RequestHandled)
+
+	/**
+	 * The event dispatcher the kernel events are also sent to.
+	 *
+	 * @var EventsContract.Dispatcher
+	 */
+	events EventsContract.Dispatcher
 }
 
 func NewKernel() (this *Kernel) {
@@ -29,6 +87,18 @@ func NewKernel() (this *Kernel) {
 		&Bootstrap.BootProviders{},
 	}
 
+	this.Middleware = []interface{}{
+		&HttpMiddleware.TrustProxies{},
+		&HttpMiddleware.TrustHosts{},
+		&HttpMiddleware.ValidatePostSize{},
+		&CookieMiddleware.AddQueuedCookiesToResponse{},
+	}
+	this.MiddlewareGroups = map[string][]interface{}{}
+	this.RouteMiddleware = map[string]interface{}{
+		"cache.headers": &HttpMiddleware.SetCacheHeaders{},
+		"throttle":      &RoutingMiddleware.ThrottleRequests{},
+	}
+
 	return this
 }
 
@@ -36,33 +106,122 @@ func (this *Kernel) Bootstrap() {
 	if !this.App.HasBeenBootstrapped() {
 		this.App.BootstrapWith(this.bootstrappers)
 	}
+
+	if events, ok := this.App.Make("events").(EventsContract.Dispatcher); ok {
+		this.events = events
+	}
+
+	this.syncMiddlewareToRouter()
+}
+
+/**
+ * Sync the current state of the middleware to the router.
+ *
+ * @return void
+ */
+func (this *Kernel) syncMiddlewareToRouter() {
+	for i, middleware := range this.Middleware {
+		this.Middleware[i] = this.resolveMiddleware(middleware)
+	}
+
+	for group, middleware := range this.MiddlewareGroups {
+		for i, item := range middleware {
+			middleware[i] = this.resolveMiddleware(item)
+		}
+		this.Router.MiddlewareGroup(group, middleware)
+	}
+
+	for key, middleware := range this.RouteMiddleware {
+		this.Router.AliasMiddleware(key, this.resolveMiddleware(middleware))
+	}
+}
+
+/**
+ * Resolve the dependencies of a middleware instance through the container.
+ *
+ * @param  interface{}  middleware
+ * @return interface{}
+ */
+func (this *Kernel) resolveMiddleware(middleware interface{}) interface{} {
+	if Type := reflect.TypeOf(middleware); Type != nil && Type.Kind() == reflect.Ptr && Type.Elem().Kind() == reflect.Struct {
+		return this.App.Build(middleware, Type.String())
+	}
+	return middleware
+}
+
+/**
+ * Add a new middleware to beginning of the stack.
+ *
+ * @param  interface{}  middleware
+ * @return this
+ */
+func (this *Kernel) PrependMiddleware(middleware interface{}) *Kernel {
+	this.Middleware = append([]interface{}{middleware}, this.Middleware...)
+
+	return this
+}
+
+/**
+ * Add a new middleware to end of the stack.
+ *
+ * @param  interface{}  middleware
+ * @return this
+ */
+func (this *Kernel) PushMiddleware(middleware interface{}) *Kernel {
+	this.Middleware = append(this.Middleware, middleware)
+
+	return this
 }
 
 func (this *Kernel) Handle() {
 	this.Bootstrap()
 
+	server := &http.Server{Addr: "127.0.0.1:8000", Handler: this}
+
+	// The server stops on an interrupt or a termination signal, letting the
+	// requests in flight finish before the application terminates.
+	stopped := make(chan struct{})
+	go func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		<-signals
+		signal.Stop(signals)
+
+		server.Shutdown(context.Background())
+		close(stopped)
+	}()
+
 	fmt.Println(`development server started: <http://127.0.0.1:8000>`)
-	panic(http.ListenAndServe("127.0.0.1:8000", this))
+	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+		panic(err)
+	}
 	// http.ListenAndServeTLS(addr, certFile, keyFile, this)
+
+	<-stopped
+	this.Terminate()
 }
 
-func (this *Kernel) SendRequestThroughRouter(request *Http.Request) {
-	this.Router.Dispatch(request).Send()
-	// request.Error(fasthttp.StatusMessage(fasthttp.StatusNotFound),
-	// fasthttp.StatusNotFound)
+/**
+ * Send the given request through the middleware / router.
+ *
+ * @param  Http\Request  request
+ * @return Http\Response
+ */
+func (this *Kernel) SendRequestThroughRouter(request *Http.Request) *Http.Response {
+	return Routing.NewPipeline(this.App).Send(request).Through(this.Middleware).Then(this.dispatchToRouter())
 }
 
 /**
  * Get the route dispatcher callback.
  *
- * @return \Closure
+ * @return Http.Next
  */
-// func (this *Kernel) dispatchToRouter() {
-// 	return func(request *Http.Request) {
-// 		// this.App.instance('request', request);
-// 		return this.Router.Dispatch(request)
-// 	}
-// }
+func (this *Kernel) dispatchToRouter() Http.Next {
+	return func(request *Http.Request) *Http.Response {
+		// this.App.instance('request', request);
+		return this.Router.Dispatch(request)
+	}
+}
 
 /**
  * Get the route dispatcher callback.
@@ -72,34 +231,222 @@ func (this *Kernel) SendRequestThroughRouter(request *Http.Request) {
 // func (this *Kernel) dispatchToRouter() *Routing.Router {
 // }
 
-func (this *Kernel) ServeFile(uri string, root string) {
-	// if len(uri) < 10 || uri[len(uri)-10:] != "/*fileuri" {
-	// 	panic("uri must end with /*fileuri in uri '" + uri + "'")
-	// }
-
-	// fileServer := http.FileServer(root)
+/**
+ * Serve the files of a directory under the given URI, the public directory
+ * of the application by default.
+ *
+ * @param  string  uri
+ * @param  string  root
+ * @return *Routing.Route
+ */
+func (this *Kernel) ServeFile(uri string, root ...string) *Routing.Route {
+	root = append(root, this.App.PublicPath())
 
-	// this.GET(uri, func(w http.ResponseWriter, req *http.Request, ps Params) {
-	// 	req.URL.Path = ps.ByName("fileuri")
-	// 	fileServer.ServeHTTP(w, req)
-	// })
+	return this.Router.Static(uri, root[0])
 }
 
 func (this *Kernel) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	writer := newResponseWriter(response)
+
+	var _request *Http.Request
+
+	// Exceptions thrown outside of the router, while the request is created, by
+	// the listeners of the handled event or while the response is sent, are
+	// reported and rendered here. A response already started is left as is.
+	defer func() {
+		if err := recover(); err != nil {
+			this.reportException(err)
+			if !writer.started {
+				this.sendException(writer, _request, err)
+			}
+		}
+	}()
+
+	_request = Http.NewRequest(this.App, writer, request)
+
+	_response := this.handleRequest(_request)
+
+	this.fireHandled(NewRequestHandled(_request, _response))
+
+	_response.Prepare(_request).Send()
+}
+
+/**
+ * Render the exception and send it, without a request when creating the
+ * request itself failed.
+ *
+ * @param  http.ResponseWriter  writer
+ * @param  Http.Request  request
+ * @param  interface{}  err
+ * @return void
+ */
+func (this *Kernel) sendException(writer http.ResponseWriter, request *Http.Request, err interface{}) {
+	response := this.renderException(request, err)
+
+	if request != nil {
+		response.Prepare(request).Send()
+		return
+	}
+
+	for key, values := range response.Headers {
+		writer.Header()[key] = values
+	}
+	writer.WriteHeader(response.Status())
+	writer.Write(response.Content())
+}
+
+/**
+ * Handle an incoming HTTP request, turning an exception into its response.
+ *
+ * @param  Http.Request  request
+ * @return Http.Response
+ */
+func (this *Kernel) handleRequest(request *Http.Request) (response *Http.Response) {
 	defer func() {
 		if err := recover(); err != nil {
-			// var buf [4096]byte
-			// n := runtime.Stack(buf[:], false)
-			// if e, ok := err.(Errors.Exception); ok {
-			// 	fmt.Printf("%+v\n%s\n", e.GetMessage(), string(buf[:n]))
-			// 	fmt.Fprintf(response, "%+v\n%s\n", e.GetMessage(), string(buf[:n]))
-			// } else {
-			// fmt.Printf("%+v\n%s\n", err, string(buf[:n]))
-			fmt.Fprintf(response, "%+v\n", err)
-			// }
+			this.reportException(err)
+			response = this.renderException(request, err)
 		}
 	}()
-	this.SendRequestThroughRouter(Http.NewRequest(this.App, response, request))
+
+	this.fireReceiving(NewRequestReceived(request))
+
+	return this.SendRequestThroughRouter(request)
+}
+
+/**
+ * Report the exception to the exception handler.
+ *
+ * @param  interface{}  err
+ * @return void
+ */
+func (this *Kernel) reportException(err interface{}) {
+	if handler, ok := this.App.Make("exception.handler").(DebugContract.ExceptionHandler); ok {
+		handler.Report(err)
+	}
+}
+
+/**
+ * Render the exception to a response.
+ *
+ * @param  Http\Request  request
+ * @param  interface{}  err
+ * @return Http.Response
+ */
+func (this *Kernel) renderException(request *Http.Request, err interface{}) *Http.Response {
+	if e, ok := err.(Errors.ModelNotFoundException); ok {
+		err = Errors.NewNotFoundHttpException(e.GetMessage())
+	}
+
+	if e, ok := err.(Errors.HttpExceptionInterface); ok {
+		headers := map[string][]string{}
+		for key, values := range e.GetHeaders() {
+			headers[key] = append([]string{}, values...)
+		}
+		return this.prepareExceptionResponse(request, e.GetMessage(), e.GetStatusCode(), headers)
+	}
+
+	// The details of the error may reveal the internals of the application, so
+	// they are only sent while debugging, the client gets a generic message otherwise.
+	message := "Server Error"
+	if this.isDebugging() {
+		message = fmt.Sprintf("%+v\n", err)
+	}
+
+	return this.prepareExceptionResponse(request, message, Http.HTTP_INTERNAL_SERVER_ERROR, map[string][]string{})
+}
+
+/**
+ * Determine if the application is in debug mode.
+ *
+ * @return bool
+ */
+func (this *Kernel) isDebugging() bool {
+	if !this.App.Bound("config") {
+		return false
+	}
+
+	config, ok := this.App.Make("config").(RepositoryContract.Repository)
+	if !ok {
+		return false
+	}
+
+	debug, _ := config.Get("app.debug", false).(bool)
+
+	return debug
+}
+
+/**
+ * Create the response for an exception, as JSON when the client expects it.
+ *
+ * @param  Http.Request  request
+ * @param  string  message
+ * @param  int  status
+ * @param  map[string][]string  headers
+ * @return Http.Response
+ */
+func (this *Kernel) prepareExceptionResponse(request *Http.Request, message string, status int, headers map[string][]string) *Http.Response {
+	if request != nil && request.ExpectsJson() {
+		return Http.NewResponse(map[string]interface{}{"message": strings.TrimSpace(message)}, status, headers)
+	}
+
+	return Http.NewResponse(message, status, headers)
+}
+
+/**
+ * Register a listener that runs when a request has been received.
+ *
+ * @param  func(*RequestReceived)  callback
+ * @return this
+ */
+func (this *Kernel) Receiving(callback func(*RequestReceived)) *Kernel {
+	this.receivingCallbacks = append(this.receivingCallbacks, callback)
+
+	return this
+}
+
+/**
+ * Register a listener that runs when a request has been handled.
+ *
+ * @param  func(*RequestHandled)  callback
+ * @return this
+ */
+func (this *Kernel) Handled(callback func(*RequestHandled)) *Kernel {
+	this.handledCallbacks = append(this.handledCallbacks, callback)
+
+	return this
+}
+
+/**
+ * Call the request received listeners.
+ *
+ * @param  *RequestReceived  event
+ * @return void
+ */
+func (this *Kernel) fireReceiving(event *RequestReceived) {
+	for _, callback := range this.receivingCallbacks {
+		callback(event)
+	}
+
+	if this.events != nil {
+		this.events.Dispatch(event)
+	}
+}
+
+/**
+ * Call the request handled listeners.
+ *
+ * @param  *RequestHandled  event
+ * @return void
+ */
+func (this *Kernel) fireHandled(event *RequestHandled) {
+	for _, callback := range this.handledCallbacks {
+		callback(event)
+	}
+
+	if this.events != nil {
+		this.events.Dispatch(event)
+	}
 }
 
 /**
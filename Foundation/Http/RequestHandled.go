@@ -0,0 +1,32 @@
+package Http
+
+import (
+	"github.com/larisgo/framework/Http"
+)
+
+type RequestHandled struct {
+	/**
+	 * The request instance.
+	 *
+	 * @var *Http.Request
+	 */
+	Request *Http.Request
+
+	/**
+	 * The response instance.
+	 *
+	 * @var *Http.Response
+	 */
+	Response *Http.Response
+}
+
+/**
+ * Create a new event instance.
+ *
+ * @param  *Http.Request  request
+ * @param  *Http.Response  response
+ * @return *RequestHandled
+ */
+func NewRequestHandled(request *Http.Request, response *Http.Response) *RequestHandled {
+	return &RequestHandled{Request: request, Response: response}
+}
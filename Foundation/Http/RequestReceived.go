@@ -0,0 +1,24 @@
+package Http
+
+import (
+	"github.com/larisgo/framework/Http"
+)
+
+type RequestReceived struct {
+	/**
+	 * The request instance.
+	 *
+	 * @var *Http.Request
+	 */
+	Request *Http.Request
+}
+
+/**
+ * Create a new event instance.
+ *
+ * @param  *Http.Request  request
+ * @return *RequestReceived
+ */
+func NewRequestReceived(request *Http.Request) *RequestReceived {
+	return &RequestReceived{Request: request}
+}
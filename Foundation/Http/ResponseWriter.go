@@ -0,0 +1,80 @@
+package Http
+
+import (
+	"bufio"
+	"github.com/larisgo/framework/Errors"
+	"net"
+	"net/http"
+)
+
+/**
+ * A response writer remembering whether the response has been started, so
+ * that an exception thrown while it is sent is not rendered into it.
+ */
+type responseWriter struct {
+	http.ResponseWriter
+
+	/**
+	 * Whether the status line has been written.
+	 *
+	 * @var bool
+	 */
+	started bool
+}
+
+/**
+ * Create a new response writer wrapping the given one.
+ *
+ * @param  http.ResponseWriter  writer
+ * @return *responseWriter
+ */
+func newResponseWriter(writer http.ResponseWriter) *responseWriter {
+	return &responseWriter{ResponseWriter: writer}
+}
+
+/**
+ * Write the status line and the headers.
+ *
+ * @param  int  statusCode
+ * @return void
+ */
+func (this *responseWriter) WriteHeader(statusCode int) {
+	this.started = true
+	this.ResponseWriter.WriteHeader(statusCode)
+}
+
+/**
+ * Write a part of the body.
+ *
+ * @param  []byte  p
+ * @return int, error
+ */
+func (this *responseWriter) Write(p []byte) (int, error) {
+	this.started = true
+	return this.ResponseWriter.Write(p)
+}
+
+/**
+ * Flush the buffered data to the client, when the wrapped writer supports it.
+ *
+ * @return void
+ */
+func (this *responseWriter) Flush() {
+	if flusher, ok := this.ResponseWriter.(http.Flusher); ok {
+		this.started = true
+		flusher.Flush()
+	}
+}
+
+/**
+ * Take over the connection, when the wrapped writer supports it.
+ *
+ * @return net.Conn, *bufio.ReadWriter, error
+ */
+func (this *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if hijacker, ok := this.ResponseWriter.(http.Hijacker); ok {
+		this.started = true
+		return hijacker.Hijack()
+	}
+	return nil, nil, Errors.NewRuntimeException("The response writer does not support hijacking.")
+}
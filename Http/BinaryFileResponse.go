@@ -0,0 +1,210 @@
+package Http
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http/HttpFoundation"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+/**
+ * Creates a response sending the given file. The file is streamed from disk
+ * when the response is sent, honouring the "Range", "If-Range" and conditional
+ * request headers.
+ *
+ * @param  string  file  The path of the file to send
+ * @param  int  status
+ * @param  map[string][]string  headers
+ * @return *Response
+ *
+ * @throws Errors.FileNotFoundException
+ */
+func NewBinaryFileResponse(file string, status int, headers ...map[string][]string) (this *Response) {
+	headers = append(headers, map[string][]string{})
+
+	contentType := http.Header(headers[0]).Get("Content-Type")
+
+	this = NewResponse("", status, headers[0])
+	this.SetFile(file)
+	if contentType != "" {
+		this.Header("Content-Type", contentType)
+	}
+	this.SetAutoLastModified()
+
+	return this
+}
+
+/**
+ * Sets the file to send.
+ *
+ * @param  string  file
+ * @return $this
+ *
+ * @throws Errors.FileNotFoundException
+ */
+func (this *Response) SetFile(file string) *Response {
+	info, err := os.Stat(file)
+	if err != nil || info.IsDir() {
+		panic(Errors.NewFileNotFoundException(fmt.Sprintf(`The file "%s" does not exist.`, file)))
+	}
+
+	this.file = file
+	this.content = []byte{}
+	this.callback = nil
+	this.Header("Content-Type", this.guessMimeType(file))
+
+	return this
+}
+
+/**
+ * Gets the file sent by the response.
+ *
+ * @return string
+ */
+func (this *Response) GetFile() string {
+	return this.file
+}
+
+/**
+ * Determine if the content of the response is a file.
+ *
+ * @return bool
+ */
+func (this *Response) IsFile() bool {
+	return this.file != ""
+}
+
+/**
+ * Automatically sets the Last-Modified header according the file modification date.
+ *
+ * @return $this
+ */
+func (this *Response) SetAutoLastModified() *Response {
+	if info, err := os.Stat(this.file); err == nil {
+		this.SetLastModified(info.ModTime())
+	}
+
+	return this
+}
+
+/**
+ * Sets the Content-Disposition header with the given filename.
+ *
+ * @param  string  disposition  DISPOSITION_INLINE or DISPOSITION_ATTACHMENT
+ * @param  string  filename     Optionally use this UTF-8 encoded filename instead of the real name of the file
+ * @param  string  filenameFallback  A fallback filename, containing only ASCII characters. Defaults to an automatically encoded filename
+ * @return $this
+ */
+func (this *Response) SetContentDisposition(disposition string, filename string, filenameFallback ...string) *Response {
+	if filename == "" && this.file != "" {
+		filename = filepath.Base(this.file)
+	}
+
+	filenameFallback = append(filenameFallback, "")
+	fallback := filenameFallback[0]
+	if fallback == "" {
+		// Characters that are not allowed in the fallback are replaced, so any
+		// filename can be given.
+		encoded := []byte{}
+		for _, char := range filename {
+			if char < 0x20 || char > 0x7e || char == '%' || char == '/' || char == '\\' {
+				encoded = append(encoded, '_')
+			} else {
+				encoded = append(encoded, byte(char))
+			}
+		}
+		fallback = string(encoded)
+	}
+
+	this.Header("Content-Disposition", HttpFoundation.MakeDisposition(disposition, filename, fallback))
+
+	return this
+}
+
+/**
+ * If this is set to true, the file will be unlinked after the request is sent.
+ *
+ * @param  bool  shouldDelete
+ * @return $this
+ */
+func (this *Response) DeleteFileAfterSend(shouldDelete ...bool) *Response {
+	shouldDelete = append(shouldDelete, true)
+
+	this.deleteFileAfterSend = shouldDelete[0]
+
+	return this
+}
+
+/**
+ * Sends the file. A successful response is served with http.ServeContent,
+ * which answers ranges and conditional requests with the ETag and
+ * Last-Modified headers already sent.
+ *
+ * @return $this
+ */
+func (this *Response) sendFile() *Response {
+	if this.deleteFileAfterSend {
+		defer os.Remove(this.file)
+	}
+
+	file, err := os.Open(this.file)
+	if err != nil {
+		this.response.WriteHeader(HTTP_INTERNAL_SERVER_ERROR)
+		return this
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		this.response.WriteHeader(HTTP_INTERNAL_SERVER_ERROR)
+		return this
+	}
+
+	if this.statusCode != HTTP_OK || this.request == nil {
+		this.response.Header().Set("Content-Length", strconv.FormatInt(info.Size(), 10))
+		this.response.WriteHeader(this.statusCode)
+		if this.request == nil || this.request.Method != http.MethodHead {
+			io.Copy(this.response, file)
+		}
+		return this
+	}
+
+	modified := time.Time{}
+	if value := this.Headers.Get("Last-Modified"); value != "" {
+		modified, _ = http.ParseTime(value)
+	}
+
+	http.ServeContent(this.response, this.request, info.Name(), modified, file)
+
+	return this
+}
+
+/**
+ * Guess the MIME type of the file from its extension, or else from its
+ * first bytes.
+ *
+ * @param  string  file
+ * @return string
+ */
+func (this *Response) guessMimeType(file string) string {
+	if mimeType := mime.TypeByExtension(filepath.Ext(file)); mimeType != "" {
+		return mimeType
+	}
+
+	if handle, err := os.Open(file); err == nil {
+		defer handle.Close()
+
+		buffer := make([]byte, 512)
+		n, _ := io.ReadFull(handle, buffer)
+
+		return http.DetectContentType(buffer[:n])
+	}
+
+	return "application/octet-stream"
+}
@@ -0,0 +1,154 @@
+package Http
+
+import (
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/larisgo/framework/Errors"
+)
+
+const binaryFileContent = "0123456789abcdefghij"
+
+func newBinaryFile(t *testing.T, name string) (string, func()) {
+	directory, err := ioutil.TempDir("", "binary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(directory, name)
+	if err := ioutil.WriteFile(file, []byte(binaryFileContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	return file, func() { os.RemoveAll(directory) }
+}
+
+func sendFileResponse(response *Response, method string, headers map[string]string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(method, "/file", nil)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	response.Prepare(NewRequest(nil, recorder, request)).Send()
+
+	return recorder
+}
+
+func TestBinaryFileResponseSendsTheWholeFile(t *testing.T) {
+	file, cleanup := newBinaryFile(t, "notes.txt")
+	defer cleanup()
+
+	response := NewBinaryFileResponse(file, HTTP_OK)
+	if !response.IsFile() || response.GetFile() != file {
+		t.Fatal("expected a file response")
+	}
+	if response.Headers.Get("Last-Modified") == "" {
+		t.Error("expected the Last-Modified header to be set")
+	}
+
+	recorder := sendFileResponse(response, "GET", nil)
+	if recorder.Code != HTTP_OK || recorder.Body.String() != binaryFileContent {
+		t.Errorf("unexpected response %d %q", recorder.Code, recorder.Body.String())
+	}
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain") {
+		t.Errorf("unexpected content type %q", contentType)
+	}
+	if recorder.Header().Get("Accept-Ranges") != "bytes" {
+		t.Error("expected ranges to be accepted")
+	}
+}
+
+func TestBinaryFileResponseAnswersRanges(t *testing.T) {
+	file, cleanup := newBinaryFile(t, "notes.txt")
+	defer cleanup()
+
+	recorder := sendFileResponse(NewBinaryFileResponse(file, HTTP_OK), "GET", map[string]string{"Range": "bytes=2-5"})
+	if recorder.Code != HTTP_PARTIAL_CONTENT || recorder.Body.String() != "2345" {
+		t.Errorf("unexpected response %d %q", recorder.Code, recorder.Body.String())
+	}
+	if contentRange := recorder.Header().Get("Content-Range"); contentRange != "bytes 2-5/20" {
+		t.Errorf("unexpected Content-Range %q", contentRange)
+	}
+
+	recorder = sendFileResponse(NewBinaryFileResponse(file, HTTP_OK), "GET", map[string]string{"Range": "bytes=50-60"})
+	if recorder.Code != HTTP_REQUESTED_RANGE_NOT_SATISFIABLE {
+		t.Errorf("expected an unsatisfiable range, got %d", recorder.Code)
+	}
+}
+
+func TestBinaryFileResponseHonoursIfRange(t *testing.T) {
+	file, cleanup := newBinaryFile(t, "notes.txt")
+	defer cleanup()
+
+	response := NewBinaryFileResponse(file, HTTP_OK).SetAutoEtag()
+	etag := response.Headers.Get("ETag")
+
+	recorder := sendFileResponse(response, "GET", map[string]string{"Range": "bytes=0-3", "If-Range": etag})
+	if recorder.Code != HTTP_PARTIAL_CONTENT || recorder.Body.String() != "0123" {
+		t.Errorf("expected the range of an unchanged file, got %d %q", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = sendFileResponse(NewBinaryFileResponse(file, HTTP_OK).SetAutoEtag(), "GET", map[string]string{"Range": "bytes=0-3", "If-Range": `"changed"`})
+	if recorder.Code != HTTP_OK || recorder.Body.String() != binaryFileContent {
+		t.Errorf("expected the whole file once it changed, got %d %q", recorder.Code, recorder.Body.String())
+	}
+
+	recorder = sendFileResponse(NewBinaryFileResponse(file, HTTP_OK).SetAutoEtag(), "GET", map[string]string{"If-None-Match": etag})
+	if recorder.Code != HTTP_NOT_MODIFIED || recorder.Body.Len() != 0 {
+		t.Errorf("expected a not modified response, got %d", recorder.Code)
+	}
+}
+
+func TestBinaryFileResponseWithAnotherStatusSkipsRanges(t *testing.T) {
+	file, cleanup := newBinaryFile(t, "notes.txt")
+	defer cleanup()
+
+	recorder := sendFileResponse(NewBinaryFileResponse(file, HTTP_NOT_FOUND), "GET", map[string]string{"Range": "bytes=0-3"})
+	if recorder.Code != HTTP_NOT_FOUND || recorder.Body.String() != binaryFileContent {
+		t.Errorf("unexpected response %d %q", recorder.Code, recorder.Body.String())
+	}
+	if length := recorder.Header().Get("Content-Length"); length != "20" {
+		t.Errorf("unexpected Content-Length %q", length)
+	}
+}
+
+func TestBinaryFileResponseDisposition(t *testing.T) {
+	file, cleanup := newBinaryFile(t, "notes.txt")
+	defer cleanup()
+
+	response := NewBinaryFileResponse(file, HTTP_OK).SetContentDisposition("attachment", "")
+	if disposition := response.Headers.Get("Content-Disposition"); disposition != `attachment; filename=notes.txt` {
+		t.Errorf("unexpected disposition %q", disposition)
+	}
+
+	response.SetContentDisposition("attachment", "résumé 100%.txt")
+	disposition := response.Headers.Get("Content-Disposition")
+	if !strings.Contains(disposition, `filename="r_sum_ 100_.txt"`) || !strings.Contains(disposition, "filename*=utf-8''r%C3%A9sum%C3%A9%20100%25.txt") {
+		t.Errorf("unexpected disposition %q", disposition)
+	}
+}
+
+func TestBinaryFileResponseDeletesTheFileAfterSend(t *testing.T) {
+	file, cleanup := newBinaryFile(t, "notes.txt")
+	defer cleanup()
+
+	sendFileResponse(NewBinaryFileResponse(file, HTTP_OK).DeleteFileAfterSend(), "GET", nil)
+
+	if _, err := os.Stat(file); !os.IsNotExist(err) {
+		t.Error("the file was not deleted")
+	}
+}
+
+func TestBinaryFileResponseOfMissingFile(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(Errors.FileNotFoundException); !ok {
+			t.Error("expected a file not found exception")
+		}
+	}()
+
+	NewBinaryFileResponse(filepath.Join(os.TempDir(), "missing-binary-file"), HTTP_OK)
+}
@@ -0,0 +1,351 @@
+package Http
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * Sets the ETag value.
+ *
+ * @param  string  etag  The ETag unique identifier, or an empty string to remove the header
+ * @param  bool  weak  Whether you want a weak ETag or not
+ * @return $this
+ */
+func (this *Response) SetEtag(etag string, weak ...bool) *Response {
+	weak = append(weak, false)
+
+	if etag == "" {
+		this.Headers.Del("ETag")
+		return this
+	}
+
+	if !strings.HasPrefix(etag, `"`) {
+		etag = `"` + etag + `"`
+	}
+	if weak[0] {
+		etag = "W/" + etag
+	}
+	this.Header("ETag", etag)
+
+	return this
+}
+
+/**
+ * Returns the literal value of the ETag HTTP header.
+ *
+ * @return string
+ */
+func (this *Response) GetEtag() string {
+	return this.Headers.Get("ETag")
+}
+
+/**
+ * Automatically sets the ETag header. The ETag of a file is made of its size
+ * and modification date, which avoids reading the whole file to hash it, any
+ * other content is hashed.
+ *
+ * @param  bool  weak
+ * @return $this
+ */
+func (this *Response) SetAutoEtag(weak ...bool) *Response {
+	if this.file != "" {
+		if info, err := os.Stat(this.file); err == nil {
+			this.SetEtag(fmt.Sprintf("%x-%x", info.ModTime().Unix(), info.Size()), weak...)
+		}
+		return this
+	}
+
+	hash := md5.Sum(this.content)
+
+	return this.SetEtag(hex.EncodeToString(hash[:]), weak...)
+}
+
+/**
+ * Sets the Last-Modified HTTP header with a time value. A zero time removes
+ * the header.
+ *
+ * @param  time.Time  date
+ * @return $this
+ */
+func (this *Response) SetLastModified(date time.Time) *Response {
+	if date.IsZero() {
+		this.Headers.Del("Last-Modified")
+		return this
+	}
+
+	this.Header("Last-Modified", date.UTC().Format(http.TimeFormat))
+
+	return this
+}
+
+/**
+ * Returns the Last-Modified HTTP header as a time, the zero time when the
+ * header is missing or invalid.
+ *
+ * @return time.Time
+ */
+func (this *Response) GetLastModified() time.Time {
+	date, _ := http.ParseTime(this.Headers.Get("Last-Modified"))
+
+	return date
+}
+
+/**
+ * Modifies the response so that it conforms to the rules defined for a 304 status code.
+ *
+ * This sets the status, removes the body, and discards any headers
+ * that MUST NOT be included in 304 responses.
+ *
+ * @see https://tools.ietf.org/html/rfc2616#section-10.3.5
+ *
+ * @return $this
+ */
+func (this *Response) SetNotModified() *Response {
+	this.SetStatusCode(HTTP_NOT_MODIFIED)
+	this.SetContent("")
+
+	// remove headers that MUST NOT be included with 304 Not Modified responses
+	for _, header := range []string{"Allow", "Content-Encoding", "Content-Language", "Content-Length", "Content-MD5", "Content-Type", "Last-Modified"} {
+		this.Headers.Del(header)
+	}
+
+	return this
+}
+
+/**
+ * Determines if the Response validators (ETag, Last-Modified) match
+ * a conditional value specified in the Request.
+ *
+ * If the Response is not modified, it sets the status code to 304 and
+ * removes the actual content by calling the setNotModified() method.
+ *
+ * @param  *Request  request
+ * @return bool
+ */
+func (this *Response) IsNotModified(request *Request) bool {
+	if !request.IsMethodCacheable() {
+		return false
+	}
+
+	notModified := false
+	lastModified := this.Headers.Get("Last-Modified")
+	modifiedSince := request.Headers.Get("If-Modified-Since")
+
+	if ifNoneMatch, etag := request.GetETags(), this.GetEtag(); len(ifNoneMatch) > 0 && etag != "" {
+		// The If-None-Match header is compared weakly, so a weak ETag matches
+		// its strong counterpart.
+		etag = strings.TrimPrefix(etag, "W/")
+
+		for _, item := range ifNoneMatch {
+			if item = strings.TrimPrefix(item, "W/"); item == etag || item == "*" {
+				notModified = true
+				break
+			}
+		}
+	} else if modifiedSince != "" && lastModified != "" {
+		since, sinceErr := http.ParseTime(modifiedSince)
+		modified, modifiedErr := http.ParseTime(lastModified)
+		notModified = sinceErr == nil && modifiedErr == nil && !since.Before(modified)
+	}
+
+	if notModified {
+		this.SetNotModified()
+	}
+
+	return notModified
+}
+
+/**
+ * Marks the response as "public".
+ *
+ * It makes the response eligible for serving other clients.
+ *
+ * @return $this
+ */
+func (this *Response) SetPublic() *Response {
+	this.AddCacheControlDirective("public")
+	this.RemoveCacheControlDirective("private")
+
+	return this
+}
+
+/**
+ * Marks the response as "private".
+ *
+ * It makes the response ineligible for serving other clients.
+ *
+ * @return $this
+ */
+func (this *Response) SetPrivate() *Response {
+	this.RemoveCacheControlDirective("public")
+	this.AddCacheControlDirective("private")
+
+	return this
+}
+
+/**
+ * Sets the number of seconds after which the response should no longer be considered fresh.
+ *
+ * This methods sets the Cache-Control max-age directive.
+ *
+ * @param  int  value
+ * @return $this
+ */
+func (this *Response) SetMaxAge(value int) *Response {
+	this.AddCacheControlDirective("max-age", strconv.Itoa(value))
+
+	return this
+}
+
+/**
+ * Sets the number of seconds after which the response should no longer be considered fresh by shared caches.
+ *
+ * This methods sets the Cache-Control s-maxage directive.
+ *
+ * @param  int  value
+ * @return $this
+ */
+func (this *Response) SetSharedMaxAge(value int) *Response {
+	this.SetPublic()
+	this.AddCacheControlDirective("s-maxage", strconv.Itoa(value))
+
+	return this
+}
+
+/**
+ * Marks a response as safe according to RFC5861.
+ *
+ * @param  bool  immutable
+ * @return $this
+ */
+func (this *Response) SetImmutable(immutable ...bool) *Response {
+	immutable = append(immutable, true)
+
+	if immutable[0] {
+		this.AddCacheControlDirective("immutable")
+	} else {
+		this.RemoveCacheControlDirective("immutable")
+	}
+
+	return this
+}
+
+/**
+ * Returns true if the response is marked as "immutable".
+ *
+ * @return bool
+ */
+func (this *Response) IsImmutable() bool {
+	return this.HasCacheControlDirective("immutable")
+}
+
+/**
+ * Adds a custom Cache-Control directive.
+ *
+ * @param  string  key
+ * @param  string  value
+ * @return $this
+ */
+func (this *Response) AddCacheControlDirective(key string, value ...string) *Response {
+	value = append(value, "")
+
+	directives := this.getCacheControlDirectives()
+	directives[strings.ToLower(key)] = value[0]
+
+	return this.setCacheControlDirectives(directives)
+}
+
+/**
+ * Returns true if the Cache-Control directive is defined.
+ *
+ * @param  string  key
+ * @return bool
+ */
+func (this *Response) HasCacheControlDirective(key string) bool {
+	_, ok := this.getCacheControlDirectives()[strings.ToLower(key)]
+
+	return ok
+}
+
+/**
+ * Returns a Cache-Control directive value by name.
+ *
+ * @param  string  key
+ * @return string
+ */
+func (this *Response) GetCacheControlDirective(key string) string {
+	return this.getCacheControlDirectives()[strings.ToLower(key)]
+}
+
+/**
+ * Removes a Cache-Control directive.
+ *
+ * @param  string  key
+ * @return $this
+ */
+func (this *Response) RemoveCacheControlDirective(key string) *Response {
+	directives := this.getCacheControlDirectives()
+	delete(directives, strings.ToLower(key))
+
+	return this.setCacheControlDirectives(directives)
+}
+
+/**
+ * Parse the directives of the Cache-Control header.
+ *
+ * @return map[string]string
+ */
+func (this *Response) getCacheControlDirectives() map[string]string {
+	directives := map[string]string{}
+
+	for _, directive := range strings.Split(this.Headers.Get("Cache-Control"), ",") {
+		if directive = strings.TrimSpace(directive); directive == "" {
+			continue
+		}
+
+		parts := strings.SplitN(directive, "=", 2)
+		parts = append(parts, "")
+		directives[strings.ToLower(strings.TrimSpace(parts[0]))] = strings.Trim(strings.TrimSpace(parts[1]), `"`)
+	}
+
+	return directives
+}
+
+/**
+ * Write the directives into the Cache-Control header, sorted by name.
+ *
+ * @param  map[string]string  directives
+ * @return $this
+ */
+func (this *Response) setCacheControlDirectives(directives map[string]string) *Response {
+	if len(directives) == 0 {
+		this.Headers.Del("Cache-Control")
+		return this
+	}
+
+	keys := []string{}
+	for key := range directives {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := []string{}
+	for _, key := range keys {
+		if value := directives[key]; value != "" {
+			parts = append(parts, key+"="+value)
+		} else {
+			parts = append(parts, key)
+		}
+	}
+	this.Header("Cache-Control", strings.Join(parts, ", "))
+
+	return this
+}
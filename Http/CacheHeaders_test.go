@@ -0,0 +1,128 @@
+package Http
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newCacheRequest(method string, headers map[string]string) *Request {
+	request := httptest.NewRequest(method, "/", nil)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	return NewRequest(nil, httptest.NewRecorder(), request)
+}
+
+func TestEtag(t *testing.T) {
+	response := NewResponse("content", HTTP_OK)
+
+	if response.SetEtag("abc").GetEtag() != `"abc"` {
+		t.Errorf("expected a quoted ETag, got %s", response.GetEtag())
+	}
+	if response.SetEtag("abc", true).GetEtag() != `W/"abc"` {
+		t.Errorf("expected a weak ETag, got %s", response.GetEtag())
+	}
+	if response.SetEtag("").GetEtag() != "" {
+		t.Error("expected the ETag to be removed")
+	}
+	if response.SetAutoEtag().GetEtag() != `"9a0364b9e99bb480dd25e1f0284c8555"` {
+		t.Errorf("expected the md5 of the content, got %s", response.GetEtag())
+	}
+}
+
+func TestNotModifiedByEtag(t *testing.T) {
+	for ifNoneMatch, notModified := range map[string]bool{
+		`"abc"`:           true,
+		`W/"abc"`:         true,
+		`"xyz", "abc"`:    true,
+		`*`:               true,
+		`"xyz"`:           false,
+		`"abc-different"`: false,
+	} {
+		response := NewResponse("content", HTTP_OK).SetEtag("abc")
+		response.Header("Content-Type", "text/plain")
+
+		if response.IsNotModified(newCacheRequest("GET", map[string]string{"If-None-Match": ifNoneMatch})) != notModified {
+			t.Errorf("If-None-Match %s: expected not modified to be %v", ifNoneMatch, notModified)
+		}
+		if notModified {
+			if response.Status() != HTTP_NOT_MODIFIED || len(response.Content()) != 0 || response.Headers.Get("Content-Type") != "" {
+				t.Errorf("If-None-Match %s: the response was not turned into a 304", ifNoneMatch)
+			}
+			if response.GetEtag() == "" {
+				t.Errorf("If-None-Match %s: the ETag was removed from the 304", ifNoneMatch)
+			}
+		} else if response.Status() != HTTP_OK {
+			t.Errorf("If-None-Match %s: the status changed", ifNoneMatch)
+		}
+	}
+}
+
+func TestNotModifiedSince(t *testing.T) {
+	modified := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	for since, notModified := range map[time.Time]bool{
+		modified:                 true,
+		modified.Add(time.Hour):  true,
+		modified.Add(-time.Hour): false,
+	} {
+		response := NewResponse("content", HTTP_OK).SetLastModified(modified)
+		request := newCacheRequest("GET", map[string]string{"If-Modified-Since": since.Format("Mon, 02 Jan 2006 15:04:05 GMT")})
+
+		if response.IsNotModified(request) != notModified {
+			t.Errorf("If-Modified-Since %s: expected not modified to be %v", since, notModified)
+		}
+	}
+
+	// the ETag wins over the date when both are sent
+	response := NewResponse("content", HTTP_OK).SetLastModified(modified).SetEtag("abc")
+	if response.IsNotModified(newCacheRequest("GET", map[string]string{
+		"If-None-Match":     `"xyz"`,
+		"If-Modified-Since": modified.Format("Mon, 02 Jan 2006 15:04:05 GMT"),
+	})) {
+		t.Error("a matching date overrode a different ETag")
+	}
+
+	if NewResponse("content", HTTP_OK).SetEtag("abc").IsNotModified(newCacheRequest("POST", map[string]string{"If-None-Match": `"abc"`})) {
+		t.Error("a POST request was answered as not modified")
+	}
+}
+
+func TestLastModified(t *testing.T) {
+	modified := time.Date(2024, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	response := NewResponse("content", HTTP_OK).SetLastModified(modified)
+
+	if header := response.Headers.Get("Last-Modified"); header != "Fri, 01 Mar 2024 11:00:00 GMT" {
+		t.Errorf("unexpected Last-Modified %s", header)
+	}
+	if !response.GetLastModified().Equal(modified) {
+		t.Errorf("unexpected last modified %s", response.GetLastModified())
+	}
+	if !response.SetLastModified(time.Time{}).GetLastModified().IsZero() {
+		t.Error("expected the Last-Modified header to be removed")
+	}
+}
+
+func TestCacheControlDirectives(t *testing.T) {
+	response := NewResponse("content", HTTP_OK)
+
+	response.SetPrivate().SetMaxAge(60)
+	if header := response.Headers.Get("Cache-Control"); header != "max-age=60, private" {
+		t.Errorf("unexpected Cache-Control %s", header)
+	}
+
+	response.SetSharedMaxAge(120).SetImmutable()
+	if header := response.Headers.Get("Cache-Control"); header != "immutable, max-age=60, public, s-maxage=120" {
+		t.Errorf("unexpected Cache-Control %s", header)
+	}
+	if !response.IsImmutable() || response.GetCacheControlDirective("s-maxage") != "120" || response.HasCacheControlDirective("private") {
+		t.Error("unexpected directives")
+	}
+
+	response.SetImmutable(false).RemoveCacheControlDirective("public").RemoveCacheControlDirective("max-age").RemoveCacheControlDirective("s-maxage")
+	if header := response.Headers.Get("Cache-Control"); header != "" {
+		t.Errorf("expected the Cache-Control header to be removed, got %s", header)
+	}
+}
@@ -0,0 +1,222 @@
+package Http
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/**
+ * A server-sent event. Data that is not a string is sent as JSON.
+ */
+type ServerSentEvent struct {
+	Id    string
+	Event string
+	Data  interface{}
+	Retry time.Duration
+}
+
+/**
+ * The writer of a stream of server-sent events. It is safe to send events
+ * from several goroutines.
+ */
+type EventStream struct {
+	writer *StreamWriter
+
+	lock sync.Mutex
+}
+
+/**
+ * Create a new event stream writing to the given stream writer.
+ *
+ * @param  *StreamWriter  writer
+ * @return *EventStream
+ */
+func NewEventStream(writer *StreamWriter) (this *EventStream) {
+	this = &EventStream{}
+	this.writer = writer
+	return this
+}
+
+/**
+ * Send an event to the client.
+ *
+ * @param  ServerSentEvent  event
+ * @return error
+ */
+func (this *EventStream) Send(event ServerSentEvent) error {
+	var message strings.Builder
+
+	if event.Id != "" {
+		message.WriteString("id: " + this.singleLine(event.Id) + "\n")
+	}
+	if event.Event != "" {
+		message.WriteString("event: " + this.singleLine(event.Event) + "\n")
+	}
+	if event.Retry > 0 {
+		message.WriteString("retry: " + strconv.FormatInt(int64(event.Retry/time.Millisecond), 10) + "\n")
+	}
+	if event.Data != nil {
+		data, ok := event.Data.(string)
+		if !ok {
+			encoded, err := json.Marshal(event.Data)
+			if err != nil {
+				return err
+			}
+			data = string(encoded)
+		}
+		// Every line of the data is a field of its own, the client joins them
+		// back with new lines.
+		for _, line := range strings.Split(strings.Replace(data, "\r\n", "\n", -1), "\n") {
+			message.WriteString("data: " + line + "\n")
+		}
+	}
+
+	return this.write(message.String() + "\n")
+}
+
+/**
+ * Send an event of the given name.
+ *
+ * @param  string  name
+ * @param  interface{}  data
+ * @return error
+ */
+func (this *EventStream) Event(name string, data interface{}) error {
+	return this.Send(ServerSentEvent{Event: name, Data: data})
+}
+
+/**
+ * Send a message, an event without a name.
+ *
+ * @param  interface{}  data
+ * @return error
+ */
+func (this *EventStream) Data(data interface{}) error {
+	return this.Send(ServerSentEvent{Data: data})
+}
+
+/**
+ * Set the id the client sends back in the "Last-Event-ID" header when it
+ * reconnects.
+ *
+ * @param  string  id
+ * @return error
+ */
+func (this *EventStream) Id(id string) error {
+	return this.Send(ServerSentEvent{Id: id})
+}
+
+/**
+ * Set the time the client waits before it reconnects.
+ *
+ * @param  time.Duration  retry
+ * @return error
+ */
+func (this *EventStream) Retry(retry time.Duration) error {
+	return this.Send(ServerSentEvent{Retry: retry})
+}
+
+/**
+ * Send a comment, which the client ignores. Comments keep the connection from
+ * being closed by proxies while no event is sent.
+ *
+ * @param  string  comment
+ * @return error
+ */
+func (this *EventStream) Comment(comment string) error {
+	var message strings.Builder
+	for _, line := range strings.Split(comment, "\n") {
+		message.WriteString(": " + line + "\n")
+	}
+
+	return this.write(message.String() + "\n")
+}
+
+/**
+ * Get a channel that is closed when the client disconnects.
+ *
+ * @return <-chan struct{}
+ */
+func (this *EventStream) Done() <-chan struct{} {
+	return this.writer.Done()
+}
+
+/**
+ * Write a message to the stream.
+ *
+ * @param  string  message
+ * @return error
+ */
+func (this *EventStream) write(message string) error {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	_, err := this.writer.WriteString(message)
+
+	return err
+}
+
+/**
+ * Remove the line breaks of a single line field, which would end the field.
+ *
+ * @param  string  value
+ * @return string
+ */
+func (this *EventStream) singleLine(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
+
+/**
+ * Creates a response streaming server-sent events. When a heartbeat interval
+ * is given, a comment is sent at that interval until the callback returns.
+ *
+ * @param  func(*EventStream)  callback
+ * @param  time.Duration  heartbeat
+ * @param  map[string][]string  headers
+ * @return *Response
+ */
+func NewEventStreamResponse(callback func(*EventStream), heartbeat time.Duration, headers ...map[string][]string) (this *Response) {
+	this = NewStreamedResponse(func(writer *StreamWriter) {
+		stream := NewEventStream(writer)
+
+		if heartbeat > 0 {
+			// The heartbeat has to be stopped before the response writer is given
+			// back, so the callback waits for it rather than only signalling it.
+			var heartbeats sync.WaitGroup
+			done := make(chan struct{})
+			defer heartbeats.Wait()
+			defer close(done)
+
+			heartbeats.Add(1)
+			go func() {
+				defer heartbeats.Done()
+
+				ticker := time.NewTicker(heartbeat)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ticker.C:
+						stream.Comment("heartbeat")
+					case <-done:
+						return
+					case <-writer.Done():
+						return
+					}
+				}
+			}()
+		}
+
+		callback(stream)
+	}, HTTP_OK, headers...)
+
+	this.Header("Content-Type", "text/event-stream")
+	this.Header("Cache-Control", "no-cache")
+	// Proxies such as nginx would otherwise buffer the events.
+	this.Header("X-Accel-Buffering", "no")
+
+	return this
+}
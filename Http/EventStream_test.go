@@ -0,0 +1,65 @@
+package Http
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+/**
+ * A response writer failing the test when it is written to after the
+ * response was sent.
+ */
+type sentResponseWriter struct {
+	*httptest.ResponseRecorder
+
+	lock sync.Mutex
+	sent bool
+	late int
+}
+
+func (this *sentResponseWriter) Write(p []byte) (int, error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if this.sent {
+		this.late++
+	}
+
+	return this.ResponseRecorder.Write(p)
+}
+
+func TestEventStreamHeartbeatStopsBeforeTheCallbackReturns(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		writer := &sentResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+		response := NewEventStreamResponse(func(stream *EventStream) {
+			time.Sleep(5 * time.Millisecond)
+			stream.Event("update", map[string]int{"count": 1})
+		}, time.Millisecond)
+
+		response.callback(NewStreamWriter(writer, context.Background()))
+
+		writer.lock.Lock()
+		writer.sent = true
+		writer.lock.Unlock()
+		time.Sleep(3 * time.Millisecond)
+
+		writer.lock.Lock()
+		late := writer.late
+		writer.lock.Unlock()
+		if late > 0 {
+			t.Fatalf("expected no write after the callback returned, got %d", late)
+		}
+
+		body := writer.Body.String()
+		if !strings.Contains(body, ": heartbeat\n\n") {
+			t.Fatalf("expected heartbeats in %q", body)
+		}
+		if !strings.Contains(body, "event: update\ndata: {\"count\":1}\n\n") {
+			t.Fatalf("expected the update event in %q", body)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package HttpFoundation
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/**
+ * An item of an Accept-* header, e.g. "text/html;level=1;q=0.8".
+ */
+type AcceptHeaderItem struct {
+	/**
+	 * The value of the item, without its attributes.
+	 *
+	 * @var string
+	 */
+	Value string
+
+	/**
+	 * The quality of the item, from 0 to 1.
+	 *
+	 * @var float64
+	 */
+	Quality float64
+
+	/**
+	 * The position of the item in the header.
+	 *
+	 * @var int
+	 */
+	Index int
+
+	/**
+	 * The attributes of the item, other than its quality.
+	 *
+	 * @var map[string]string
+	 */
+	Attributes map[string]string
+}
+
+type AcceptHeader struct {
+	/**
+	 * The items of the header, sorted by quality.
+	 *
+	 * @var []*AcceptHeaderItem
+	 */
+	items []*AcceptHeaderItem
+}
+
+/**
+ * Builds an AcceptHeader instance from a string.
+ *
+ * @param  string  header
+ * @return *AcceptHeader
+ */
+func NewAcceptHeaderFromString(header string) (this *AcceptHeader) {
+	this = &AcceptHeader{}
+	this.items = []*AcceptHeaderItem{}
+
+	for _, part := range splitQuoted(header, ',') {
+		// An item made only of parameters, such as ";q=1", has no value to accept
+		// and is skipped rather than taking its first parameter as the value.
+		params := splitQuoted(part, ';')
+		if len(params) == 0 || strings.HasPrefix(part, ";") {
+			continue
+		}
+
+		item := &AcceptHeaderItem{Value: params[0], Quality: 1, Index: len(this.items), Attributes: map[string]string{}}
+		for _, param := range params[1:] {
+			pair := strings.SplitN(param, "=", 2)
+			name, value := strings.ToLower(strings.TrimSpace(pair[0])), ""
+			if len(pair) == 2 {
+				value = Unquote(strings.TrimSpace(pair[1]))
+			}
+			if name == "q" {
+				if quality, err := strconv.ParseFloat(value, 64); err == nil {
+					item.Quality = quality
+				}
+				continue
+			}
+			item.Attributes[name] = value
+		}
+		this.items = append(this.items, item)
+	}
+
+	// The items are sorted by descending quality, the items of the same quality
+	// keep the order in which they were sent.
+	sort.SliceStable(this.items, func(i, j int) bool {
+		return this.items[i].Quality > this.items[j].Quality
+	})
+
+	return this
+}
+
+/**
+ * Returns all items, sorted by descending quality.
+ *
+ * @return []*AcceptHeaderItem
+ */
+func (this *AcceptHeader) All() []*AcceptHeaderItem {
+	return this.items
+}
+
+/**
+ * Returns the values of the acceptable items, sorted by descending quality.
+ * Items with a quality of 0 are not acceptable and are left out.
+ *
+ * @return []string
+ */
+func (this *AcceptHeader) Values() []string {
+	values := []string{}
+	for _, item := range this.items {
+		if item.Quality > 0 {
+			values = append(values, item.Value)
+		}
+	}
+
+	return values
+}
+
+/**
+ * Tests if header has given value.
+ *
+ * @param  string  value
+ * @return bool
+ */
+func (this *AcceptHeader) Has(value string) bool {
+	return this.Get(value) != nil
+}
+
+/**
+ * Returns given value's item, if exists.
+ *
+ * @param  string  value
+ * @return *AcceptHeaderItem
+ */
+func (this *AcceptHeader) Get(value string) *AcceptHeaderItem {
+	for _, item := range this.items {
+		if strings.EqualFold(item.Value, value) {
+			return item
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Returns first item, the one of the highest quality.
+ *
+ * @return *AcceptHeaderItem
+ */
+func (this *AcceptHeader) First() *AcceptHeaderItem {
+	if len(this.items) == 0 {
+		return nil
+	}
+
+	return this.items[0]
+}
@@ -0,0 +1,73 @@
+package HttpFoundation
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAcceptHeaderSkipsItemsWithoutValue(t *testing.T) {
+	for header, expected := range map[string][]string{
+		"text/html, ;":            {"text/html"},
+		";q=1, application/json":  {"application/json"},
+		",,text/plain,,":          {"text/plain"},
+		";":                       {},
+		"":                        {},
+		" ; level=1 , text/css ;": {"text/css"},
+	} {
+		if values := NewAcceptHeaderFromString(header).Values(); !reflect.DeepEqual(values, expected) {
+			t.Errorf("%q: expected %v, got %v", header, expected, values)
+		}
+	}
+}
+
+func TestAcceptHeaderSortsItemsByQuality(t *testing.T) {
+	header := NewAcceptHeaderFromString("text/plain;q=0.5, text/html, application/json;q=0.8, text/css;q=0.5")
+
+	expected := []string{"text/html", "application/json", "text/plain", "text/css"}
+	if values := header.Values(); !reflect.DeepEqual(values, expected) {
+		t.Errorf("expected %v, got %v", expected, values)
+	}
+	if first := header.First(); first == nil || first.Value != "text/html" {
+		t.Errorf("expected text/html first, got %v", first)
+	}
+}
+
+func TestAcceptHeaderLeavesOutItemsOfZeroQuality(t *testing.T) {
+	header := NewAcceptHeaderFromString("gzip;q=0, br, identity;q=0.0")
+
+	if values := header.Values(); !reflect.DeepEqual(values, []string{"br"}) {
+		t.Errorf("expected only br, got %v", values)
+	}
+	if item := header.Get("GZIP"); item == nil || item.Quality != 0 {
+		t.Errorf("expected gzip to be listed with a quality of 0, got %v", item)
+	}
+	if len(header.All()) != 3 {
+		t.Errorf("expected all 3 items, got %d", len(header.All()))
+	}
+}
+
+func TestAcceptHeaderIgnoresInvalidQuality(t *testing.T) {
+	item := NewAcceptHeaderFromString("text/html;q=high").First()
+
+	if item == nil || item.Quality != 1 {
+		t.Errorf("expected the default quality of 1, got %v", item)
+	}
+}
+
+func TestAcceptHeaderReadsQuotedAttributes(t *testing.T) {
+	header := NewAcceptHeaderFromString(`text/html;title="a, b; c";charset=utf-8;q=0.9, text/plain;note="say \"hi\""`)
+
+	html := header.Get("text/html")
+	if html == nil {
+		t.Fatalf("expected text/html in %v", header.Values())
+	}
+	if html.Attributes["title"] != "a, b; c" || html.Attributes["charset"] != "utf-8" || html.Quality != 0.9 {
+		t.Errorf("unexpected text/html item %+v", html)
+	}
+	if plain := header.Get("text/plain"); plain == nil || plain.Attributes["note"] != `say "hi"` {
+		t.Errorf("unexpected text/plain item %+v", plain)
+	}
+	if len(header.All()) != 2 {
+		t.Errorf("expected 2 items, got %d", len(header.All()))
+	}
+}
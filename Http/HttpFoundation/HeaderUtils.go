@@ -0,0 +1,163 @@
+package HttpFoundation
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const (
+	DISPOSITION_ATTACHMENT = "attachment"
+	DISPOSITION_INLINE     = "inline"
+)
+
+var tokenPattern = regexp.MustCompile("^(?i)[a-z0-9!#$%&'*.^_`|~-]+$")
+
+/**
+ * Parse an RFC 7239 "Forwarded" header into the parameters of each of its
+ * elements, e.g. `for=192.0.2.60;proto=http, for="[2001:db8::1]:4711"`.
+ * Parameter names are lowercased and quoted values are unquoted.
+ *
+ * @param  string  header
+ * @return []map[string]string
+ */
+func ParseForwarded(header string) []map[string]string {
+	elements := []map[string]string{}
+
+	for _, element := range splitQuoted(header, ',') {
+		params := map[string]string{}
+		for _, pair := range splitQuoted(element, ';') {
+			parts := strings.SplitN(pair, "=", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			params[strings.ToLower(strings.TrimSpace(parts[0]))] = Unquote(strings.TrimSpace(parts[1]))
+		}
+		elements = append(elements, params)
+	}
+
+	return elements
+}
+
+/**
+ * Decodes a quoted string. Values that are not quoted are returned as is.
+ *
+ * @param  string  s
+ * @return string
+ */
+func Unquote(s string) string {
+	if len(s) < 2 || s[0] != '"' || s[len(s)-1] != '"' {
+		return s
+	}
+
+	var unquoted strings.Builder
+	for i := 1; i < len(s)-1; i++ {
+		if s[i] == '\\' && i+1 < len(s)-1 {
+			i++
+		}
+		unquoted.WriteByte(s[i])
+	}
+
+	return unquoted.String()
+}
+
+/**
+ * Quote a header parameter value, unless it is a valid token already.
+ *
+ * @param  string  s
+ * @return string
+ */
+func Quote(s string) string {
+	if tokenPattern.MatchString(s) {
+		return s
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+/**
+ * Generate an HTTP Content-Disposition field-value.
+ *
+ * @param  string  disposition  One of "inline" or "attachment"
+ * @param  string  filename     A unicode string
+ * @param  string  filenameFallback  A string containing only ASCII characters that
+ *                                   is semantically equivalent to filename. If the
+ *                                   filename is already ASCII, it can be omitted
+ * @return string
+ *
+ * @throws Errors.InvalidArgumentException
+ *
+ * @see RFC 6266
+ */
+func MakeDisposition(disposition string, filename string, filenameFallback ...string) string {
+	if disposition != DISPOSITION_ATTACHMENT && disposition != DISPOSITION_INLINE {
+		panic(Errors.NewInvalidArgumentException(fmt.Sprintf(`The disposition must be either "%s" or "%s".`, DISPOSITION_ATTACHMENT, DISPOSITION_INLINE)))
+	}
+
+	filenameFallback = append(filenameFallback, "")
+	fallback := filenameFallback[0]
+	if fallback == "" {
+		fallback = filename
+	}
+
+	// filenameFallback is not ASCII.
+	for i := 0; i < len(fallback); i++ {
+		if fallback[i] < 0x20 || fallback[i] > 0x7e {
+			panic(Errors.NewInvalidArgumentException("The filename fallback must only contain ASCII characters."))
+		}
+	}
+
+	// percent characters aren't safe in fallback.
+	if strings.Contains(fallback, "%") {
+		panic(Errors.NewInvalidArgumentException(`The filename fallback cannot contain the "%" character.`))
+	}
+
+	// path separators aren't allowed in either.
+	if strings.ContainsAny(filename, `/\`) || strings.ContainsAny(fallback, `/\`) {
+		panic(Errors.NewInvalidArgumentException(`The filename and the fallback cannot contain the "/" and "\" characters.`))
+	}
+
+	value := disposition + "; filename=" + Quote(fallback)
+	if filename != fallback {
+		value += "; filename*=utf-8''" + strings.Replace(url.QueryEscape(filename), "+", "%20", -1)
+	}
+
+	return value
+}
+
+/**
+ * Split a header value on the given separator, ignoring the separators
+ * within quoted strings.
+ *
+ * @param  string  header
+ * @param  byte  separator
+ * @return []string
+ */
+func splitQuoted(header string, separator byte) []string {
+	parts := []string{}
+	quoted, start := false, 0
+
+	for i := 0; i < len(header); i++ {
+		switch {
+		case header[i] == '\\' && quoted:
+			i++
+		case header[i] == '"':
+			quoted = !quoted
+		case header[i] == separator && !quoted:
+			parts = append(parts, header[start:i])
+			start = i + 1
+		}
+	}
+	parts = append(parts, header[start:])
+
+	trimmed := []string{}
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			trimmed = append(trimmed, part)
+		}
+	}
+
+	return trimmed
+}
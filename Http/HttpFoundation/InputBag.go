@@ -0,0 +1,168 @@
+package HttpFoundation
+
+import (
+	"strconv"
+	"strings"
+)
+
+/**
+ * A bag of decoded input, where values may be nested maps and lists that
+ * are reached with "dot" notation, e.g. "user.addresses.0.city".
+ */
+type InputBag struct {
+	parameters map[string]interface{}
+}
+
+func NewInputBag(parameters map[string]interface{}) *InputBag {
+	if parameters == nil {
+		parameters = map[string]interface{}{}
+	}
+	return &InputBag{parameters: parameters}
+}
+
+/**
+ * Returns the parameters.
+ *
+ * @return map[string]interface{}
+ */
+func (this *InputBag) All() map[string]interface{} {
+	return this.parameters
+}
+
+/**
+ * Returns the parameter keys.
+ *
+ * @return []string
+ */
+func (this *InputBag) Keys() []string {
+	keys := []string{}
+	for k := range this.parameters {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+/**
+ * Replaces the current parameters by a new set.
+ *
+ * @param  map[string]interface{}  parameters
+ * @return void
+ */
+func (this *InputBag) Replace(parameters map[string]interface{}) {
+	this.parameters = parameters
+}
+
+/**
+ * Returns a parameter by name using "dot" notation.
+ *
+ * @param  string  key
+ * @param  interface{}  default
+ * @return interface{}
+ */
+func (this *InputBag) Get(key string, _default ...interface{}) interface{} {
+	_default = append(_default, nil)
+
+	if value, ok := this.lookup(key); ok {
+		return value
+	}
+	return _default[0]
+}
+
+/**
+ * Returns true if the parameter is defined, using "dot" notation.
+ *
+ * @param  string  key
+ * @return bool
+ */
+func (this *InputBag) Has(key string) bool {
+	_, ok := this.lookup(key)
+	return ok
+}
+
+/**
+ * Sets a parameter by name.
+ *
+ * @param  string  key
+ * @param  interface{}  value
+ * @return void
+ */
+func (this *InputBag) Set(key string, value interface{}) {
+	this.parameters[key] = value
+}
+
+/**
+ * Removes a parameter by name using "dot" notation.
+ *
+ * @param  string  key
+ * @return void
+ */
+func (this *InputBag) Remove(key string) {
+	Forget(this.parameters, key)
+}
+
+/**
+ * Returns the number of parameters.
+ *
+ * @return int
+ */
+func (this *InputBag) Count() int {
+	return len(this.parameters)
+}
+
+/**
+ * Find a parameter using "dot" notation.
+ *
+ * @param  string  key
+ * @return interface{}, bool
+ */
+func (this *InputBag) lookup(key string) (interface{}, bool) {
+	if value, ok := this.parameters[key]; ok {
+		return value, true
+	}
+
+	var value interface{} = this.parameters
+	for _, segment := range strings.Split(key, ".") {
+		switch _value := value.(type) {
+		case map[string]interface{}:
+			next, ok := _value[segment]
+			if !ok {
+				return nil, false
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(_value) {
+				return nil, false
+			}
+			value = _value[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+/**
+ * Remove an item from nested maps using "dot" notation.
+ *
+ * @param  map[string]interface{}  parameters
+ * @param  string  key
+ * @return void
+ */
+func Forget(parameters map[string]interface{}, key string) {
+	if _, ok := parameters[key]; ok {
+		delete(parameters, key)
+		return
+	}
+
+	segments := strings.Split(key, ".")
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := parameters[segment].(map[string]interface{})
+		if !ok {
+			return
+		}
+		parameters = next
+	}
+	delete(parameters, segments[len(segments)-1])
+}
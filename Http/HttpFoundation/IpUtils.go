@@ -0,0 +1,33 @@
+package HttpFoundation
+
+import (
+	"net"
+	"strings"
+)
+
+/**
+ * Checks if an IPv4 or IPv6 address is contained in the list of given IPs or
+ * subnets, e.g. "192.168.1.1", "10.0.0.0/8" or "2001:db8::/32".
+ *
+ * @param  string  requestIp
+ * @param  []string  ips
+ * @return bool
+ */
+func CheckIp(requestIp string, ips []string) bool {
+	ip := net.ParseIP(requestIp)
+	if ip == nil {
+		return false
+	}
+
+	for _, item := range ips {
+		if strings.Contains(item, "/") {
+			if _, subnet, err := net.ParseCIDR(item); err == nil && subnet.Contains(ip) {
+				return true
+			}
+		} else if other := net.ParseIP(item); other != nil && other.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
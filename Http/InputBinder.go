@@ -0,0 +1,284 @@
+package Http
+
+import (
+	"encoding"
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+/**
+ * Decode the input of the request into the value the given pointer points
+ * to. Struct fields take their input key from the "form" tag, then from the
+ * "json" tag, then from their name; "-" skips a field. Text input is
+ * converted to numbers, booleans and types implementing TextUnmarshaler.
+ *
+ * @param  interface{}  v
+ * @return error
+ */
+func (this *Request) Bind(v interface{}) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Ptr || target.IsNil() {
+		return Errors.NewInvalidArgumentException(fmt.Sprintf("Bind expects a non-nil pointer, [%T] given.", v))
+	}
+
+	return bindInput(target.Elem(), this.All(), "")
+}
+
+/**
+ * Decode an input value into the given value.
+ *
+ * @param  reflect.Value  target
+ * @param  interface{}  value
+ * @param  string  path
+ * @return error
+ */
+func bindInput(target reflect.Value, value interface{}, path string) error {
+	if value == nil {
+		return nil
+	}
+
+	// files and other values already of the right type are bound as they are
+	if reflect.TypeOf(value).AssignableTo(target.Type()) {
+		target.Set(reflect.ValueOf(value))
+		return nil
+	}
+
+	if target.Kind() == reflect.Ptr {
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		return bindInput(target.Elem(), value, path)
+	}
+
+	if text, ok := value.(string); ok && target.CanAddr() {
+		if unmarshaler, ok := target.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := unmarshaler.UnmarshalText([]byte(text)); err != nil {
+				return bindError(path, target, err)
+			}
+			return nil
+		}
+	}
+
+	switch target.Kind() {
+	case reflect.Interface:
+		if target.NumMethod() == 0 {
+			target.Set(reflect.ValueOf(value))
+			return nil
+		}
+	case reflect.String:
+		if isScalarInput(value) {
+			target.SetString(inputString(value))
+			return nil
+		}
+	case reflect.Bool:
+		switch _value := value.(type) {
+		case bool:
+			target.SetBool(_value)
+			return nil
+		case float64:
+			target.SetBool(_value != 0)
+			return nil
+		case string:
+			switch strings.ToLower(strings.TrimSpace(_value)) {
+			case "1", "true", "on", "yes":
+				target.SetBool(true)
+				return nil
+			case "", "0", "false", "off", "no":
+				target.SetBool(false)
+				return nil
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if isScalarInput(value) {
+			integer, err := strconv.ParseInt(strings.TrimSpace(inputString(value)), 10, target.Type().Bits())
+			if err != nil {
+				return bindError(path, target, err)
+			}
+			target.SetInt(integer)
+			return nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if isScalarInput(value) {
+			integer, err := strconv.ParseUint(strings.TrimSpace(inputString(value)), 10, target.Type().Bits())
+			if err != nil {
+				return bindError(path, target, err)
+			}
+			target.SetUint(integer)
+			return nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if isScalarInput(value) {
+			float, err := strconv.ParseFloat(strings.TrimSpace(inputString(value)), target.Type().Bits())
+			if err != nil {
+				return bindError(path, target, err)
+			}
+			target.SetFloat(float)
+			return nil
+		}
+	case reflect.Slice:
+		items, ok := value.([]interface{})
+		if !ok {
+			// a single value binds as a list of one
+			items = []interface{}{value}
+		}
+		slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := bindInput(slice.Index(i), item, joinInputPath(path, strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+		return nil
+	case reflect.Map:
+		items, ok := value.(map[string]interface{})
+		if !ok || target.Type().Key().Kind() != reflect.String {
+			break
+		}
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(target.Type()))
+		}
+		for key, item := range items {
+			element := reflect.New(target.Type().Elem()).Elem()
+			if err := bindInput(element, item, joinInputPath(path, key)); err != nil {
+				return err
+			}
+			target.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), element)
+		}
+		return nil
+	case reflect.Struct:
+		items, ok := value.(map[string]interface{})
+		if !ok {
+			break
+		}
+		return bindStruct(target, items, path)
+	}
+
+	return bindError(path, target, nil)
+}
+
+/**
+ * Decode input into the fields of a struct.
+ *
+ * @param  reflect.Value  target
+ * @param  map[string]interface{}  items
+ * @param  string  path
+ * @return error
+ */
+func bindStruct(target reflect.Value, items map[string]interface{}, path string) error {
+	Type := target.Type()
+
+	for i := 0; i < Type.NumField(); i++ {
+		field := Type.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := inputFieldName(field)
+		if name == "-" {
+			continue
+		}
+
+		// embedded structs without a name of their own share the input of the
+		// struct embedding them
+		if field.Anonymous && name == "" {
+			fieldValue := target.Field(i)
+			if fieldValue.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct {
+				if fieldValue.IsNil() {
+					fieldValue.Set(reflect.New(field.Type.Elem()))
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct {
+				if err := bindStruct(fieldValue, items, path); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		value, ok := items[name]
+		if !ok {
+			// the name of the field matches its key regardless of case
+			for key, item := range items {
+				if strings.EqualFold(key, name) {
+					value, ok = item, true
+					break
+				}
+			}
+		}
+		if !ok {
+			continue
+		}
+
+		if err := bindInput(target.Field(i), value, joinInputPath(path, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Get the input key of a struct field from its tags.
+ *
+ * @param  reflect.StructField  field
+ * @return string
+ */
+func inputFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"form", "json"} {
+		if name := strings.Split(field.Tag.Get(tag), ",")[0]; name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+/**
+ * Determine if an input value is a scalar.
+ *
+ * @param  interface{}  value
+ * @return bool
+ */
+func isScalarInput(value interface{}) bool {
+	switch value.(type) {
+	case string, float64, bool:
+		return true
+	}
+	return false
+}
+
+/**
+ * Join the segments of an input path with dots.
+ *
+ * @param  string  path
+ * @param  string  segment
+ * @return string
+ */
+func joinInputPath(path string, segment string) string {
+	if path == "" {
+		return segment
+	}
+	return path + "." + segment
+}
+
+/**
+ * Create the error of input that cannot be bound to the given value.
+ *
+ * @param  string  path
+ * @param  reflect.Value  target
+ * @param  error  err
+ * @return error
+ */
+func bindError(path string, target reflect.Value, err error) error {
+	message := fmt.Sprintf(`The "%s" input cannot be bound to %s.`, path, target.Type().String())
+	if err != nil {
+		message = fmt.Sprintf(`The "%s" input cannot be bound to %s: %s`, path, target.Type().String(), err.Error())
+	}
+	return Errors.NewUnexpectedValueException(message)
+}
@@ -0,0 +1,87 @@
+package Http
+
+import (
+	"testing"
+)
+
+type bindAddress struct {
+	City string `form:"city"`
+}
+
+type BindTimestamps struct {
+	Version int
+}
+
+type bindUser struct {
+	BindTimestamps
+
+	Name    string            `json:"name"`
+	Age     int               `form:"age"`
+	Admin   bool              `form:"admin"`
+	Ratio   float64           `form:"ratio"`
+	Tags    []string          `form:"tags"`
+	Address *bindAddress      `form:"address"`
+	Meta    map[string]string `form:"meta"`
+	Secret  string            `form:"-"`
+}
+
+func TestBindFormInputToStruct(t *testing.T) {
+	request := newInputRequest("POST", "/?version=3", "application/x-www-form-urlencoded",
+		"name=taylor&age=31&admin=on&ratio=0.5&tags[]=a&tags[]=b&address[city]=Paris&meta[role]=owner&Secret=x")
+
+	user := bindUser{}
+	if err := request.Bind(&user); err != nil {
+		t.Fatal(err)
+	}
+
+	if user.Name != "taylor" || user.Age != 31 || !user.Admin || user.Ratio != 0.5 {
+		t.Errorf("unexpected scalars %+v", user)
+	}
+	if len(user.Tags) != 2 || user.Tags[0] != "a" || user.Tags[1] != "b" {
+		t.Errorf("unexpected tags %v", user.Tags)
+	}
+	if user.Address == nil || user.Address.City != "Paris" {
+		t.Errorf("unexpected address %+v", user.Address)
+	}
+	if user.Meta["role"] != "owner" {
+		t.Errorf("unexpected meta %v", user.Meta)
+	}
+	if user.Version != 3 {
+		t.Errorf("expected the embedded struct to be bound, got %d", user.Version)
+	}
+	if user.Secret != "" {
+		t.Error("a skipped field was bound")
+	}
+}
+
+func TestBindJsonInputToStruct(t *testing.T) {
+	request := newInputRequest("POST", "/", "application/json", `{"name":"taylor","age":31,"tags":"solo","address":{"city":"Paris"}}`)
+
+	user := bindUser{}
+	if err := request.Bind(&user); err != nil {
+		t.Fatal(err)
+	}
+	if user.Name != "taylor" || user.Age != 31 || user.Address.City != "Paris" {
+		t.Errorf("unexpected user %+v", user)
+	}
+	if len(user.Tags) != 1 || user.Tags[0] != "solo" {
+		t.Errorf("expected a single value to bind as a list, got %v", user.Tags)
+	}
+}
+
+func TestBindRejectsInvalidInput(t *testing.T) {
+	request := newInputRequest("POST", "/", "application/x-www-form-urlencoded", "age=old")
+
+	user := bindUser{}
+	err := request.Bind(&user)
+	if err == nil {
+		t.Fatal("expected the age to fail binding")
+	}
+	if message := err.Error(); message == "" {
+		t.Error("expected an error message")
+	}
+
+	if err := request.Bind(user); err == nil {
+		t.Error("expected a non-pointer to be rejected")
+	}
+}
@@ -0,0 +1,320 @@
+package Http
+
+import (
+	"github.com/larisgo/framework/Http/HttpFoundation"
+	"github.com/larisgo/framework/Support"
+	"strings"
+)
+
+/**
+ * Gets a list of content types acceptable by the client browser, most
+ * preferred first.
+ *
+ * @return []string
+ */
+func (this *Request) GetAcceptableContentTypes() []string {
+	if this.acceptableContentTypes == nil {
+		this.acceptableContentTypes = HttpFoundation.NewAcceptHeaderFromString(this.Headers.Get("Accept")).Values()
+	}
+
+	return this.acceptableContentTypes
+}
+
+/**
+ * Gets a list of languages acceptable by the client browser, most preferred
+ * first. Language tags are normalized, "en-us" becomes "en_US".
+ *
+ * @return []string
+ */
+func (this *Request) GetLanguages() []string {
+	if this.languages == nil {
+		this.languages = []string{}
+		for _, language := range HttpFoundation.NewAcceptHeaderFromString(this.Headers.Get("Accept-Language")).Values() {
+			if codes := strings.Split(language, "-"); len(codes) > 1 {
+				if codes[0] == "i" {
+					// Language not listed in ISO 639 that are not variants
+					// of any listed language, which can be registered with the
+					// i-prefix, such as i-cherokee
+					language = codes[1]
+				} else {
+					language = strings.ToLower(codes[0])
+					for _, code := range codes[1:] {
+						language += "_" + strings.ToUpper(code)
+					}
+				}
+			}
+			this.languages = append(this.languages, language)
+		}
+	}
+
+	return this.languages
+}
+
+/**
+ * Gets a list of charsets acceptable by the client browser, most preferred
+ * first.
+ *
+ * @return []string
+ */
+func (this *Request) GetCharsets() []string {
+	if this.charsets == nil {
+		this.charsets = HttpFoundation.NewAcceptHeaderFromString(this.Headers.Get("Accept-Charset")).Values()
+	}
+
+	return this.charsets
+}
+
+/**
+ * Gets a list of encodings acceptable by the client browser, most preferred
+ * first.
+ *
+ * @return []string
+ */
+func (this *Request) GetEncodings() []string {
+	if this.encodings == nil {
+		this.encodings = HttpFoundation.NewAcceptHeaderFromString(this.Headers.Get("Accept-Encoding")).Values()
+	}
+
+	return this.encodings
+}
+
+/**
+ * Returns the preferred language.
+ *
+ * @param  string  locales  An array of ordered available locales
+ * @return string
+ */
+func (this *Request) PreferredLanguage(locales ...string) string {
+	preferredLanguages := this.GetLanguages()
+
+	if len(locales) == 0 {
+		if len(preferredLanguages) > 0 {
+			return preferredLanguages[0]
+		}
+		return ""
+	}
+
+	preferred := map[string]bool{}
+	for _, language := range preferredLanguages {
+		preferred[language] = true
+	}
+
+	// A regional language, "en_US", also accepts its super language, "en",
+	// when the client does not ask for the super language itself.
+	extendedPreferredLanguages := []string{}
+	for _, language := range preferredLanguages {
+		extendedPreferredLanguages = append(extendedPreferredLanguages, language)
+		if position := strings.Index(language, "_"); position > -1 && !preferred[language[:position]] {
+			extendedPreferredLanguages = append(extendedPreferredLanguages, language[:position])
+		}
+	}
+
+	for _, language := range extendedPreferredLanguages {
+		for _, locale := range locales {
+			if language == locale {
+				return locale
+			}
+		}
+	}
+
+	return locales[0]
+}
+
+/**
+ * Determine if the current request probably expects a JSON response.
+ *
+ * @return bool
+ */
+func (this *Request) ExpectsJson() bool {
+	return (this.Ajax() && !this.Pjax() && this.AcceptsAnyContentType()) || this.WantsJson()
+}
+
+/**
+ * Determine if the current request is asking for JSON.
+ *
+ * @return bool
+ */
+func (this *Request) WantsJson() bool {
+	acceptable := this.GetAcceptableContentTypes()
+
+	return len(acceptable) > 0 && Support.Str().Contains(acceptable[0], []string{"/json", "+json"})
+}
+
+/**
+ * Determine if the current request prefers JSON over HTML.
+ *
+ * @return bool
+ */
+func (this *Request) PrefersJson() bool {
+	return this.Prefers("text/html", "application/json") == "application/json"
+}
+
+/**
+ * Determines whether the current requests accepts a given content type.
+ *
+ * @param  string  contentTypes
+ * @return bool
+ */
+func (this *Request) Accepts(contentTypes ...string) bool {
+	accepts := this.GetAcceptableContentTypes()
+
+	if len(accepts) == 0 {
+		return true
+	}
+
+	for _, accept := range accepts {
+		if accept == "*/*" || accept == "*" {
+			return true
+		}
+
+		for _, _type := range contentTypes {
+			if this.matchesType(accept, _type) || accept == strings.SplitN(_type, "/", 2)[0]+"/*" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+/**
+ * Return the most suitable content type from the given array based on
+ * content negotiation, or "" when none of them is accepted.
+ *
+ * @param  string  contentTypes
+ * @return string
+ */
+func (this *Request) Prefers(contentTypes ...string) string {
+	if len(contentTypes) == 0 {
+		return ""
+	}
+
+	accepts := this.GetAcceptableContentTypes()
+
+	for _, accept := range accepts {
+		if accept == "*/*" || accept == "*" {
+			return contentTypes[0]
+		}
+
+		accept = strings.ToLower(accept)
+		for _, contentType := range contentTypes {
+			_type := strings.ToLower(this.mimeTypeOf(contentType))
+
+			if this.matchesType(_type, accept) || accept == strings.SplitN(_type, "/", 2)[0]+"/*" {
+				return contentType
+			}
+		}
+	}
+
+	// A client without an Accept header accepts anything.
+	if len(accepts) == 0 {
+		return contentTypes[0]
+	}
+
+	return ""
+}
+
+/**
+ * Determine if the current request accepts any content type.
+ *
+ * @return bool
+ */
+func (this *Request) AcceptsAnyContentType() bool {
+	acceptable := this.GetAcceptableContentTypes()
+
+	return len(acceptable) == 0 || acceptable[0] == "*/*" || acceptable[0] == "*"
+}
+
+/**
+ * Determines whether a request accepts JSON.
+ *
+ * @return bool
+ */
+func (this *Request) AcceptsJson() bool {
+	return this.Accepts("application/json")
+}
+
+/**
+ * Determines whether a request accepts HTML.
+ *
+ * @return bool
+ */
+func (this *Request) AcceptsHtml() bool {
+	return this.Accepts("text/html")
+}
+
+/**
+ * Get the data format expected in the response, the format of the most
+ * preferred acceptable content type.
+ *
+ * @param  string  default
+ * @return string
+ */
+func (this *Request) Format(_default ...string) string {
+	_default = append(_default, "html")
+
+	for _, contentType := range this.GetAcceptableContentTypes() {
+		if format := GetFormat(contentType); format != "" {
+			return format
+		}
+	}
+
+	return _default[0]
+}
+
+/**
+ * Determine if the request is the result of an AJAX call.
+ *
+ * @return bool
+ */
+func (this *Request) Ajax() bool {
+	return this.Headers.Get("X-Requested-With") == "XMLHttpRequest"
+}
+
+/**
+ * Determine if the request is the result of a PJAX call.
+ *
+ * @return bool
+ */
+func (this *Request) Pjax() bool {
+	return this.Headers.Get("X-PJAX") == "true"
+}
+
+/**
+ * Determine if the given content types match.
+ *
+ * @param  string  actual
+ * @param  string  _type
+ * @return bool
+ */
+func (this *Request) matchesType(actual string, _type string) bool {
+	if actual == _type {
+		return true
+	}
+
+	split := strings.SplitN(actual, "/", 2)
+	if len(split) != 2 {
+		return false
+	}
+
+	// A structured syntax suffix matches its base type, so "application/json"
+	// matches "application/vnd.api+json".
+	return strings.HasPrefix(_type, split[0]+"/") && strings.HasSuffix(_type, "+"+split[1])
+}
+
+/**
+ * Get the mime type of a content type given as a mime type or as a format,
+ * e.g. "json".
+ *
+ * @param  string  contentType
+ * @return string
+ */
+func (this *Request) mimeTypeOf(contentType string) string {
+	if strings.Contains(contentType, "/") {
+		return contentType
+	}
+	if mimeType := GetMimeType(contentType); mimeType != "" {
+		return mimeType
+	}
+	return contentType
+}
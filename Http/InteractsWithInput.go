@@ -0,0 +1,498 @@
+package Http
+
+import (
+	"github.com/larisgo/framework/Http/HttpFoundation"
+	"github.com/larisgo/framework/Support"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * The date formats tried when no format is given to Date.
+ *
+ * @var []string
+ */
+var inputDateFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+/**
+ * Get all of the input of the request, the query merged with the body. Form
+ * keys in bracket notation, "user[address][city]", become nested maps and
+ * values sent several times become lists.
+ *
+ * @return map[string]interface{}
+ */
+func (this *Request) All() map[string]interface{} {
+	input := nestValues(this.Query.All())
+
+	var source map[string]interface{}
+	if this.IsJson() {
+		source = copyInput(this.Json().All()).(map[string]interface{})
+	} else {
+		source = nestValues(this.Post.All())
+	}
+
+	for key, value := range source {
+		input[key] = value
+	}
+
+	for key, value := range this.nestedFiles() {
+		input[key] = value
+	}
+
+	return input
+}
+
+/**
+ * Retrieve an input item from the request using "dot" notation.
+ *
+ * @param  string  key
+ * @param  interface{}  default
+ * @return interface{}
+ */
+func (this *Request) Input(key string, _default ...interface{}) interface{} {
+	_default = append(_default, nil)
+
+	if key == "" {
+		return this.All()
+	}
+
+	return HttpFoundation.NewInputBag(this.All()).Get(key, _default[0])
+}
+
+/**
+ * Get a subset containing the provided keys with values from the input data.
+ *
+ * @param  string  keys
+ * @return map[string]interface{}
+ */
+func (this *Request) Only(keys ...string) map[string]interface{} {
+	results := map[string]interface{}{}
+
+	input := HttpFoundation.NewInputBag(this.All())
+	for _, key := range keys {
+		if value, ok := input.All()[key]; ok {
+			results[key] = value
+		} else if input.Has(key) {
+			Support.Arr().Set(&results, key, input.Get(key))
+		}
+	}
+
+	return results
+}
+
+/**
+ * Get all of the input except for a specified array of items.
+ *
+ * @param  string  keys
+ * @return map[string]interface{}
+ */
+func (this *Request) Except(keys ...string) map[string]interface{} {
+	results := this.All()
+
+	for _, key := range keys {
+		HttpFoundation.Forget(results, key)
+	}
+
+	return results
+}
+
+/**
+ * Determine if the request contains all of the given input items.
+ *
+ * @param  string  keys
+ * @return bool
+ */
+func (this *Request) Has(keys ...string) bool {
+	input := HttpFoundation.NewInputBag(this.All())
+
+	for _, key := range keys {
+		if !input.Has(key) {
+			return false
+		}
+	}
+
+	return len(keys) > 0
+}
+
+/**
+ * Determine if the request contains any of the given input items.
+ *
+ * @param  string  keys
+ * @return bool
+ */
+func (this *Request) HasAny(keys ...string) bool {
+	input := HttpFoundation.NewInputBag(this.All())
+
+	for _, key := range keys {
+		if input.Has(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+ * Determine if the request is missing all of the given input items.
+ *
+ * @param  string  keys
+ * @return bool
+ */
+func (this *Request) Missing(keys ...string) bool {
+	return !this.HasAny(keys...)
+}
+
+/**
+ * Determine if the request contains a non-empty value for all of the given
+ * input items.
+ *
+ * @param  string  keys
+ * @return bool
+ */
+func (this *Request) Filled(keys ...string) bool {
+	input := HttpFoundation.NewInputBag(this.All())
+
+	for _, key := range keys {
+		if isBlankInput(input.Get(key)) {
+			return false
+		}
+	}
+
+	return len(keys) > 0
+}
+
+/**
+ * Retrieve an input item as an integer.
+ *
+ * @param  string  key
+ * @param  int  default
+ * @return int
+ */
+func (this *Request) Integer(key string, _default ...int) int {
+	_default = append(_default, 0)
+
+	switch value := this.Input(key).(type) {
+	case nil:
+		return _default[0]
+	case float64:
+		return int(value)
+	case bool:
+		if value {
+			return 1
+		}
+		return 0
+	default:
+		text := strings.TrimSpace(inputString(value))
+		if integer, err := strconv.Atoi(text); err == nil {
+			return integer
+		}
+		if float, err := strconv.ParseFloat(text, 64); err == nil {
+			return int(float)
+		}
+		return 0
+	}
+}
+
+/**
+ * Retrieve an input item as a boolean. "1", "true", "on" and "yes" are true.
+ *
+ * @param  string  key
+ * @param  bool  default
+ * @return bool
+ */
+func (this *Request) Boolean(key string, _default ...bool) bool {
+	_default = append(_default, false)
+
+	switch value := this.Input(key).(type) {
+	case nil:
+		return _default[0]
+	case bool:
+		return value
+	case float64:
+		return value != 0
+	default:
+		switch strings.ToLower(strings.TrimSpace(inputString(value))) {
+		case "1", "true", "on", "yes":
+			return true
+		}
+		return false
+	}
+}
+
+/**
+ * Retrieve an input item as a date, parsed with the given layout or with
+ * RFC 3339 and the common date formats. A missing or empty item gives the
+ * zero time.
+ *
+ * @param  string  key
+ * @param  string  format
+ * @return time.Time, error
+ */
+func (this *Request) Date(key string, format ...string) (time.Time, error) {
+	value := this.Input(key)
+	if isBlankInput(value) {
+		return time.Time{}, nil
+	}
+
+	if len(format) == 0 {
+		format = inputDateFormats
+	}
+
+	var err error
+	var date time.Time
+	for _, layout := range format {
+		if date, err = time.Parse(layout, strings.TrimSpace(inputString(value))); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, err
+}
+
+/**
+ * Get all of the files uploaded with the request.
+ *
+ * @return map[string][]*UploadedFile
+ */
+func (this *Request) AllFiles() map[string][]*UploadedFile {
+	if this.files == nil {
+		this.files = map[string][]*UploadedFile{}
+
+		if form := this.request.MultipartForm; form != nil {
+			maxSize := this.configSize("http.max_upload_size", 0)
+			for key, headers := range form.File {
+				for _, header := range headers {
+					this.files[key] = append(this.files[key], NewUploadedFile(this.App, header, maxSize))
+				}
+			}
+		}
+	}
+
+	return this.files
+}
+
+/**
+ * Retrieve a file from the request using "dot" notation, the first of the
+ * files when several were uploaded under the key.
+ *
+ * @param  string  key
+ * @return *UploadedFile
+ */
+func (this *Request) File(key string) *UploadedFile {
+	if files := this.Files(key); len(files) > 0 {
+		return files[0]
+	}
+	return nil
+}
+
+/**
+ * Retrieve all of the files uploaded under the given key.
+ *
+ * @param  string  key
+ * @return []*UploadedFile
+ */
+func (this *Request) Files(key string) []*UploadedFile {
+	files := []*UploadedFile{}
+
+	switch value := HttpFoundation.NewInputBag(this.nestedFiles()).Get(key).(type) {
+	case *UploadedFile:
+		files = append(files, value)
+	case []interface{}:
+		for _, item := range value {
+			if file, ok := item.(*UploadedFile); ok {
+				files = append(files, file)
+			}
+		}
+	}
+
+	return files
+}
+
+/**
+ * Determine if the request contains a valid file under the given key.
+ *
+ * @param  string  key
+ * @return bool
+ */
+func (this *Request) HasFile(key string) bool {
+	for _, file := range this.Files(key) {
+		if file.IsValid() {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Get the uploaded files nested like the rest of the input.
+ *
+ * @return map[string]interface{}
+ */
+func (this *Request) nestedFiles() map[string]interface{} {
+	input := map[string]interface{}{}
+
+	for key, files := range this.AllFiles() {
+		items := []interface{}{}
+		for _, file := range files {
+			items = append(items, file)
+		}
+		nestItems(input, key, items)
+	}
+
+	return input
+}
+
+/**
+ * Determine if an input value is empty, whitespace only strings included.
+ *
+ * @param  interface{}  value
+ * @return bool
+ */
+func isBlankInput(value interface{}) bool {
+	switch _value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(_value) == ""
+	case []interface{}:
+		return len(_value) == 0
+	case map[string]interface{}:
+		return len(_value) == 0
+	case *UploadedFile:
+		return _value == nil
+	}
+	return false
+}
+
+/**
+ * Get the text of a scalar input value.
+ *
+ * @param  interface{}  value
+ * @return string
+ */
+func inputString(value interface{}) string {
+	switch _value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return _value
+	case float64:
+		return strconv.FormatFloat(_value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(_value)
+	}
+	return ""
+}
+
+/**
+ * Copy decoded input, so changes to the copy leave the original alone.
+ *
+ * @param  interface{}  value
+ * @return interface{}
+ */
+func copyInput(value interface{}) interface{} {
+	switch _value := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(_value))
+		for key, item := range _value {
+			copied[key] = copyInput(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(_value))
+		for i, item := range _value {
+			copied[i] = copyInput(item)
+		}
+		return copied
+	}
+	return value
+}
+
+/**
+ * Turn form values into nested input, following the bracket notation of
+ * their keys: "tags[]" is a list and "user[name]" a map.
+ *
+ * @param  map[string][]string  values
+ * @return map[string]interface{}
+ */
+func nestValues(values map[string][]string) map[string]interface{} {
+	input := map[string]interface{}{}
+
+	for key, items := range values {
+		_items := []interface{}{}
+		for _, item := range items {
+			_items = append(_items, item)
+		}
+		nestItems(input, key, _items)
+	}
+
+	return input
+}
+
+/**
+ * Put the items sent under a key in bracket notation into nested input. A
+ * key sent once holds its item, otherwise it holds the list of its items.
+ *
+ * @param  map[string]interface{}  input
+ * @param  string  key
+ * @param  []interface{}  items
+ * @return void
+ */
+func nestItems(input map[string]interface{}, key string, items []interface{}) {
+	segments := splitInputKey(key)
+
+	list := len(segments) > 1 && segments[len(segments)-1] == ""
+	if list {
+		segments = segments[:len(segments)-1]
+	}
+
+	parent := input
+	for _, segment := range segments[:len(segments)-1] {
+		next, ok := parent[segment].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			parent[segment] = next
+		}
+		parent = next
+	}
+
+	last := segments[len(segments)-1]
+	if list || len(items) > 1 {
+		parent[last] = items
+	} else if len(items) == 1 {
+		parent[last] = items[0]
+	}
+}
+
+var inputKeySegment = regexp.MustCompile(`\[([^\]]*)\]`)
+
+/**
+ * Split a form key in bracket notation into its segments.
+ *
+ * @param  string  key
+ * @return []string
+ */
+func splitInputKey(key string) []string {
+	open := strings.Index(key, "[")
+	if open <= 0 || !strings.HasSuffix(key, "]") {
+		return []string{key}
+	}
+
+	segments := []string{key[:open]}
+	rest := key[open:]
+	for _, match := range inputKeySegment.FindAllStringSubmatch(rest, -1) {
+		segments = append(segments, match[1])
+	}
+	if strings.Join(inputKeySegment.FindAllString(rest, -1), "") != rest {
+		return []string{key}
+	}
+
+	return segments
+}
@@ -0,0 +1,114 @@
+package Http
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newInputRequest(method string, target string, contentType string, body string) *Request {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	if contentType != "" {
+		request.Header.Set("Content-Type", contentType)
+	}
+
+	return NewRequest(nil, httptest.NewRecorder(), request)
+}
+
+func TestFormInputIsNestedAndMergedWithTheQuery(t *testing.T) {
+	request := newInputRequest("POST", "/?page=2&name=query", "application/x-www-form-urlencoded",
+		"name=taylor&user[address][city]=Paris&tags[]=a&tags[]=b")
+
+	expected := map[string]interface{}{
+		"page": "2",
+		"name": "taylor",
+		"user": map[string]interface{}{"address": map[string]interface{}{"city": "Paris"}},
+		"tags": []interface{}{"a", "b"},
+	}
+	if all := request.All(); !reflect.DeepEqual(all, expected) {
+		t.Errorf("unexpected input %#v", all)
+	}
+	if city := request.Input("user.address.city"); city != "Paris" {
+		t.Errorf("expected the nested city, got %v", city)
+	}
+	if missing := request.Input("user.address.zip", "none"); missing != "none" {
+		t.Errorf("expected the default, got %v", missing)
+	}
+}
+
+func TestJsonInput(t *testing.T) {
+	request := newInputRequest("POST", "/?page=2", "application/json",
+		`{"name":"taylor","age":31,"admin":true,"user":{"email":"t@example.com"}}`)
+
+	if name := request.Input("name"); name != "taylor" {
+		t.Errorf("expected the name, got %v", name)
+	}
+	if email := request.Input("user.email"); email != "t@example.com" {
+		t.Errorf("expected the nested email, got %v", email)
+	}
+	if page := request.Input("page"); page != "2" {
+		t.Errorf("expected the query to be merged, got %v", page)
+	}
+	if age := request.Integer("age"); age != 31 {
+		t.Errorf("expected the age, got %d", age)
+	}
+	if !request.Boolean("admin") {
+		t.Error("expected admin to be true")
+	}
+}
+
+func TestInputPresence(t *testing.T) {
+	request := newInputRequest("POST", "/", "application/x-www-form-urlencoded", "name=taylor&blank=+&user[email]=t@example.com")
+
+	if !request.Has("name", "user.email") || request.Has("name", "age") || request.Has() {
+		t.Error("unexpected Has result")
+	}
+	if !request.HasAny("age", "name") || request.HasAny("age") {
+		t.Error("unexpected HasAny result")
+	}
+	if !request.Missing("age") || request.Missing("name") {
+		t.Error("unexpected Missing result")
+	}
+	if !request.Filled("name") || request.Filled("blank") || request.Filled("age") {
+		t.Error("unexpected Filled result")
+	}
+
+	if only := request.Only("name", "user.email"); !reflect.DeepEqual(only, map[string]interface{}{
+		"name": "taylor",
+		"user": map[string]interface{}{"email": "t@example.com"},
+	}) {
+		t.Errorf("unexpected Only result %#v", only)
+	}
+	if except := request.Except("blank", "user"); !reflect.DeepEqual(except, map[string]interface{}{"name": "taylor"}) {
+		t.Errorf("unexpected Except result %#v", except)
+	}
+}
+
+func TestTypedInput(t *testing.T) {
+	request := newInputRequest("GET", "/?count=12&ratio=2.5&bad=x&on=yes&off=no&day=2024-03-01", "", "")
+
+	for key, expected := range map[string]int{"count": 12, "ratio": 2, "bad": 0} {
+		if actual := request.Integer(key); actual != expected {
+			t.Errorf("Integer(%q) = %d, expected %d", key, actual, expected)
+		}
+	}
+	if request.Integer("missing", 7) != 7 {
+		t.Error("expected the default integer")
+	}
+	if !request.Boolean("on") || request.Boolean("off") || !request.Boolean("missing", true) {
+		t.Error("unexpected Boolean result")
+	}
+
+	day, err := request.Date("day")
+	if err != nil || !day.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected date %v, %v", day, err)
+	}
+	if _, err := request.Date("bad"); err == nil {
+		t.Error("expected an invalid date to fail")
+	}
+	if missing, err := request.Date("missing"); err != nil || !missing.IsZero() {
+		t.Error("expected a missing date to be the zero time")
+	}
+}
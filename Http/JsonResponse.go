@@ -0,0 +1,138 @@
+package Http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Support"
+	"regexp"
+	"strings"
+)
+
+const (
+	/**
+	 * Indent the JSON for humans to read.
+	 */
+	JSON_PRETTY_PRINT = 1 << iota
+
+	/**
+	 * Keep "<", ">" and "&" as they are instead of escaping them, which is only
+	 * safe when the JSON is never embedded in HTML.
+	 */
+	JSON_UNESCAPED_HTML
+)
+
+var (
+	/**
+	 * A valid JavaScript identifier, optionally followed by array accesses, for
+	 * each dotted part of a JSONP callback name.
+	 *
+	 * partially taken from https://geekality.net/2011/08/03/valid-javascript-identifier/
+	 * partially taken from https://github.com/willdurand/JsonpCallbackValidator
+	 *      JsonpCallbackValidator is released under the MIT License. See https://github.com/willdurand/JsonpCallbackValidator/blob/v1.1.0/LICENSE for details.
+	 *      (c) William Durand <william.durand1@gmail.com>
+	 *
+	 * @var *regexp.Regexp
+	 */
+	jsonpCallbackPattern = regexp.MustCompile(`^[$_\p{L}][$_\p{L}\p{Mn}\p{Mc}\p{Nd}\p{Pc}\x{200C}\x{200D}]*(?:\[(?:"(?:\\.|[^"\\])*"|'(?:\\.|[^'\\])*'|\d+)\])*?$`)
+
+	/**
+	 * The JavaScript reserved words, which are not valid callback names.
+	 *
+	 * @var map[string]bool
+	 */
+	jsonpReservedWords = map[string]bool{
+		"break": true, "do": true, "instanceof": true, "typeof": true, "case": true, "else": true, "new": true, "var": true, "catch": true, "finally": true, "return": true, "void": true, "continue": true, "for": true, "switch": true, "while": true,
+		"debugger": true, "function": true, "this": true, "with": true, "default": true, "if": true, "throw": true, "delete": true, "in": true, "try": true, "class": true, "enum": true, "extends": true, "super": true, "const": true, "export": true,
+		"import": true, "implements": true, "let": true, "private": true, "public": true, "yield": true, "interface": true, "package": true, "protected": true, "static": true, "null": true, "true": true, "false": true,
+	}
+)
+
+/**
+ * Creates a response with the given data encoded as JSON. Any value that can
+ * be marshaled is accepted, as well as Jsonable values.
+ *
+ * @param  interface{}  data
+ * @param  int  status
+ * @param  int  options  A bit field of JSON_* options
+ * @param  map[string][]string  headers
+ * @return *Response
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func NewJsonResponse(data interface{}, status int, options int, headers ...map[string][]string) (this *Response) {
+	content, err := encodeJson(data, options)
+	if err != nil {
+		panic(Errors.NewInvalidArgumentException(err.Error()))
+	}
+
+	this = NewResponse(content, status, headers...)
+	this.Header("Content-Type", "application/json")
+
+	return this
+}
+
+/**
+ * Encode the given data as JSON with the given options.
+ *
+ * @param  interface{}  data
+ * @param  int  options
+ * @return []byte, error
+ */
+func encodeJson(data interface{}, options int) ([]byte, error) {
+	if jsonable, ok := data.(Support.Jsonable); ok {
+		content, err := jsonable.ToJson()
+		if err != nil {
+			return nil, err
+		}
+		// A Jsonable value encodes itself, so "<", ">" and "&" are escaped here
+		// the way the encoder escapes them, unless they are to be kept.
+		if options&JSON_UNESCAPED_HTML == 0 {
+			var escaped bytes.Buffer
+			json.HTMLEscape(&escaped, content)
+			content = escaped.Bytes()
+		}
+		if options&JSON_PRETTY_PRINT == 0 {
+			return content, nil
+		}
+		var indented bytes.Buffer
+		err = json.Indent(&indented, content, "", "    ")
+		return indented.Bytes(), err
+	}
+
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(options&JSON_UNESCAPED_HTML == 0)
+	if options&JSON_PRETTY_PRINT != 0 {
+		encoder.SetIndent("", "    ")
+	}
+	if err := encoder.Encode(data); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimRight(buffer.Bytes(), "\n"), nil
+}
+
+/**
+ * Turn the JSON content of the response into JSONP by wrapping it in a call
+ * to the given callback.
+ *
+ * @param  string  callback
+ * @return $this
+ *
+ * @throws Errors.InvalidArgumentException When the callback name is not valid
+ */
+func (this *Response) WithCallback(callback string) *Response {
+	for _, part := range strings.Split(callback, ".") {
+		if !jsonpCallbackPattern.MatchString(part) || jsonpReservedWords[part] {
+			panic(Errors.NewInvalidArgumentException(fmt.Sprintf(`The callback name "%s" is not valid.`, callback)))
+		}
+	}
+
+	// Not using application/javascript for compatibility reasons with older browsers.
+	this.content = []byte(fmt.Sprintf("/**/%s(%s);", callback, this.content))
+	this.Header("Content-Type", "text/javascript")
+
+	return this
+}
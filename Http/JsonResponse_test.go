@@ -0,0 +1,57 @@
+package Http
+
+import (
+	"testing"
+
+	"github.com/larisgo/framework/Errors"
+)
+
+type rawJson string
+
+func (this rawJson) ToJson() ([]byte, error) {
+	return []byte(this), nil
+}
+
+func TestJsonResponseEscapesHtmlUnlessTold(t *testing.T) {
+	for _, data := range []interface{}{map[string]string{"html": "<b>&</b>"}, rawJson(`{"html":"<b>&</b>"}`)} {
+		escaped := NewJsonResponse(data, HTTP_OK, 0)
+		if content := string(escaped.content); content != `{"html":"\u003cb\u003e\u0026\u003c/b\u003e"}` {
+			t.Errorf("%T: expected the HTML to be escaped, got %s", data, content)
+		}
+
+		unescaped := NewJsonResponse(data, HTTP_OK, JSON_UNESCAPED_HTML)
+		if content := string(unescaped.content); content != `{"html":"<b>&</b>"}` {
+			t.Errorf("%T: expected the HTML to be kept, got %s", data, content)
+		}
+
+		pretty := NewJsonResponse(data, HTTP_OK, JSON_PRETTY_PRINT|JSON_UNESCAPED_HTML)
+		if content := string(pretty.content); content != "{\n    \"html\": \"<b>&</b>\"\n}" {
+			t.Errorf("%T: expected indented JSON, got %s", data, content)
+		}
+	}
+}
+
+func TestJsonResponseWithCallback(t *testing.T) {
+	response := NewJsonResponse(map[string]int{"count": 1}, HTTP_OK, 0).WithCallback("app.callbacks[0]")
+
+	if content := string(response.content); content != `/**/app.callbacks[0]({"count":1});` {
+		t.Errorf("unexpected JSONP content %s", content)
+	}
+	if contentType := response.Headers.Get("Content-Type"); contentType != "text/javascript" {
+		t.Errorf("expected text/javascript, got %s", contentType)
+	}
+}
+
+func TestJsonResponseRejectsInvalidCallbacks(t *testing.T) {
+	for _, callback := range []string{"alert(1)", "app.function", "1app", "app..done", ""} {
+		func() {
+			defer func() {
+				if _, ok := recover().(Errors.InvalidArgumentException); !ok {
+					t.Errorf("expected %q to be rejected", callback)
+				}
+			}()
+
+			NewJsonResponse(nil, HTTP_OK, 0).WithCallback(callback)
+		}()
+	}
+}
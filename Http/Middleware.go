@@ -0,0 +1,25 @@
+package Http
+
+type Next func(*Request) *Response
+
+type Middleware interface {
+	/**
+	 * Handle an incoming request.
+	 *
+	 * @param  Http.Request  request
+	 * @param  Http.Next  next
+	 * @param  string  parameters
+	 * @return Http.Response
+	 */
+	Handle(request *Request, next Next, parameters ...string) *Response
+}
+
+/**
+ * The MiddlewareFunc type is an adapter to allow the use of ordinary
+ * functions as middleware.
+ */
+type MiddlewareFunc func(request *Request, next Next, parameters ...string) *Response
+
+func (this MiddlewareFunc) Handle(request *Request, next Next, parameters ...string) *Response {
+	return this(request, next, parameters...)
+}
@@ -0,0 +1,409 @@
+package Middleware
+
+import (
+	"fmt"
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+	"github.com/larisgo/framework/Support"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type CorsOptions struct {
+	/**
+	 * The request paths the CORS headers apply to, e.g. "api/*". An empty list
+	 * applies them to every path.
+	 *
+	 * @var []string
+	 */
+	Paths []string
+
+	AllowedOrigins         []string
+	AllowedOriginsPatterns []string
+	AllowedMethods         []string
+	AllowedHeaders         []string
+	ExposedHeaders         []string
+
+	/**
+	 * The number of seconds a preflight response may be cached by the client.
+	 *
+	 * @var int
+	 */
+	MaxAge int
+
+	SupportsCredentials bool
+
+	/**
+	 * The compiled allowed origins patterns.
+	 *
+	 * @var []*regexp.Regexp
+	 */
+	originsPatterns []*regexp.Regexp
+}
+
+/**
+ * Create the CORS options from a configuration array.
+ *
+ * @param  map[string]interface{}  config
+ * @return *CorsOptions
+ */
+func NewCorsOptions(config map[string]interface{}) (this *CorsOptions) {
+	this = &CorsOptions{}
+	this.Paths = corsStrings(config["paths"])
+	this.AllowedOrigins = corsStrings(config["allowed_origins"])
+	this.AllowedOriginsPatterns = corsStrings(config["allowed_origins_patterns"])
+	this.AllowedMethods = corsStrings(config["allowed_methods"])
+	this.AllowedHeaders = corsStrings(config["allowed_headers"])
+	this.ExposedHeaders = corsStrings(config["exposed_headers"])
+
+	switch maxAge := config["max_age"].(type) {
+	case int:
+		this.MaxAge = maxAge
+	case string:
+		this.MaxAge, _ = strconv.Atoi(maxAge)
+	}
+
+	if credentials, ok := config["supports_credentials"].(bool); ok {
+		this.SupportsCredentials = credentials
+	}
+
+	this.compilePatterns()
+
+	return this
+}
+
+/**
+ * Compile the allowed origins patterns, unless they are compiled already.
+ *
+ * @return void
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *CorsOptions) compilePatterns() {
+	if len(this.originsPatterns) == len(this.AllowedOriginsPatterns) {
+		return
+	}
+
+	patterns := make([]*regexp.Regexp, 0, len(this.AllowedOriginsPatterns))
+	for _, pattern := range this.AllowedOriginsPatterns {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			panic(Errors.NewInvalidArgumentException(fmt.Sprintf("Invalid CORS allowed origins pattern [%s]: %s", pattern, err)))
+		}
+		patterns = append(patterns, compiled)
+	}
+	this.originsPatterns = patterns
+}
+
+type HandleCors struct {
+	App Foundation.Application `inject:"app"`
+
+	/**
+	 * The CORS options. When nil, they are read from the "cors" configuration.
+	 *
+	 * @var *CorsOptions
+	 */
+	Options *CorsOptions
+
+	once sync.Once
+
+	/**
+	 * The error met while reading the options, reported on every request.
+	 *
+	 * @var interface{}
+	 */
+	optionsError interface{}
+}
+
+func NewHandleCors(options ...*CorsOptions) (this *HandleCors) {
+	this = &HandleCors{}
+	if len(options) > 0 {
+		this.Options = options[0]
+	}
+	return this
+}
+
+/**
+ * Handle the incoming request.
+ *
+ * @param  Http.Request  request
+ * @param  Http.Next  next
+ * @return Http.Response
+ */
+func (this *HandleCors) Handle(request *Http.Request, next Http.Next, parameters ...string) *Http.Response {
+	options := this.getOptions()
+
+	if !this.hasMatchingPath(request, options) {
+		return next(request)
+	}
+
+	// The router answers OPTIONS requests for any path matched by a route, so we
+	// will let the request through and turn a successful answer into a proper
+	// preflight response. Unknown paths keep failing with a 404 as expected.
+	if this.isPreflightRequest(request) {
+		return this.addPreflightRequestHeaders(next(request), request, options)
+	}
+
+	response := next(request)
+
+	if request.Method() == "OPTIONS" {
+		response.Header("Vary", "Access-Control-Request-Method", false)
+	}
+
+	return this.addActualRequestHeaders(response, request, options)
+}
+
+/**
+ * Get the CORS options, with their allowed origins patterns compiled.
+ *
+ * @return *CorsOptions
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *HandleCors) getOptions() *CorsOptions {
+	this.once.Do(func() {
+		defer func() {
+			this.optionsError = recover()
+		}()
+
+		if this.Options == nil {
+			this.Options = this.resolveOptions()
+		}
+		this.Options.compilePatterns()
+	})
+
+	if this.optionsError != nil {
+		panic(this.optionsError)
+	}
+
+	return this.Options
+}
+
+/**
+ * Read the CORS options from the "cors" configuration.
+ *
+ * @return *CorsOptions
+ */
+func (this *HandleCors) resolveOptions() *CorsOptions {
+	if this.App != nil {
+		if config, ok := this.App.Make("config").(RepositoryContract.Repository); ok {
+			if cors, ok := config.Get("cors").(map[string]interface{}); ok {
+				return NewCorsOptions(cors)
+			}
+		}
+	}
+
+	return &CorsOptions{}
+}
+
+/**
+ * Get the path from the config, to see if the CORS Service should run.
+ *
+ * @param  Http.Request  request
+ * @param  *CorsOptions  options
+ * @return bool
+ */
+func (this *HandleCors) hasMatchingPath(request *Http.Request, options *CorsOptions) bool {
+	if len(options.Paths) == 0 {
+		return true
+	}
+
+	path := strings.Trim(request.Path(), "/")
+	if path == "" {
+		path = "/"
+	}
+
+	for _, pattern := range options.Paths {
+		if pattern != "/" {
+			pattern = strings.Trim(pattern, "/")
+		}
+		if Support.Str().Is(path, []string{pattern}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+ * Determine if the request is a CORS preflight request.
+ *
+ * @param  Http.Request  request
+ * @return bool
+ */
+func (this *HandleCors) isPreflightRequest(request *Http.Request) bool {
+	return request.Method() == "OPTIONS" && request.Headers.Get("Origin") != "" && request.Headers.Get("Access-Control-Request-Method") != ""
+}
+
+/**
+ * Add the preflight headers to the response.
+ *
+ * @param  Http.Response  response
+ * @param  Http.Request  request
+ * @param  *CorsOptions  options
+ * @return Http.Response
+ */
+func (this *HandleCors) addPreflightRequestHeaders(response *Http.Response, request *Http.Request, options *CorsOptions) *Http.Response {
+	if !response.IsSuccessful() || !this.configureAllowedOrigin(response, request, options) {
+		return response
+	}
+
+	response.SetStatusCode(Http.HTTP_NO_CONTENT)
+	response.SetContent("")
+	response.Headers.Del("Content-Type")
+
+	if options.SupportsCredentials {
+		response.Header("Access-Control-Allow-Credentials", "true")
+	}
+
+	if this.allowsAll(options.AllowedMethods) {
+		// When every method is allowed we will only advertise the methods the matched
+		// route actually responds to, which the router puts in the "Allow" header.
+		if allow := response.Headers.Get("Allow"); allow != "" {
+			response.Header("Access-Control-Allow-Methods", allow)
+		} else {
+			response.Header("Access-Control-Allow-Methods", strings.ToUpper(request.Headers.Get("Access-Control-Request-Method")))
+		}
+	} else {
+		response.Header("Access-Control-Allow-Methods", strings.Join(options.AllowedMethods, ", "))
+	}
+
+	if this.allowsAll(options.AllowedHeaders) {
+		if headers := request.Headers.Get("Access-Control-Request-Headers"); headers != "" {
+			response.Header("Access-Control-Allow-Headers", headers)
+		}
+		response.Header("Vary", "Access-Control-Request-Headers", false)
+	} else if len(options.AllowedHeaders) > 0 {
+		response.Header("Access-Control-Allow-Headers", strings.Join(options.AllowedHeaders, ", "))
+	}
+
+	if options.MaxAge > 0 {
+		response.Header("Access-Control-Max-Age", strconv.Itoa(options.MaxAge))
+	}
+
+	response.Header("Vary", "Access-Control-Request-Method", false)
+
+	return response
+}
+
+/**
+ * Add the CORS headers to the response of an actual request.
+ *
+ * @param  Http.Response  response
+ * @param  Http.Request  request
+ * @param  *CorsOptions  options
+ * @return Http.Response
+ */
+func (this *HandleCors) addActualRequestHeaders(response *Http.Response, request *Http.Request, options *CorsOptions) *Http.Response {
+	if !this.configureAllowedOrigin(response, request, options) {
+		return response
+	}
+
+	if options.SupportsCredentials {
+		response.Header("Access-Control-Allow-Credentials", "true")
+	}
+
+	if len(options.ExposedHeaders) > 0 {
+		response.Header("Access-Control-Expose-Headers", strings.Join(options.ExposedHeaders, ", "))
+	}
+
+	return response
+}
+
+/**
+ * Set the allowed origin on the response.
+ *
+ * @param  Http.Response  response
+ * @param  Http.Request  request
+ * @param  *CorsOptions  options
+ * @return bool  Whether the origin of the request is allowed
+ */
+func (this *HandleCors) configureAllowedOrigin(response *Http.Response, request *Http.Request, options *CorsOptions) bool {
+	origin := request.Headers.Get("Origin")
+
+	// Safe+cacheable, allow everything. A wildcard can not be combined with the
+	// credentials header, so in that case the origin is echoed back instead.
+	if this.allowsAll(options.AllowedOrigins) && !options.SupportsCredentials {
+		response.Header("Access-Control-Allow-Origin", "*")
+		return true
+	}
+
+	if origin == "" || !this.isOriginAllowed(origin, options) {
+		return false
+	}
+
+	response.Header("Access-Control-Allow-Origin", origin)
+	response.Header("Vary", "Origin", false)
+
+	return true
+}
+
+/**
+ * Determine if the given origin is allowed.
+ *
+ * @param  string  origin
+ * @param  *CorsOptions  options
+ * @return bool
+ */
+func (this *HandleCors) isOriginAllowed(origin string, options *CorsOptions) bool {
+	if this.allowsAll(options.AllowedOrigins) {
+		return true
+	}
+
+	for _, allowed := range options.AllowedOrigins {
+		if allowed == origin {
+			return true
+		}
+	}
+
+	for _, pattern := range options.originsPatterns {
+		if pattern.MatchString(origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+ * Determine if the given list allows any value.
+ *
+ * @param  []string  values
+ * @return bool
+ */
+func (this *HandleCors) allowsAll(values []string) bool {
+	for _, value := range values {
+		if value == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Convert a configuration value into a list of strings.
+ *
+ * @param  interface{}  value
+ * @return []string
+ */
+func corsStrings(value interface{}) []string {
+	switch _value := value.(type) {
+	case []string:
+		return _value
+	case string:
+		return []string{_value}
+	case []interface{}:
+		values := []string{}
+		for _, item := range _value {
+			if _item, ok := item.(string); ok {
+				values = append(values, _item)
+			}
+		}
+		return values
+	}
+	return []string{}
+}
@@ -0,0 +1,59 @@
+package Middleware
+
+import (
+	"testing"
+
+	"github.com/larisgo/framework/Errors"
+)
+
+func TestOriginMatchingAnAllowedPatternIsAllowed(t *testing.T) {
+	cors := NewHandleCors(NewCorsOptions(map[string]interface{}{
+		"allowed_origins":          []interface{}{"https://example.com"},
+		"allowed_origins_patterns": []interface{}{`^https://[a-z]+\.example\.com$`},
+	}))
+	options := cors.getOptions()
+
+	for origin, allowed := range map[string]bool{
+		"https://example.com":      true,
+		"https://acme.example.com": true,
+		"https://acme.example.org": false,
+		"http://acme.example.com":  false,
+	} {
+		if cors.isOriginAllowed(origin, options) != allowed {
+			t.Errorf("expected %s to be allowed: %v", origin, allowed)
+		}
+	}
+}
+
+func TestPatternsOfGivenOptionsAreCompiled(t *testing.T) {
+	cors := NewHandleCors(&CorsOptions{AllowedOriginsPatterns: []string{`^https://.*\.example\.com$`}})
+
+	if !cors.isOriginAllowed("https://acme.example.com", cors.getOptions()) {
+		t.Error("expected the origin to match the pattern")
+	}
+}
+
+func expectInvalidArgument(t *testing.T, callback func()) {
+	t.Helper()
+
+	defer func() {
+		if _, ok := recover().(Errors.InvalidArgumentException); !ok {
+			t.Error("expected an invalid argument exception")
+		}
+	}()
+
+	callback()
+}
+
+func TestInvalidPatternIsReported(t *testing.T) {
+	expectInvalidArgument(t, func() {
+		NewCorsOptions(map[string]interface{}{"allowed_origins_patterns": "^https://(example.com$"})
+	})
+
+	cors := NewHandleCors(&CorsOptions{AllowedOriginsPatterns: []string{"^https://(example.com$"}})
+	for i := 0; i < 2; i++ {
+		expectInvalidArgument(t, func() {
+			cors.getOptions()
+		})
+	}
+}
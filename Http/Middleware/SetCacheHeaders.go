@@ -0,0 +1,139 @@
+package Middleware
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * Set the cache headers of the response from the options given as the
+ * middleware parameters, e.g. "cache.headers:public;max_age=2628000;etag".
+ * A request whose validators match the response is answered with a
+ * "304 Not Modified" response.
+ */
+type SetCacheHeaders struct {
+}
+
+/**
+ * Handle an incoming request.
+ *
+ * @param  Http.Request  request
+ * @param  Http.Next  next
+ * @param  string  parameters
+ * @return Http.Response
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *SetCacheHeaders) Handle(request *Http.Request, next Http.Next, parameters ...string) *Http.Response {
+	response := next(request)
+
+	// The content of a streamed response is unknown until it is sent, so
+	// there is nothing to validate it with.
+	if !request.IsMethodCacheable() || response.IsStreamed() || (!response.IsFile() && len(response.Content()) == 0) {
+		return response
+	}
+
+	options := this.parseOptions(parameters)
+
+	if _, ok := options["etag"]; ok {
+		response.SetAutoEtag()
+		delete(options, "etag")
+	} else if _, ok := options["weak_etag"]; ok {
+		response.SetAutoEtag(true)
+		delete(options, "weak_etag")
+	}
+
+	if value, ok := options["last_modified"]; ok {
+		response.SetLastModified(this.parseTime(value))
+		delete(options, "last_modified")
+	}
+
+	this.setCache(response, options)
+	response.IsNotModified(request)
+
+	return response
+}
+
+/**
+ * Parse the options given as "name" or "name=value" pairs separated by
+ * semicolons.
+ *
+ * @param  []string  parameters
+ * @return map[string]string
+ */
+func (this *SetCacheHeaders) parseOptions(parameters []string) map[string]string {
+	options := map[string]string{}
+
+	for _, option := range strings.Split(strings.Join(parameters, ";"), ";") {
+		if option = strings.TrimSpace(option); option == "" {
+			continue
+		}
+
+		parts := append(strings.SplitN(option, "=", 2), "")
+		options[strings.ToLower(strings.TrimSpace(parts[0]))] = strings.TrimSpace(parts[1])
+	}
+
+	return options
+}
+
+/**
+ * Set the Cache-Control directives of the response.
+ *
+ * @param  *Http.Response  response
+ * @param  map[string]string  options
+ * @return void
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *SetCacheHeaders) setCache(response *Http.Response, options map[string]string) {
+	for option, value := range options {
+		switch option {
+		case "public":
+			response.SetPublic()
+		case "private":
+			response.SetPrivate()
+		case "immutable":
+			response.SetImmutable()
+		case "max_age", "s_maxage":
+			seconds, err := strconv.Atoi(value)
+			if err != nil {
+				panic(Errors.NewInvalidArgumentException(fmt.Sprintf(`The "%s" cache option must be a number of seconds, "%s" given.`, option, value)))
+			}
+			if option == "max_age" {
+				response.SetMaxAge(seconds)
+			} else {
+				response.SetSharedMaxAge(seconds)
+			}
+		case "must_revalidate", "no_cache", "no_store", "no_transform", "proxy_revalidate":
+			response.AddCacheControlDirective(strings.Replace(option, "_", "-", -1))
+		default:
+			panic(Errors.NewInvalidArgumentException(fmt.Sprintf(`Response does not support the following options: "%s".`, option)))
+		}
+	}
+}
+
+/**
+ * Parse the time of the "last_modified" option, either a Unix timestamp or
+ * an HTTP date.
+ *
+ * @param  string  value
+ * @return time.Time
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *SetCacheHeaders) parseTime(value string) time.Time {
+	if timestamp, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(timestamp, 0)
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return date
+	}
+
+	panic(Errors.NewInvalidArgumentException(fmt.Sprintf(`The "last_modified" cache option must be a timestamp or a date, "%s" given.`, value)))
+}
@@ -0,0 +1,75 @@
+package Middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+)
+
+func cacheHeaders(method string, headers map[string]string, content string, parameters ...string) *Http.Response {
+	request := httptest.NewRequest(method, "/", nil)
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+
+	return (&SetCacheHeaders{}).Handle(Http.NewRequest(nil, httptest.NewRecorder(), request), func(*Http.Request) *Http.Response {
+		return Http.NewResponse(content, Http.HTTP_OK)
+	}, parameters...)
+}
+
+func TestCacheHeadersAreSetFromTheOptions(t *testing.T) {
+	response := cacheHeaders("GET", nil, "content", "public;max_age=2628000;etag", "must_revalidate")
+
+	if header := response.Headers.Get("Cache-Control"); header != "max-age=2628000, must-revalidate, public" {
+		t.Errorf("unexpected Cache-Control %s", header)
+	}
+	if response.GetEtag() == "" {
+		t.Error("expected an ETag")
+	}
+	if response.Status() != Http.HTTP_OK {
+		t.Errorf("unexpected status %d", response.Status())
+	}
+
+	if etag := cacheHeaders("GET", nil, "content", "weak_etag").GetEtag(); etag[:2] != "W/" {
+		t.Errorf("expected a weak ETag, got %s", etag)
+	}
+	if modified := cacheHeaders("GET", nil, "content", "last_modified=1709294400").Headers.Get("Last-Modified"); modified != "Fri, 01 Mar 2024 12:00:00 GMT" {
+		t.Errorf("unexpected Last-Modified %s", modified)
+	}
+}
+
+func TestMatchingValidatorsGiveNotModified(t *testing.T) {
+	etag := cacheHeaders("GET", nil, "content", "etag").GetEtag()
+
+	response := cacheHeaders("GET", map[string]string{"If-None-Match": etag}, "content", "etag")
+	if response.Status() != Http.HTTP_NOT_MODIFIED || len(response.Content()) != 0 {
+		t.Errorf("expected a not modified response, got %d", response.Status())
+	}
+
+	response = cacheHeaders("GET", map[string]string{"If-Modified-Since": "Fri, 01 Mar 2024 12:00:00 GMT"}, "content", "last_modified=1709294400")
+	if response.Status() != Http.HTTP_NOT_MODIFIED {
+		t.Errorf("expected a not modified response, got %d", response.Status())
+	}
+
+	if response := cacheHeaders("POST", map[string]string{"If-None-Match": etag}, "content", "etag"); response.Status() != Http.HTTP_OK || response.GetEtag() != "" {
+		t.Error("the cache headers were applied to a POST request")
+	}
+	if response := cacheHeaders("GET", nil, "", "etag"); response.GetEtag() != "" {
+		t.Error("the cache headers were applied to an empty response")
+	}
+}
+
+func TestInvalidCacheOptionsAreRejected(t *testing.T) {
+	for _, parameters := range []string{"max_age=soon", "unknown", "last_modified=tomorrow"} {
+		func() {
+			defer func() {
+				if _, ok := recover().(Errors.InvalidArgumentException); !ok {
+					t.Errorf("expected %q to be rejected", parameters)
+				}
+			}()
+			cacheHeaders("GET", nil, "content", parameters)
+		}()
+	}
+}
@@ -0,0 +1,104 @@
+package Middleware
+
+import (
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type TrustHosts struct {
+	App Foundation.Application `inject:"app"`
+
+	/**
+	 * The host patterns that should be trusted. When nil, they are read from
+	 * the "trustedhost.hosts" configuration.
+	 *
+	 * @var []string
+	 */
+	Hosts []string
+
+	patterns []*regexp.Regexp
+
+	once sync.Once
+}
+
+/**
+ * Handle the incoming request.
+ *
+ * @param  Http.Request  request
+ * @param  Http.Next  next
+ * @return Http.Response
+ *
+ * @throws Errors.SuspiciousOperationException
+ */
+func (this *TrustHosts) Handle(request *Http.Request, next Http.Next, parameters ...string) *Http.Response {
+	this.once.Do(func() {
+		this.patterns = Http.CompileTrustedHosts(this.hosts())
+	})
+
+	// Without host patterns of its own, the request keeps the globally trusted
+	// ones.
+	if len(this.patterns) > 0 {
+		request.SetTrustedHosts(this.patterns)
+	}
+
+	if (len(this.patterns) > 0 || len(Http.GetTrustedHosts()) > 0) && !request.IsHostValid() {
+		panic(Errors.NewSuspiciousOperationException("Untrusted Host.", nil))
+	}
+
+	return next(request)
+}
+
+/**
+ * Get the host patterns that should be trusted.
+ *
+ * @return []string
+ */
+func (this *TrustHosts) hosts() []string {
+	if this.Hosts != nil || this.App == nil {
+		return this.Hosts
+	}
+
+	config, ok := this.App.Make("config").(RepositoryContract.Repository)
+	if !ok {
+		return []string{}
+	}
+
+	hosts := []string{}
+	for _, host := range corsStrings(config.Get("trustedhost.hosts")) {
+		// The application URL stands for itself and all of its subdomains.
+		if host == "app.url" {
+			if host = this.AllSubdomainsOfApplicationUrl(); host == "" {
+				continue
+			}
+		}
+		hosts = append(hosts, host)
+	}
+
+	return hosts
+}
+
+/**
+ * Get a regular expression matching the application URL and all of its
+ * subdomains.
+ *
+ * @return string
+ */
+func (this *TrustHosts) AllSubdomainsOfApplicationUrl() string {
+	config, ok := this.App.Make("config").(RepositoryContract.Repository)
+	if !ok {
+		return ""
+	}
+
+	appUrl, _ := config.Get("app.url").(string)
+	if parsed, err := url.Parse(appUrl); err == nil && parsed.Hostname() != "" {
+		return `^(.+\.)?` + regexp.QuoteMeta(strings.ToLower(parsed.Hostname())) + `$`
+	}
+
+	return ""
+}
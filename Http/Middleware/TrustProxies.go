@@ -0,0 +1,104 @@
+package Middleware
+
+import (
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Http"
+	"strings"
+	"sync"
+)
+
+type TrustProxies struct {
+	App Foundation.Application `inject:"app"`
+
+	/**
+	 * The trusted proxies, as IPs or CIDR notations. When nil, they are read
+	 * from the "trustedproxy.proxies" configuration.
+	 *
+	 * @var []string
+	 */
+	Proxies []string
+
+	/**
+	 * The headers that should be used to detect proxies. When zero, they are
+	 * read from the "trustedproxy.headers" configuration.
+	 *
+	 * @var int
+	 */
+	Headers int
+
+	once sync.Once
+}
+
+/**
+ * The names the trusted header sets can be configured by.
+ *
+ * @var map[string]int
+ */
+var trustedHeaderNames = map[string]int{
+	"forwarded":           Http.HEADER_FORWARDED,
+	"x-forwarded-for":     Http.HEADER_X_FORWARDED_FOR,
+	"x-forwarded-host":    Http.HEADER_X_FORWARDED_HOST,
+	"x-forwarded-proto":   Http.HEADER_X_FORWARDED_PROTO,
+	"x-forwarded-port":    Http.HEADER_X_FORWARDED_PORT,
+	"x-forwarded-all":     Http.HEADER_X_FORWARDED_ALL,
+	"x-forwarded-aws-elb": Http.HEADER_X_FORWARDED_AWS_ELB,
+}
+
+/**
+ * Handle an incoming request.
+ *
+ * @param  Http.Request  request
+ * @param  Http.Next  next
+ * @return Http.Response
+ */
+func (this *TrustProxies) Handle(request *Http.Request, next Http.Next, parameters ...string) *Http.Response {
+	this.once.Do(this.loadConfiguration)
+
+	// Without proxies of its own, the request keeps the globally trusted ones.
+	if len(this.Proxies) > 0 {
+		request.SetTrustedProxies(this.Proxies, this.Headers)
+	}
+
+	return next(request)
+}
+
+/**
+ * Fill the proxies and headers that were not given from the configuration.
+ *
+ * @return void
+ */
+func (this *TrustProxies) loadConfiguration() {
+	var config RepositoryContract.Repository
+	if this.App != nil {
+		config, _ = this.App.Make("config").(RepositoryContract.Repository)
+	}
+
+	if this.Proxies == nil {
+		this.Proxies = []string{}
+		if config != nil {
+			// A single string may list several proxies separated by commas.
+			for _, proxy := range corsStrings(config.Get("trustedproxy.proxies")) {
+				for _, _proxy := range strings.Split(proxy, ",") {
+					if _proxy = strings.TrimSpace(_proxy); _proxy != "" {
+						this.Proxies = append(this.Proxies, _proxy)
+					}
+				}
+			}
+		}
+	}
+
+	if this.Headers == 0 {
+		this.Headers = Http.HEADER_X_FORWARDED_ALL
+		if config != nil {
+			switch headers := config.Get("trustedproxy.headers").(type) {
+			case int:
+				this.Headers = headers
+			case string:
+				if headerSet, ok := trustedHeaderNames[strings.ToLower(headers)]; ok {
+					this.Headers = headerSet
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package Middleware
+
+import (
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+)
+
+type ValidatePostSize struct {
+}
+
+/**
+ * Handle an incoming request.
+ *
+ * @param  Http.Request  request
+ * @param  Http.Next  next
+ * @return Http.Response
+ */
+func (this *ValidatePostSize) Handle(request *Http.Request, next Http.Next, parameters ...string) *Http.Response {
+	// A JSON body is only read when first asked for, so we will read it now to
+	// find out whether it is within the limit before the route sees it.
+	if request.IsJson() {
+		request.GetContent()
+	}
+
+	if request.IsBodyTooLarge() {
+		panic(Errors.NewPostTooLargeException("The POST data is too large.", nil))
+	}
+
+	return next(request)
+}
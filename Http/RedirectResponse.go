@@ -0,0 +1,47 @@
+package Http
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"html"
+)
+
+/**
+ * Creates a redirect response so that it conforms to the rules defined for a redirect status code.
+ *
+ * @param  string  url      The URL to redirect to. The URL should be a full URL, with schema etc.,
+ *                          but practically every browser redirects on paths only as well
+ * @param  int     status   The status code (302 by default)
+ * @param  map[string][]string  headers  The headers (Location is always set to the given URL)
+ * @return *Response
+ *
+ * @throws Errors.InvalidArgumentException
+ *
+ * @see http://tools.ietf.org/html/rfc2616#section-10.3
+ */
+func NewRedirectResponse(url string, status int, headers ...map[string][]string) (this *Response) {
+	if url == "" {
+		panic(Errors.NewInvalidArgumentException("Cannot redirect to an empty URL."))
+	}
+
+	this = NewResponse(fmt.Sprintf(`<!DOCTYPE html>
+<html>
+    <head>
+        <meta charset="UTF-8" />
+        <meta http-equiv="refresh" content="0;url='%[1]s'" />
+
+        <title>Redirecting to %[1]s</title>
+    </head>
+    <body>
+        Redirecting to <a href="%[1]s">%[1]s</a>.
+    </body>
+</html>`, html.EscapeString(url)), status, headers...)
+
+	this.Header("Location", url)
+
+	if !this.IsRedirect() || this.IsEmpty() || status == HTTP_CREATED {
+		panic(Errors.NewInvalidArgumentException(fmt.Sprintf(`The HTTP status code is not a redirect ("%d" given).`, status)))
+	}
+
+	return this
+}
@@ -1,33 +1,170 @@
 package Http
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	CookieContract "github.com/larisgo/framework/Contracts/Cookie"
 	"github.com/larisgo/framework/Contracts/Foundation"
 	"github.com/larisgo/framework/Errors"
 	"github.com/larisgo/framework/Http/HttpFoundation"
 	"github.com/larisgo/framework/Support"
+	"io"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
 
+const (
+	/**
+	 * The largest request body read when "http.max_body_size" is not set.
+	 */
+	DEFAULT_MAX_BODY_SIZE = 64 << 20
+
+	/**
+	 * The part of a multipart body kept in memory when "http.max_memory" is
+	 * not set, the rest going to temporary files.
+	 */
+	DEFAULT_MAX_MEMORY = 32 << 20
+)
+
+var (
+	/**
+	 * An IPv6 literal host, within brackets, and its optional port.
+	 *
+	 * @var *regexp.Regexp
+	 */
+	ipv6HostPattern = regexp.MustCompile(`^(\[[0-9a-f:.]+\])(?::\d*)?$`)
+
+	/**
+	 * The port number at the end of a host.
+	 *
+	 * @var *regexp.Regexp
+	 */
+	hostPortPattern = regexp.MustCompile(`:\d*$`)
+
+	/**
+	 * The characters allowed in a host, see RFC 952 and RFC 2181.
+	 *
+	 * @var *regexp.Regexp
+	 */
+	hostCharactersPattern = regexp.MustCompile(`(?:^\[)?[a-z0-9-:\]_]+\.?`)
+)
+
 type Request struct {
 	App Foundation.Application
 
 	request  *http.Request
 	response http.ResponseWriter
 
-	method      string
-	pathInfo    string
-	isHostValid bool
+	method           string
+	pathInfo         string
+	isHostValid      bool
+	isForwardedValid bool
+
+	/**
+	 * The proxies trusted for this request, the globally trusted proxies are
+	 * used when nil.
+	 *
+	 * @var []string
+	 */
+	trustedProxies []string
+
+	/**
+	 * The set of headers trusted from the proxies of this request.
+	 *
+	 * @var int
+	 */
+	trustedHeaderSet int
+
+	/**
+	 * The host patterns trusted for this request, the globally trusted host
+	 * patterns are used when nil.
+	 *
+	 * @var []*regexp.Regexp
+	 */
+	trustedHostPatterns []*regexp.Regexp
+
+	/**
+	 * The parsed Accept-* headers, most preferred first.
+	 *
+	 * @var []string
+	 */
+	acceptableContentTypes []string
+	languages              []string
+	charsets               []string
+	encodings              []string
 
 	Query      *HttpFoundation.ParameterBag
 	Post       *HttpFoundation.ParameterBag
 	Attributes *HttpFoundation.ParameterBag
+	Cookies    *HttpFoundation.ParameterBag
 	Headers    http.Header
 
+	/**
+	 * The raw body of the request, read on first use.
+	 *
+	 * @var []byte
+	 */
+	content []byte
+
+	/**
+	 * The decoded JSON body of the request.
+	 *
+	 * @var *HttpFoundation.InputBag
+	 */
+	json *HttpFoundation.InputBag
+
+	/**
+	 * The files uploaded with the request.
+	 *
+	 * @var map[string][]*UploadedFile
+	 */
+	files map[string][]*UploadedFile
+
+	/**
+	 * Indicates if the body of the request is larger than allowed.
+	 *
+	 * @var bool
+	 */
+	bodyTooLarge bool
+
+	/**
+	 * The jar of the cookies queued for the response to the request.
+	 *
+	 * @var CookieContract.QueueingFactory
+	 */
+	cookieJar CookieContract.QueueingFactory
+
+	/**
+	 * The route resolver callback.
+	 *
+	 * @var func() interface{}
+	 */
+	routeResolver func() interface{}
+
+	/**
+	 * The parameters bound to the matched route.
+	 *
+	 * @var map[string]interface{}
+	 */
+	routeParameters map[string]interface{}
+
+	/**
+	 * The parameters captured from the host of the matched route.
+	 *
+	 * @var map[string]string
+	 */
+	hostParameters map[string]string
+
 	context       context.Context
 	contextCancel context.CancelFunc
 }
@@ -37,17 +174,139 @@ func NewRequest(app Foundation.Application, response http.ResponseWriter, reques
 	this = &Request{App: app, request: request, response: response}
 	this.Query = HttpFoundation.NewParameterBag(request.URL.Query())
 
-	request.ParseMultipartForm(64 << 20)
+	this.parseBody()
 	this.Post = HttpFoundation.NewParameterBag(request.PostForm)
 	this.Headers = request.Header
 	this.Attributes = HttpFoundation.NewParameterBag(map[string][]string{})
+	this.Cookies = HttpFoundation.NewParameterBag(map[string][]string{})
+	for _, cookie := range request.Cookies() {
+		this.Cookies.Add(cookie.Name, cookie.Value)
+	}
+	this.routeParameters = map[string]interface{}{}
+	this.hostParameters = map[string]string{}
 
 	this.isHostValid = true
+	this.isForwardedValid = true
 
 	this.context, this.contextCancel = context.WithCancel(request.Context())
 	return this
 }
 
+/**
+ * Parse the form of the request, reading no more of the body than allowed.
+ *
+ * @return void
+ */
+func (this *Request) parseBody() {
+	if maxBodySize := this.configSize("http.max_body_size", DEFAULT_MAX_BODY_SIZE); maxBodySize > 0 && this.request.Body != nil {
+		// A body known to be too large is not read at all.
+		if this.request.ContentLength > maxBodySize {
+			this.bodyTooLarge = true
+			this.request.Body.Close()
+			this.request.Body = ioutil.NopCloser(bytes.NewReader(nil))
+			this.request.PostForm = url.Values{}
+			return
+		}
+		this.request.Body = &limitedBody{ReadCloser: this.request.Body, remaining: maxBodySize, exceeded: &this.bodyTooLarge}
+	}
+
+	this.request.ParseMultipartForm(this.configSize("http.max_memory", DEFAULT_MAX_MEMORY))
+
+	if this.request.PostForm == nil {
+		this.request.PostForm = url.Values{}
+	}
+}
+
+/**
+ * Determine if the body of the request is larger than allowed. A body of
+ * unknown length is only known to be too large once it has been read.
+ *
+ * @return bool
+ */
+func (this *Request) IsBodyTooLarge() bool {
+	return this.bodyTooLarge
+}
+
+/**
+ * Retrieve a cookie from the request.
+ *
+ * @param  string  key
+ * @param  string  default
+ * @return string
+ */
+func (this *Request) Cookie(key string, _default ...string) string {
+	return this.Cookies.GetFirst(key, _default...)
+}
+
+/**
+ * Determine if a cookie is set on the request.
+ *
+ * @param  string  key
+ * @return bool
+ */
+func (this *Request) HasCookie(key string) bool {
+	return this.Cookies.Has(key)
+}
+
+/**
+ * Get the jar of the cookies to send with the response to the request. Each
+ * request gets a jar of its own, so cookies queued while handling one request
+ * are never sent to another.
+ *
+ * @return CookieContract.QueueingFactory
+ */
+func (this *Request) CookieJar() CookieContract.QueueingFactory {
+	if this.cookieJar == nil && this.App != nil {
+		if newJar, ok := this.App.Make("cookie.jar").(func() CookieContract.QueueingFactory); ok {
+			this.cookieJar = newJar()
+		}
+	}
+
+	return this.cookieJar
+}
+
+/**
+ * Get a size in bytes from the configuration. Sizes may be given as numbers
+ * of bytes or as strings with a unit, e.g. "8M".
+ *
+ * @param  string  key
+ * @param  int64  default
+ * @return int64
+ */
+func (this *Request) configSize(key string, _default int64) int64 {
+	if this.App == nil {
+		return _default
+	}
+
+	config, ok := this.App.Make("config").(RepositoryContract.Repository)
+	if !ok {
+		return _default
+	}
+
+	switch size := config.Get(key).(type) {
+	case int:
+		return int64(size)
+	case int64:
+		return size
+	case float64:
+		return int64(size)
+	case string:
+		size = strings.ToUpper(strings.TrimSpace(size))
+		multiplier := int64(1)
+		for unit, bytes := range map[string]int64{"K": 1 << 10, "M": 1 << 20, "G": 1 << 30} {
+			if strings.HasSuffix(size, unit) {
+				size, multiplier = strings.TrimSuffix(size, unit), bytes
+				break
+			}
+		}
+		if value, err := strconv.ParseInt(size, 10, 64); err == nil {
+			return value * multiplier
+		}
+	}
+
+	return _default
+}
+
 // ----- implement context.Context interface ----- //
 func (this *Request) Deadline() (time.Time, bool) {
 	return this.context.Deadline()
@@ -100,6 +359,37 @@ func (this *Request) Method() string {
 	return this.GetMethod()
 }
 
+/**
+ * Checks whether the method is cacheable or not.
+ *
+ * @see https://tools.ietf.org/html/rfc7231#section-4.2.3
+ *
+ * @return bool
+ */
+func (this *Request) IsMethodCacheable() bool {
+	method := this.GetMethod()
+
+	return method == "GET" || method == "HEAD"
+}
+
+/**
+ * Gets the Etags.
+ *
+ * @return []string
+ */
+func (this *Request) GetETags() []string {
+	etags := []string{}
+	for _, value := range this.Headers["If-None-Match"] {
+		for _, etag := range strings.Split(value, ",") {
+			if etag = strings.TrimSpace(etag); etag != "" {
+				etags = append(etags, etag)
+			}
+		}
+	}
+
+	return etags
+}
+
 /**
  * Gets a "parameter" value from any bag.
  *
@@ -129,6 +419,14 @@ func (this *Request) Get(key string, _default ...string) string {
 		return this.Post.Get(key)
 	}
 
+	if this.IsJson() {
+		switch value := this.Json().Get(key).(type) {
+		case nil, map[string]interface{}, []interface{}:
+		default:
+			return inputString(value)
+		}
+	}
+
 	return _default[0]
 }
 
@@ -147,6 +445,20 @@ func (this *Request) Gets(key string, _default ...[]string) []string {
 		return this.Post.Gets(key)
 	}
 
+	if this.IsJson() {
+		switch value := this.Json().Get(key).(type) {
+		case nil, map[string]interface{}:
+		case []interface{}:
+			values := []string{}
+			for _, item := range value {
+				values = append(values, inputString(item))
+			}
+			return values
+		default:
+			return []string{inputString(value)}
+		}
+	}
+
 	return _default[0]
 }
 
@@ -186,36 +498,184 @@ func (this *Request) GetMethod() string {
 	return this.method
 }
 
+/**
+ * Returns the host name.
+ *
+ * This method can read the client host name from the "X-Forwarded-Host" or
+ * "Forwarded" header when the request comes from a trusted proxy.
+ *
+ * @return string
+ */
 func (this *Request) GetHost() string {
 	host := this.request.Host
+	if this.IsFromTrustedProxy() {
+		if hosts := this.getTrustedValues(HEADER_X_FORWARDED_HOST); len(hosts) > 0 {
+			host = hosts[0]
+		}
+	}
+
 	// trim and remove port number from host
 	// host is lowercase as per RFC 952/2181
-	host = regexp.MustCompile(`:\d+$`).ReplaceAllString(strings.TrimSpace(host), "")
+	host = strings.ToLower(strings.TrimSpace(host))
+	if strings.HasPrefix(host, "[") {
+		// an IPv6 literal keeps its brackets, only the port after them is removed
+		matches := ipv6HostPattern.FindStringSubmatch(host)
+		if matches == nil {
+			this.isHostValid = false
+			return ""
+		}
+		host = matches[1]
+	} else {
+		host = hostPortPattern.ReplaceAllString(host, "")
+	}
 	// as the host can come from the user (HTTP_HOST and depending on the configuration, SERVER_NAME too can come from the user)
 	// check that it does not contain forbidden characters (see RFC 952 and RFC 2181)
 	// use preg_replace() instead of preg_match() to prevent DoS attacks with long host names
 	if host != "" {
-		if host = regexp.MustCompile(`(?:^\[)?[a-zA-Z0-9-:\]_]+\.?`).ReplaceAllString(host, ""); host != "" {
-			if !this.isHostValid {
-				return ""
-			}
+		if hostCharactersPattern.ReplaceAllString(host, "") != "" {
+			// an invalid host never matches a domain route, it is reported
+			// through IsHostValid instead of aborting the whole request
 			this.isHostValid = false
-			panic(Errors.NewUnexpectedValueException(fmt.Sprintf(`Invalid Host "%s".`, host)))
+			return ""
 		}
 	}
 
+	// a host that matches none of the trusted host patterns is never handed out,
+	// so no link can be made from a forged Host header
+	if !this.isHostTrusted(host) {
+		this.isHostValid = false
+		return ""
+	}
+
 	return host
 }
 
+/**
+ * Determine if the host sent by the client is valid, and trusted when there
+ * are trusted host patterns.
+ *
+ * @return bool
+ */
+func (this *Request) IsHostValid() bool {
+	this.GetHost()
+	return this.isHostValid
+}
+
+/**
+ * Set the host patterns trusted for this request, overriding the global ones.
+ *
+ * @param  []*regexp.Regexp  patterns
+ * @return *Request
+ */
+func (this *Request) SetTrustedHosts(patterns []*regexp.Regexp) *Request {
+	this.trustedHostPatterns = append([]*regexp.Regexp{}, patterns...)
+	this.isHostValid = true
+
+	return this
+}
+
+/**
+ * Determine if the given host matches one of the trusted host patterns.
+ *
+ * @param  string  host
+ * @return bool
+ */
+func (this *Request) isHostTrusted(host string) bool {
+	patterns := this.trustedHostPatterns
+	if patterns == nil {
+		patterns = GetTrustedHosts()
+	}
+
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		if pattern.MatchString(host) {
+			return true
+		}
+	}
+
+	return false
+}
+
 /**
  * Determine if the request is over HTTPS.
  *
+ * This method can read the client protocol from the "X-Forwarded-Proto" or
+ * "Forwarded" header when the request comes from a trusted proxy.
+ *
  * @return bool
  */
 func (this *Request) Secure() bool {
+	if this.IsFromTrustedProxy() {
+		if protocols := this.getTrustedValues(HEADER_X_FORWARDED_PROTO); len(protocols) > 0 {
+			switch strings.ToLower(protocols[0]) {
+			case "https", "on", "ssl", "1":
+				return true
+			}
+			return false
+		}
+	}
+
 	return this.request.TLS != nil
 }
 
+/**
+ * Gets the request's scheme.
+ *
+ * @return string
+ */
+func (this *Request) Scheme() string {
+	if this.Secure() {
+		return "https"
+	}
+	return "http"
+}
+
+/**
+ * Returns the port on which the request is made.
+ *
+ * This method can read the client port from the "X-Forwarded-Port" or
+ * "Forwarded" header when the request comes from a trusted proxy.
+ *
+ * @return int
+ */
+func (this *Request) Port() int {
+	host := ""
+	if this.IsFromTrustedProxy() {
+		if ports := this.getTrustedValues(HEADER_X_FORWARDED_PORT); len(ports) > 0 {
+			host = ports[0]
+		} else if hosts := this.getTrustedValues(HEADER_X_FORWARDED_HOST); len(hosts) > 0 {
+			host = hosts[0]
+		}
+	}
+
+	if host == "" {
+		host = this.request.Host
+	}
+	if host == "" {
+		if addr, ok := this.request.Context().Value(http.LocalAddrContextKey).(net.Addr); ok {
+			host = addr.String()
+		}
+	}
+
+	pos := strings.LastIndex(host, ":")
+	if strings.HasPrefix(host, "[") && pos < strings.LastIndex(host, "]") {
+		pos = -1
+	}
+	if pos > -1 {
+		if port, err := strconv.Atoi(host[pos+1:]); err == nil {
+			return port
+		}
+	}
+
+	if this.Scheme() == "https" {
+		return 443
+	}
+	return 80
+}
+
 /**
  * Get the current path info for the request.
  *
@@ -228,6 +688,216 @@ func (this *Request) Path() string {
 	return "/"
 }
 
+/**
+ * Get the client IP address.
+ *
+ * @return string
+ */
+func (this *Request) Ip() string {
+	return this.Ips()[0]
+}
+
+/**
+ * Get the client IP addresses, the most trusted one first.
+ *
+ * Only the IP addresses found in the "X-Forwarded-For" or "Forwarded" header
+ * sent by trusted proxies are returned, the proxies themselves are left out.
+ *
+ * @return []string
+ */
+func (this *Request) Ips() []string {
+	ip := this.remoteAddr()
+
+	if !this.IsFromTrustedProxy() {
+		return []string{ip}
+	}
+
+	if ips := this.getTrustedValues(HEADER_X_FORWARDED_FOR, ip); len(ips) > 0 {
+		return ips
+	}
+
+	return []string{ip}
+}
+
+/**
+ * Set the proxies trusted for this request, overriding the global ones.
+ *
+ * @param  []string  proxies
+ * @param  int  headerSet
+ * @return *Request
+ */
+func (this *Request) SetTrustedProxies(proxies []string, headerSet int) *Request {
+	this.trustedProxies = append([]string{}, proxies...)
+	this.trustedHeaderSet = headerSet
+
+	return this
+}
+
+/**
+ * Indicates whether this request originated from a trusted proxy.
+ *
+ * @return bool
+ */
+func (this *Request) IsFromTrustedProxy() bool {
+	return this.isTrustedProxy(this.remoteAddr())
+}
+
+/**
+ * Determine if the given IP address belongs to a trusted proxy.
+ *
+ * @param  string  ip
+ * @return bool
+ */
+func (this *Request) isTrustedProxy(ip string) bool {
+	proxies := this.trustedProxies
+	if proxies == nil {
+		proxies = GetTrustedProxies()
+	}
+
+	trusted := []string{}
+	for _, proxy := range proxies {
+		// The peer the request came from is trusted, whoever it is.
+		if proxy == "*" || proxy == "REMOTE_ADDR" {
+			proxy = this.remoteAddr()
+		}
+		trusted = append(trusted, proxy)
+	}
+
+	return len(trusted) > 0 && HttpFoundation.CheckIp(ip, trusted)
+}
+
+/**
+ * Get the address of the peer that sent the request.
+ *
+ * @return string
+ */
+func (this *Request) remoteAddr() string {
+	if host, _, err := net.SplitHostPort(this.request.RemoteAddr); err == nil {
+		return host
+	}
+	return this.request.RemoteAddr
+}
+
+/**
+ * Get the values of the trusted header of the given type, from the
+ * "X-Forwarded-*" header and the "Forwarded" header when both are trusted.
+ *
+ * @param  int  _type
+ * @param  string  ip
+ * @return []string
+ *
+ * @throws Errors.ConflictingHeadersException
+ */
+func (this *Request) getTrustedValues(_type int, ip ...string) []string {
+	headerSet := this.trustedHeaderSet
+	if this.trustedProxies == nil {
+		headerSet = GetTrustedHeaderSet()
+	}
+
+	clientValues, forwardedValues := []string{}, []string{}
+
+	if headerSet&_type != 0 {
+		if header := strings.Join(this.Headers[trustedHeaders[_type]], ","); header != "" {
+			for _, value := range strings.Split(header, ",") {
+				if _type == HEADER_X_FORWARDED_PORT {
+					value = "0.0.0.0:" + strings.TrimSpace(value)
+				}
+				clientValues = append(clientValues, strings.TrimSpace(value))
+			}
+		}
+	}
+
+	if param, ok := forwardedParams[_type]; ok && headerSet&HEADER_FORWARDED != 0 {
+		for _, params := range HttpFoundation.ParseForwarded(strings.Join(this.Headers[trustedHeaders[HEADER_FORWARDED]], ",")) {
+			value, ok := params[param]
+			if !ok {
+				continue
+			}
+			if _type == HEADER_X_FORWARDED_PORT {
+				if strings.HasSuffix(value, "]") || !strings.Contains(value, ":") {
+					value = ":80"
+					if this.Secure() {
+						value = ":443"
+					}
+				} else {
+					value = value[strings.LastIndex(value, ":"):]
+				}
+				value = "0.0.0.0" + value
+			}
+			forwardedValues = append(forwardedValues, value)
+		}
+	}
+
+	if len(ip) > 0 {
+		clientValues = this.normalizeAndFilterClientIps(clientValues, ip[0])
+		forwardedValues = this.normalizeAndFilterClientIps(forwardedValues, ip[0])
+	}
+
+	if len(clientValues) == 0 || strings.Join(forwardedValues, ",") == strings.Join(clientValues, ",") {
+		return forwardedValues
+	}
+
+	if len(forwardedValues) == 0 {
+		return clientValues
+	}
+
+	if !this.isForwardedValid {
+		if len(ip) > 0 {
+			return []string{"0.0.0.0", ip[0]}
+		}
+		return []string{}
+	}
+	this.isForwardedValid = false
+
+	panic(Errors.NewConflictingHeadersException(fmt.Sprintf(`The request has both a trusted "%s" header and a trusted "%s" header, conflicting with each other. You should either configure your proxy to remove one of them, or configure your project to distrust the offending one.`, trustedHeaders[HEADER_FORWARDED], trustedHeaders[_type]), nil))
+}
+
+/**
+ * Complete the chain of client IPs with the IP the request came from, drop
+ * the invalid and trusted ones, and put the nearest client first.
+ *
+ * @param  []string  clientIps
+ * @param  string  ip
+ * @return []string
+ */
+func (this *Request) normalizeAndFilterClientIps(clientIps []string, ip string) []string {
+	if len(clientIps) == 0 {
+		return clientIps
+	}
+
+	firstTrustedIp, filtered := "", []string{}
+	for _, clientIp := range append(clientIps, ip) {
+		if strings.Contains(clientIp, ".") && strings.Count(clientIp, ":") == 1 {
+			// IPv4 with a port
+			clientIp = clientIp[:strings.Index(clientIp, ":")]
+		} else if strings.HasPrefix(clientIp, "[") {
+			// IPv6 with a port
+			if i := strings.Index(clientIp, "]"); i > -1 {
+				clientIp = clientIp[1:i]
+			}
+		}
+
+		if net.ParseIP(clientIp) == nil {
+			continue
+		}
+
+		if this.isTrustedProxy(clientIp) {
+			if firstTrustedIp == "" {
+				firstTrustedIp = clientIp
+			}
+			continue
+		}
+
+		filtered = append([]string{clientIp}, filtered...)
+	}
+
+	if len(filtered) == 0 && firstTrustedIp != "" {
+		return []string{firstTrustedIp}
+	}
+
+	return filtered
+}
+
 /**
  * Get the client user agent.
  *
@@ -237,6 +907,55 @@ func (this *Request) UserAgent() string {
 	return this.Headers.Get("User-Agent")
 }
 
+/**
+ * Get the raw body of the request. The body is read once and put back, so
+ * it can still be read from the underlying request.
+ *
+ * @return []byte
+ */
+func (this *Request) GetContent() []byte {
+	if this.content == nil {
+		this.content = []byte{}
+		if this.request.Body != nil {
+			if content, err := ioutil.ReadAll(this.request.Body); err == nil {
+				this.content = content
+			}
+			this.request.Body.Close()
+		}
+		this.request.Body = ioutil.NopCloser(bytes.NewReader(this.content))
+	}
+
+	return this.content
+}
+
+/**
+ * Get the decoded JSON body of the request. A body that is not a JSON object
+ * or list gives an empty bag.
+ *
+ * @return *HttpFoundation.InputBag
+ */
+func (this *Request) Json() *HttpFoundation.InputBag {
+	if this.json == nil {
+		var body interface{}
+		json.Unmarshal(this.GetContent(), &body)
+
+		switch _body := body.(type) {
+		case map[string]interface{}:
+			this.json = HttpFoundation.NewInputBag(_body)
+		case []interface{}:
+			items := map[string]interface{}{}
+			for i, item := range _body {
+				items[strconv.Itoa(i)] = item
+			}
+			this.json = HttpFoundation.NewInputBag(items)
+		default:
+			this.json = HttpFoundation.NewInputBag(nil)
+		}
+	}
+
+	return this.json
+}
+
 /**
  * Determine if the request is sending JSON.
  *
@@ -245,3 +964,162 @@ func (this *Request) UserAgent() string {
 func (this *Request) IsJson() bool {
 	return Support.Str().Contains(this.Headers.Get("Content-Type"), []string{"/json", "+json"})
 }
+
+/**
+ * Get the route handling the request, or a parameter of that route.
+ *
+ * @param  string  param
+ * @return interface{}
+ */
+func (this *Request) Route(param ...string) interface{} {
+	if len(param) > 0 {
+		if value, ok := this.routeParameters[param[0]]; ok {
+			return value
+		}
+		return nil
+	}
+
+	if this.routeResolver == nil {
+		return nil
+	}
+
+	return this.routeResolver()
+}
+
+/**
+ * Get the route resolver callback.
+ *
+ * @return func() interface{}
+ */
+func (this *Request) GetRouteResolver() func() interface{} {
+	if this.routeResolver == nil {
+		return func() interface{} {
+			return nil
+		}
+	}
+	return this.routeResolver
+}
+
+/**
+ * Set the route resolver callback.
+ *
+ * @param  func() interface{}  callback
+ * @return this
+ */
+func (this *Request) SetRouteResolver(callback func() interface{}) *Request {
+	this.routeResolver = callback
+
+	return this
+}
+
+/**
+ * Get the parameters bound to the matched route.
+ *
+ * @return map[string]interface{}
+ */
+func (this *Request) RouteParameters() map[string]interface{} {
+	return this.routeParameters
+}
+
+/**
+ * Determine if the matched route has a given parameter.
+ *
+ * @param  string  key
+ * @return bool
+ */
+func (this *Request) HasRouteParameter(key string) bool {
+	_, ok := this.routeParameters[key]
+	return ok
+}
+
+/**
+ * Set a parameter on the matched route.
+ *
+ * @param  string  key
+ * @param  interface{}  value
+ * @return void
+ */
+func (this *Request) SetRouteParameter(key string, value interface{}) {
+	this.routeParameters[key] = value
+}
+
+/**
+ * Get the route parameters captured from the path of the request.
+ *
+ * @return map[string]interface{}
+ */
+func (this *Request) PathParameters() map[string]interface{} {
+	parameters := map[string]interface{}{}
+	for key, value := range this.routeParameters {
+		if _, ok := this.hostParameters[key]; !ok {
+			parameters[key] = value
+		}
+	}
+	return parameters
+}
+
+/**
+ * Get the route parameters captured from the host of the request.
+ *
+ * @return map[string]string
+ */
+func (this *Request) HostParameters() map[string]string {
+	return this.hostParameters
+}
+
+/**
+ * Get a route parameter captured from the host of the request.
+ *
+ * @param  string  key
+ * @param  string  _default
+ * @return string
+ */
+func (this *Request) HostParameter(key string, _default ...string) string {
+	_default = append(_default, "")
+	if value, ok := this.hostParameters[key]; ok {
+		return value
+	}
+	return _default[0]
+}
+
+/**
+ * Set a parameter captured from the host of the request.
+ *
+ * @param  string  key
+ * @param  string  value
+ * @return void
+ */
+func (this *Request) SetHostParameter(key string, value string) {
+	this.hostParameters[key] = value
+}
+
+/**
+ * A request body that stops, and tells so, once more than the allowed number
+ * of bytes is read.
+ */
+type limitedBody struct {
+	io.ReadCloser
+
+	remaining int64
+	exceeded  *bool
+}
+
+func (this *limitedBody) Read(p []byte) (int, error) {
+	if this.remaining < 0 {
+		return 0, errors.New("http: request body too large")
+	}
+	if int64(len(p)) > this.remaining+1 {
+		p = p[:this.remaining+1]
+	}
+
+	n, err := this.ReadCloser.Read(p)
+	if int64(n) <= this.remaining {
+		this.remaining -= int64(n)
+		return n, err
+	}
+
+	n, this.remaining = int(this.remaining), -1
+	*this.exceeded = true
+
+	return n, errors.New("http: request body too large")
+}
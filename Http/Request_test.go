@@ -0,0 +1,64 @@
+package Http
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func newHostRequest(host string) *Request {
+	request := httptest.NewRequest("GET", "/", nil)
+	request.Host = host
+
+	return NewRequest(nil, httptest.NewRecorder(), request)
+}
+
+func TestGetHost(t *testing.T) {
+	for host, expected := range map[string]string{
+		"example.com":           "example.com",
+		"Acme.Example.com":      "acme.example.com",
+		"acme.example.com:8080": "acme.example.com",
+		"example.com:":          "example.com",
+		"127.0.0.1:8000":        "127.0.0.1",
+		"[::1]":                 "[::1]",
+		"[::1]:8080":            "[::1]",
+		"[2001:DB8::1]:443":     "[2001:db8::1]",
+		"my_host.example.com":   "my_host.example.com",
+		"example.com.":          "example.com.",
+		"":                      "",
+	} {
+		request := newHostRequest(host)
+		if actual := request.GetHost(); actual != expected {
+			t.Errorf("GetHost() of %q = %q, expected %q", host, actual, expected)
+		}
+		if !request.IsHostValid() {
+			t.Errorf("expected %q to be a valid host", host)
+		}
+	}
+}
+
+func TestGetHostOfInvalidHostIsEmpty(t *testing.T) {
+	for _, host := range []string{"exa mple.com", "example.com/evil", "evil.com?.example.com", "[::1", "[::1]x", "[example.com]"} {
+		request := newHostRequest(host)
+		if actual := request.GetHost(); actual != "" {
+			t.Errorf("GetHost() of %q = %q, expected an empty host", host, actual)
+		}
+		if request.IsHostValid() {
+			t.Errorf("expected %q to be an invalid host", host)
+		}
+	}
+}
+
+func TestMalformedAcceptHeaderDoesNotPanic(t *testing.T) {
+	for accept, json := range map[string]bool{
+		"text/html, ;":           false,
+		";q=1, application/json": true,
+		";":                      false,
+	} {
+		request := newHostRequest("example.com")
+		request.Headers.Set("Accept", accept)
+
+		if request.ExpectsJson() != json || request.WantsJson() != json {
+			t.Errorf("expected ExpectsJson() and WantsJson() of %q to be %v", accept, json)
+		}
+	}
+}
@@ -1,11 +1,14 @@
 package Http
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/larisgo/framework/Errors"
 	"github.com/larisgo/framework/Support"
 	"net/http"
+	"reflect"
+	"time"
 )
 
 const (
@@ -111,6 +114,41 @@ type Response struct {
 	 * @var string
 	 */
 	charset string
+
+	/**
+	 * The cookies to send with the response.
+	 *
+	 * @var []*http.Cookie
+	 */
+	cookies []*http.Cookie
+
+	/**
+	 * The callback writing the content of a streamed response.
+	 *
+	 * @var func(*StreamWriter)
+	 */
+	callback func(*StreamWriter)
+
+	/**
+	 * The request the response is sent for.
+	 *
+	 * @var *http.Request
+	 */
+	request *http.Request
+
+	/**
+	 * The file sent as the content of a binary file response.
+	 *
+	 * @var string
+	 */
+	file string
+
+	/**
+	 * Whether the file is deleted once it has been sent.
+	 *
+	 * @var bool
+	 */
+	deleteFileAfterSend bool
 }
 
 func NewResponse(content interface{}, status int, headers ...map[string][]string) (this *Response) {
@@ -125,6 +163,7 @@ func NewResponse(content interface{}, status int, headers ...map[string][]string
 
 func (this *Response) Prepare(request *Request) *Response {
 	this.response = request.Response()
+	this.request = request.Request()
 
 	return this
 }
@@ -136,6 +175,8 @@ func (this *Response) Prepare(request *Request) *Response {
  * @return $this
  */
 func (this *Response) SetContent(content interface{}) *Response {
+	this.callback = nil
+	this.file = ""
 	this.Header("Content-Type", "text/html; charset=UTF-8")
 	// If the content is "JSONable" we will set the appropriate header and convert
 	// the content to JSON. This is useful when returning something like models
@@ -171,8 +212,19 @@ func (this *Response) shouldBeJson(content interface{}) bool {
 	if _, ok := content.(Support.Jsonable); ok {
 		return ok
 	}
-	if _, ok := content.(map[string]interface{}); ok {
-		return ok
+
+	// Maps, slices and structs are turned into JSON, with the exception of the
+	// raw bytes of the content.
+	if _, ok := content.([]byte); ok || content == nil {
+		return false
+	}
+	Type := reflect.TypeOf(content)
+	if Type.Kind() == reflect.Ptr {
+		Type = Type.Elem()
+	}
+	switch Type.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array, reflect.Struct:
+		return true
 	}
 	return false
 }
@@ -230,6 +282,53 @@ func (this *Response) Header(key string, value string, replace ...bool) *Respons
 	return this
 }
 
+/**
+ * Add a cookie to the response. A cookie with the same name, path and domain
+ * replaces the one added before.
+ *
+ * @param  *http.Cookie  cookie
+ * @return $this
+ */
+func (this *Response) WithCookie(cookie *http.Cookie) *Response {
+	cookies := []*http.Cookie{}
+	for _, _cookie := range this.cookies {
+		if _cookie.Name != cookie.Name || _cookie.Path != cookie.Path || _cookie.Domain != cookie.Domain {
+			cookies = append(cookies, _cookie)
+		}
+	}
+	this.cookies = append(cookies, cookie)
+
+	return this
+}
+
+/**
+ * Expire a cookie when sending the response.
+ *
+ * @param  string  name
+ * @param  string  path
+ * @return $this
+ */
+func (this *Response) WithoutCookie(name string, path ...string) *Response {
+	path = append(path, "/")
+
+	return this.WithCookie(&http.Cookie{
+		Name:     name,
+		Path:     path[0],
+		Expires:  time.Unix(1, 0),
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+}
+
+/**
+ * Get the cookies to send with the response.
+ *
+ * @return []*http.Cookie
+ */
+func (this *Response) GetCookies() []*http.Cookie {
+	return this.cookies
+}
+
 /**
  * Get the status code for the response.
  *
@@ -268,6 +367,11 @@ func (this *Response) SendHeaders() *Response {
 			this.response.Header().Add(key, value)
 		}
 	}
+	for _, cookie := range this.cookies {
+		if value := cookie.String(); value != "" {
+			this.response.Header().Add("Set-Cookie", value)
+		}
+	}
 	return this
 }
 
@@ -277,7 +381,21 @@ func (this *Response) SendHeaders() *Response {
  * @return $this
  */
 func (this *Response) SendContent() *Response {
+	if this.file != "" {
+		return this.sendFile()
+	}
+
 	this.response.WriteHeader(this.statusCode)
+
+	if this.callback != nil {
+		ctx := context.Background()
+		if this.request != nil {
+			ctx = this.request.Context()
+		}
+		this.callback(NewStreamWriter(this.response, ctx))
+		return this
+	}
+
 	this.response.Write(this.content)
 
 	return this
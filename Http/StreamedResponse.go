@@ -0,0 +1,136 @@
+package Http
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+/**
+ * The writer a streamed response writes its content with. Each write is
+ * flushed to the client, and fails once the client has gone away.
+ */
+type StreamWriter struct {
+	writer  io.Writer
+	flusher http.Flusher
+	context context.Context
+}
+
+/**
+ * Create a new stream writer.
+ *
+ * @param  http.ResponseWriter  writer
+ * @param  context.Context  ctx
+ * @return *StreamWriter
+ */
+func NewStreamWriter(writer http.ResponseWriter, ctx context.Context) (this *StreamWriter) {
+	this = &StreamWriter{}
+	this.writer = writer
+	this.flusher, _ = writer.(http.Flusher)
+	this.context = ctx
+	return this
+}
+
+/**
+ * Write a chunk of the content and flush it to the client.
+ *
+ * @param  []byte  p
+ * @return int, error
+ */
+func (this *StreamWriter) Write(p []byte) (int, error) {
+	if err := this.context.Err(); err != nil {
+		return 0, err
+	}
+
+	n, err := this.writer.Write(p)
+	if err == nil {
+		this.Flush()
+	}
+
+	return n, err
+}
+
+/**
+ * Write a chunk of the content given as a string.
+ *
+ * @param  string  s
+ * @return int, error
+ */
+func (this *StreamWriter) WriteString(s string) (int, error) {
+	return this.Write([]byte(s))
+}
+
+/**
+ * Flush the content written so far to the client.
+ *
+ * @return void
+ */
+func (this *StreamWriter) Flush() {
+	if this.flusher != nil {
+		this.flusher.Flush()
+	}
+}
+
+/**
+ * Get the context of the request, which is done when the client disconnects.
+ *
+ * @return context.Context
+ */
+func (this *StreamWriter) Context() context.Context {
+	return this.context
+}
+
+/**
+ * Get a channel that is closed when the client disconnects.
+ *
+ * @return <-chan struct{}
+ */
+func (this *StreamWriter) Done() <-chan struct{} {
+	return this.context.Done()
+}
+
+/**
+ * Creates a response whose content is written by the given callback while
+ * it is sent, instead of being buffered beforehand.
+ *
+ * @param  func(*StreamWriter)  callback
+ * @param  int  status
+ * @param  map[string][]string  headers
+ * @return *Response
+ */
+func NewStreamedResponse(callback func(*StreamWriter), status int, headers ...map[string][]string) (this *Response) {
+	headers = append(headers, map[string][]string{})
+
+	// The content type given with the headers is kept, the empty content would
+	// otherwise make it HTML.
+	contentType := http.Header(headers[0]).Get("Content-Type")
+
+	this = NewResponse("", status, headers[0])
+	if contentType != "" {
+		this.Header("Content-Type", contentType)
+	}
+	this.SetCallback(callback)
+	return this
+}
+
+/**
+ * Sets the callback writing the content of the response.
+ *
+ * @param  func(*StreamWriter)  callback
+ * @return $this
+ */
+func (this *Response) SetCallback(callback func(*StreamWriter)) *Response {
+	this.callback = callback
+	this.file = ""
+
+	return this
+}
+
+/**
+ * Determine if the content of the response is streamed.
+ *
+ * @return bool
+ */
+func (this *Response) IsStreamed() bool {
+	return this.callback != nil
+}
@@ -2,6 +2,10 @@ package Http
 
 import (
 	"mime/multipart"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
 )
 
 const HEADER_FORWARDED = 0x1 // When using RFC 7239
@@ -29,9 +33,9 @@ const METHOD_CONNECT = "CONNECT"
 var trustedProxies []string
 
 /**
- * @var string[]
+ * @var []*regexp.Regexp
  */
-var trustedHostPatterns []string
+var trustedHostPatterns []*regexp.Regexp
 
 /**
  * @var string[]
@@ -41,9 +45,25 @@ var trustedHosts []string
 var httpMethodParameterOverride bool = false
 
 /**
- * @var array
+ * The mime types of each request format.
+ *
+ * @var map[string][]string
  */
-var formats []string
+var formats map[string][]string = map[string][]string{
+	"html":   {"text/html", "application/xhtml+xml"},
+	"txt":    {"text/plain"},
+	"js":     {"application/javascript", "application/x-javascript", "text/javascript"},
+	"css":    {"text/css"},
+	"json":   {"application/json", "application/x-json"},
+	"jsonld": {"application/ld+json"},
+	"xml":    {"text/xml", "application/xml", "application/x-xml"},
+	"rdf":    {"application/rdf+xml"},
+	"atom":   {"application/atom+xml"},
+	"rss":    {"application/rss+xml"},
+	"form":   {"application/x-www-form-urlencoded", "multipart/form-data"},
+}
+
+var formatsLock sync.RWMutex
 
 // var requestFactory;
 
@@ -66,11 +86,160 @@ var forwardedParams map[int]string = map[int]string{
  * by popular reverse proxies (like Apache mod_proxy or Amazon EC2).
  */
 var trustedHeaders map[int]string = map[int]string{
-	HEADER_FORWARDED:         "FORWARDED",
-	HEADER_X_FORWARDED_FOR:   "X_FORWARDED_FOR",
-	HEADER_X_FORWARDED_HOST:  "X_FORWARDED_HOST",
-	HEADER_X_FORWARDED_PROTO: "X_FORWARDED_PROTO",
-	HEADER_X_FORWARDED_PORT:  "X_FORWARDED_PORT",
+	HEADER_FORWARDED:         "Forwarded",
+	HEADER_X_FORWARDED_FOR:   "X-Forwarded-For",
+	HEADER_X_FORWARDED_HOST:  "X-Forwarded-Host",
+	HEADER_X_FORWARDED_PROTO: "X-Forwarded-Proto",
+	HEADER_X_FORWARDED_PORT:  "X-Forwarded-Port",
+}
+
+var trustedProxiesLock, trustedHostsLock sync.RWMutex
+
+/**
+ * Sets a list of trusted proxies, as IPs or CIDR notations, used for every
+ * request. "REMOTE_ADDR" and "*" trust whichever peer sent the request.
+ *
+ * You should only list the reverse proxies that you manage directly.
+ *
+ * @param  []string  proxies           A list of trusted proxies
+ * @param  int       trustedHeaderSet  A bit field of HEADER_*, to set which headers to trust from your proxies
+ * @return void
+ */
+func SetTrustedProxies(proxies []string, headerSet int) {
+	trustedProxiesLock.Lock()
+	defer trustedProxiesLock.Unlock()
+
+	trustedProxies = append([]string{}, proxies...)
+	trustedHeaderSet = headerSet
+}
+
+/**
+ * Gets the list of trusted proxies.
+ *
+ * @return []string
+ */
+func GetTrustedProxies() []string {
+	trustedProxiesLock.RLock()
+	defer trustedProxiesLock.RUnlock()
+
+	return trustedProxies
+}
+
+/**
+ * Gets the set of trusted headers from trusted proxies.
+ *
+ * @return int A bit field of HEADER_* that defines which headers are trusted from your proxies
+ */
+func GetTrustedHeaderSet() int {
+	trustedProxiesLock.RLock()
+	defer trustedProxiesLock.RUnlock()
+
+	return trustedHeaderSet
+}
+
+/**
+ * Associates a format with mime types.
+ *
+ * @param  string  format
+ * @param  []string  mimeTypes  The associated mime types (the preferred one must be the first as it will be used as the content type)
+ * @return void
+ */
+func SetFormat(format string, mimeTypes ...string) {
+	formatsLock.Lock()
+	defer formatsLock.Unlock()
+
+	formats[format] = mimeTypes
+}
+
+/**
+ * Gets the mime type associated with the format.
+ *
+ * @param  string  format
+ * @return string
+ */
+func GetMimeType(format string) string {
+	formatsLock.RLock()
+	defer formatsLock.RUnlock()
+
+	if mimeTypes := formats[format]; len(mimeTypes) > 0 {
+		return mimeTypes[0]
+	}
+	return ""
+}
+
+/**
+ * Gets the format associated with the mime type.
+ *
+ * @param  string  mimeType
+ * @return string
+ */
+func GetFormat(mimeType string) string {
+	if i := strings.Index(mimeType, ";"); i > -1 {
+		mimeType = mimeType[:i]
+	}
+	mimeType = strings.ToLower(strings.TrimSpace(mimeType))
+
+	formatsLock.RLock()
+	defer formatsLock.RUnlock()
+
+	// The formats are tried in order, so the same mime type always gives the
+	// same format.
+	names := make([]string, 0, len(formats))
+	for format := range formats {
+		names = append(names, format)
+	}
+	sort.Strings(names)
+
+	for _, format := range names {
+		for _, _mimeType := range formats[format] {
+			if _mimeType == mimeType {
+				return format
+			}
+		}
+	}
+	return ""
+}
+
+/**
+ * Sets a list of trusted host patterns used for every request.
+ *
+ * You should only list the hosts you manage using regexs.
+ *
+ * @param  []string  hostPatterns  A list of trusted host patterns
+ * @return void
+ */
+func SetTrustedHosts(hostPatterns []string) {
+	trustedHostsLock.Lock()
+	defer trustedHostsLock.Unlock()
+
+	trustedHostPatterns = CompileTrustedHosts(hostPatterns)
+}
+
+/**
+ * Gets the list of trusted host patterns.
+ *
+ * @return []*regexp.Regexp
+ */
+func GetTrustedHosts() []*regexp.Regexp {
+	trustedHostsLock.RLock()
+	defer trustedHostsLock.RUnlock()
+
+	return trustedHostPatterns
+}
+
+/**
+ * Compile trusted host patterns, which match hosts case-insensitively.
+ *
+ * @param  []string  hostPatterns
+ * @return []*regexp.Regexp
+ */
+func CompileTrustedHosts(hostPatterns []string) []*regexp.Regexp {
+	patterns := []*regexp.Regexp{}
+	for _, hostPattern := range hostPatterns {
+		patterns = append(patterns, regexp.MustCompile("(?i)"+hostPattern))
+	}
+
+	return patterns
 }
 
 type SymfonyRequest struct {
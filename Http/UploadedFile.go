@@ -0,0 +1,311 @@
+package Http
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	FilesystemContract "github.com/larisgo/framework/Contracts/Filesystem"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Errors"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+/**
+ * The extensions preferred for the common MIME types, the system MIME table
+ * being used for the others.
+ *
+ * @var map[string]string
+ */
+var mimeExtensions = map[string]string{
+	"application/json":             "json",
+	"application/octet-stream":     "bin",
+	"application/ogg":              "ogg",
+	"application/pdf":              "pdf",
+	"application/postscript":       "ps",
+	"application/wasm":             "wasm",
+	"application/x-gzip":           "gz",
+	"application/x-rar-compressed": "rar",
+	"application/zip":              "zip",
+	"audio/aiff":                   "aiff",
+	"audio/basic":                  "au",
+	"audio/midi":                   "mid",
+	"audio/mpeg":                   "mp3",
+	"audio/wave":                   "wav",
+	"font/otf":                     "otf",
+	"font/ttf":                     "ttf",
+	"font/woff":                    "woff",
+	"font/woff2":                   "woff2",
+	"image/bmp":                    "bmp",
+	"image/gif":                    "gif",
+	"image/jpeg":                   "jpg",
+	"image/png":                    "png",
+	"image/svg+xml":                "svg",
+	"image/webp":                   "webp",
+	"image/x-icon":                 "ico",
+	"text/css":                     "css",
+	"text/csv":                     "csv",
+	"text/html":                    "html",
+	"text/javascript":              "js",
+	"text/plain":                   "txt",
+	"text/xml":                     "xml",
+	"video/avi":                    "avi",
+	"video/mp4":                    "mp4",
+	"video/webm":                   "webm",
+}
+
+type UploadedFile struct {
+	/**
+	 * The application instance, used to reach the storage disks.
+	 *
+	 * @var Foundation.Application
+	 */
+	app Foundation.Application
+
+	/**
+	 * The multipart header of the file.
+	 *
+	 * @var *multipart.FileHeader
+	 */
+	header *multipart.FileHeader
+
+	/**
+	 * The MIME type detected from the contents of the file.
+	 *
+	 * @var string
+	 */
+	mimeType string
+
+	/**
+	 * The error of the upload, empty when the upload is valid.
+	 *
+	 * @var string
+	 */
+	err string
+}
+
+/**
+ * Create a new uploaded file instance. A file larger than the given maximum
+ * size is invalid, a maximum of zero allowing any size.
+ *
+ * @param  Foundation.Application  app
+ * @param  *multipart.FileHeader  header
+ * @param  int64  maxSize
+ * @return *UploadedFile
+ */
+func NewUploadedFile(app Foundation.Application, header *multipart.FileHeader, maxSize int64) (this *UploadedFile) {
+	this = &UploadedFile{}
+	this.app = app
+	this.header = header
+	if maxSize > 0 && header.Size > maxSize {
+		this.err = fmt.Sprintf("The file \"%s\" exceeds the maximum upload size of %d bytes.", this.GetClientOriginalName(), maxSize)
+	}
+	return this
+}
+
+/**
+ * Returns the original file name, as sent by the client.
+ *
+ * @return string
+ */
+func (this *UploadedFile) GetClientOriginalName() string {
+	return filepath.Base(strings.Replace(this.header.Filename, `\`, "/", -1))
+}
+
+/**
+ * Returns the original file extension, as sent by the client.
+ *
+ * @return string
+ */
+func (this *UploadedFile) GetClientOriginalExtension() string {
+	return strings.TrimPrefix(path.Ext(this.GetClientOriginalName()), ".")
+}
+
+/**
+ * Returns the file MIME type, as sent by the client. It should not be
+ * trusted, use GetMimeType for a type detected from the contents.
+ *
+ * @return string
+ */
+func (this *UploadedFile) GetClientMimeType() string {
+	if mimeType := this.header.Header.Get("Content-Type"); mimeType != "" {
+		return mimeType
+	}
+	return "application/octet-stream"
+}
+
+/**
+ * Returns the file size in bytes.
+ *
+ * @return int64
+ */
+func (this *UploadedFile) GetSize() int64 {
+	return this.header.Size
+}
+
+/**
+ * Returns the MIME type detected from the contents of the file.
+ *
+ * @return string
+ */
+func (this *UploadedFile) GetMimeType() string {
+	if this.mimeType == "" {
+		this.mimeType = "application/octet-stream"
+
+		if file, err := this.header.Open(); err == nil {
+			defer file.Close()
+
+			buffer := make([]byte, 512)
+			n, _ := io.ReadFull(file, buffer)
+			if mediaType, _, err := mime.ParseMediaType(http.DetectContentType(buffer[:n])); err == nil {
+				this.mimeType = mediaType
+			}
+		}
+	}
+
+	return this.mimeType
+}
+
+/**
+ * Get the file's extension, guessed from the detected MIME type.
+ *
+ * @return string
+ */
+func (this *UploadedFile) Extension() string {
+	return this.GuessExtension()
+}
+
+/**
+ * Returns the extension based on the detected MIME type, empty when it is
+ * unknown.
+ *
+ * @return string
+ */
+func (this *UploadedFile) GuessExtension() string {
+	mimeType := this.GetMimeType()
+
+	if extension, ok := mimeExtensions[mimeType]; ok {
+		return extension
+	}
+	if extensions, err := mime.ExtensionsByType(mimeType); err == nil && len(extensions) > 0 {
+		return strings.TrimPrefix(extensions[0], ".")
+	}
+	return ""
+}
+
+/**
+ * Returns whether the file was uploaded successfully.
+ *
+ * @return bool
+ */
+func (this *UploadedFile) IsValid() bool {
+	return this.err == ""
+}
+
+/**
+ * Returns an informative upload error message.
+ *
+ * @return string
+ */
+func (this *UploadedFile) GetErrorMessage() string {
+	return this.err
+}
+
+/**
+ * Open the uploaded file for reading.
+ *
+ * @return multipart.File, error
+ */
+func (this *UploadedFile) Open() (multipart.File, error) {
+	return this.header.Open()
+}
+
+/**
+ * Get the contents of the uploaded file.
+ *
+ * @return []byte, error
+ */
+func (this *UploadedFile) Get() ([]byte, error) {
+	file, err := this.header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ioutil.ReadAll(file)
+}
+
+/**
+ * Get a filename for the file, random and with the guessed extension.
+ *
+ * @param  string  path
+ * @return string
+ */
+func (this *UploadedFile) HashName(_path ...string) string {
+	_path = append(_path, "")
+
+	random := make([]byte, 20)
+	rand.Read(random)
+	name := hex.EncodeToString(random)
+
+	if extension := this.GuessExtension(); extension != "" {
+		name += "." + extension
+	}
+
+	if _path[0] != "" {
+		return strings.TrimRight(_path[0], "/") + "/" + name
+	}
+	return name
+}
+
+/**
+ * Store the uploaded file on a filesystem disk, under a random name.
+ *
+ * @param  string  path
+ * @param  string  disk
+ * @return string, error
+ */
+func (this *UploadedFile) Store(_path string, disk ...string) (string, error) {
+	return this.StoreAs(_path, this.HashName(), disk...)
+}
+
+/**
+ * Store the uploaded file on a filesystem disk, under the given name.
+ *
+ * @param  string  path
+ * @param  string  name
+ * @param  string  disk
+ * @return string, error
+ */
+func (this *UploadedFile) StoreAs(_path string, name string, disk ...string) (string, error) {
+	if !this.IsValid() {
+		return "", Errors.NewRuntimeException(this.err)
+	}
+
+	var filesystem FilesystemContract.Factory
+	if this.app != nil {
+		filesystem, _ = this.app.Make("filesystem").(FilesystemContract.Factory)
+	}
+	if filesystem == nil {
+		return "", Errors.NewRuntimeException("No filesystem is bound to store the uploaded file.")
+	}
+
+	file, err := this.header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	destination := strings.Trim(strings.TrimRight(_path, "/")+"/"+name, "/")
+	if err := filesystem.Disk(disk...).PutStream(destination, file); err != nil {
+		return "", err
+	}
+
+	return destination, nil
+}
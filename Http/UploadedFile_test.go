@@ -0,0 +1,90 @@
+package Http
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+var pngContent = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 32)...)
+
+func newUploadRequest(t *testing.T, filename string, content []byte) *Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	writer.WriteField("name", "taylor")
+	part, err := writer.CreateFormFile("avatar", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	writer.Close()
+
+	request := httptest.NewRequest("POST", "/", body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return NewRequest(nil, httptest.NewRecorder(), request)
+}
+
+func TestUploadedFileOfMultipartRequest(t *testing.T) {
+	request := newUploadRequest(t, `C:\fakepath\me.jpeg`, pngContent)
+
+	if !request.HasFile("avatar") || request.HasFile("name") || request.File("missing") != nil {
+		t.Fatal("unexpected files")
+	}
+	if name := request.Input("name"); name != "taylor" {
+		t.Errorf("expected the form field next to the file, got %v", name)
+	}
+
+	file := request.File("avatar")
+	if name := file.GetClientOriginalName(); name != "me.jpeg" {
+		t.Errorf("expected the client path to be dropped, got %q", name)
+	}
+	if extension := file.GetClientOriginalExtension(); extension != "jpeg" {
+		t.Errorf("unexpected client extension %q", extension)
+	}
+	if mimeType := file.GetMimeType(); mimeType != "image/png" {
+		t.Errorf("expected the type detected from the contents, got %q", mimeType)
+	}
+	if extension := file.GuessExtension(); extension != "png" {
+		t.Errorf("expected the png extension, got %q", extension)
+	}
+	if size := file.GetSize(); size != int64(len(pngContent)) {
+		t.Errorf("unexpected size %d", size)
+	}
+	if content, err := file.Get(); err != nil || !bytes.Equal(content, pngContent) {
+		t.Errorf("unexpected contents, %v", err)
+	}
+	if !file.IsValid() {
+		t.Errorf("unexpected error %s", file.GetErrorMessage())
+	}
+	if name := file.HashName("avatars/"); !regexp.MustCompile(`^avatars/[0-9a-f]{40}\.png$`).MatchString(name) {
+		t.Errorf("unexpected hash name %q", name)
+	}
+}
+
+func TestUploadedFileLargerThanTheMaximumIsInvalid(t *testing.T) {
+	request := newUploadRequest(t, "me.png", pngContent)
+	header := request.File("avatar").header
+
+	file := NewUploadedFile(nil, header, 8)
+	if file.IsValid() || file.GetErrorMessage() == "" {
+		t.Fatal("expected the file to be too large")
+	}
+	if _, err := file.StoreAs("avatars", "me.png"); err == nil {
+		t.Error("an invalid file was stored")
+	}
+
+	if !NewUploadedFile(nil, header, 0).IsValid() {
+		t.Error("a maximum of zero should allow any size")
+	}
+}
+
+func TestUploadedFileCannotBeStoredWithoutFilesystem(t *testing.T) {
+	file := newUploadRequest(t, "me.png", pngContent).File("avatar")
+
+	if _, err := file.Store("avatars"); err == nil {
+		t.Error("expected an error without a filesystem")
+	}
+}
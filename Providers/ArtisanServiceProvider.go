@@ -0,0 +1,32 @@
+package Providers
+
+import (
+	"github.com/larisgo/framework/Contracts/Foundation"
+	QueueConsole "github.com/larisgo/framework/Queue/Console"
+	RoutingConsole "github.com/larisgo/framework/Routing/Console"
+	"github.com/larisgo/framework/Support"
+)
+
+type ArtisanServiceProvider struct {
+	*Support.ServiceProvider
+}
+
+func NewArtisanServiceProvider(app Foundation.Application) (this *ArtisanServiceProvider) {
+	this = &ArtisanServiceProvider{ServiceProvider: Support.NewServiceProvider(app)}
+	return this
+}
+
+/**
+ * Register the service provider.
+ *
+ * @return void
+ */
+func (this *ArtisanServiceProvider) Register() {
+	this.Commands(
+		RoutingConsole.NewRouteCacheCommand(),
+		RoutingConsole.NewRouteClearCommand(),
+		QueueConsole.NewWorkCommand(),
+		QueueConsole.NewRetryCommand(),
+		QueueConsole.NewListFailedCommand(),
+	)
+}
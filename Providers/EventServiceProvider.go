@@ -0,0 +1,67 @@
+package Providers
+
+import (
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Events"
+	"github.com/larisgo/framework/Support"
+)
+
+/**
+ * The base of the application's event service provider, which maps events to
+ * their listeners and registers the subscribers.
+ */
+type EventServiceProvider struct {
+	*Support.ServiceProvider
+
+	/**
+	 * The event handler mappings for the application, keyed by event name or
+	 * by an event value whose type names the event.
+	 *
+	 * @var map[interface{}][]interface{}
+	 */
+	Listen map[interface{}][]interface{}
+
+	/**
+	 * The subscribers to register.
+	 *
+	 * @var []interface{}
+	 */
+	Subscribe []interface{}
+}
+
+func NewEventServiceProvider(app Foundation.Application) (this *EventServiceProvider) {
+	this = &EventServiceProvider{ServiceProvider: Support.NewServiceProvider(app)}
+	this.Listen = map[interface{}][]interface{}{}
+	this.Subscribe = []interface{}{}
+	return this
+}
+
+/**
+ * Register the application's event listeners.
+ *
+ * @return void
+ */
+func (this *EventServiceProvider) Register() {
+	this.App.Booting(func(app interface{}) {
+		events := this.App.Make("events").(*Events.Dispatcher)
+
+		for event, listeners := range this.Listen {
+			for _, listener := range listeners {
+				events.Listen(event, listener)
+			}
+		}
+
+		for _, subscriber := range this.Subscribe {
+			events.Subscribe(subscriber)
+		}
+	})
+}
+
+/**
+ * Get the events and handlers.
+ *
+ * @return map[interface{}][]interface{}
+ */
+func (this *EventServiceProvider) Listens() map[interface{}][]interface{} {
+	return this.Listen
+}
@@ -1,7 +1,9 @@
 package Providers
 
 import (
+	"fmt"
 	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Errors"
 	"github.com/larisgo/framework/Routing"
 	"github.com/larisgo/framework/Support"
 	// "github.com/larisgo/framework/Support/Facades"
@@ -28,5 +30,32 @@ func (this *RouteServiceProvider) Register() {
 func (this *RouteServiceProvider) Boot() {
 	this.App.Booted(func(app interface{}) {
 		this.App.Make("router").(*Routing.Router).GetRoutes().RefreshNameLookups()
+
+		if this.routesAreCached() {
+			this.loadCachedRoutes()
+		}
 	})
 }
+
+/**
+ * Determine if the application routes are cached.
+ *
+ * @return bool
+ */
+func (this *RouteServiceProvider) routesAreCached() bool {
+	return this.App.RoutesAreCached()
+}
+
+/**
+ * Load the cached routes for the application.
+ *
+ * @return void
+ */
+func (this *RouteServiceProvider) loadCachedRoutes() {
+	cache, err := Routing.LoadRouteCache(this.App.GetCachedRoutesPath())
+	if err != nil {
+		panic(Errors.NewRuntimeException(fmt.Sprintf("Unable to load the route cache [%s]: %s", this.App.GetCachedRoutesPath(), err)))
+	}
+
+	this.App.Make("router").(*Routing.Router).GetRoutes().SetRouteCache(cache)
+}
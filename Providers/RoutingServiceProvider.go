@@ -1,7 +1,9 @@
 package Providers
 
 import (
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
 	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Contracts/Events"
 	"github.com/larisgo/framework/Contracts/Foundation"
 	"github.com/larisgo/framework/Routing"
 	"github.com/larisgo/framework/Support"
@@ -18,6 +20,8 @@ func NewRoutingServiceProvider(app Foundation.Application) (this *RoutingService
 
 func (this *RoutingServiceProvider) Register() {
 	this.registerRouter()
+	this.registerUrlGenerator()
+	this.registerResponseFactory()
 }
 
 /**
@@ -27,6 +31,39 @@ func (this *RoutingServiceProvider) Register() {
  */
 func (this *RoutingServiceProvider) registerRouter() {
 	this.App.Singleton("router", func(app Container.Container) interface{} {
-		return Routing.NewRouter(app)
+		router := Routing.NewRouter(app)
+
+		if events, ok := app.Make("events").(Events.Dispatcher); ok {
+			router.SetEventDispatcher(events)
+		}
+
+		return router
+	})
+}
+
+/**
+ * Register the URL generator service.
+ *
+ * @return void
+ */
+func (this *RoutingServiceProvider) registerUrlGenerator() {
+	this.App.Singleton("url", func(app Container.Container) interface{} {
+		rootUrl := ""
+		if config, ok := app.Make("config").(RepositoryContract.Repository); ok {
+			rootUrl, _ = config.Get("app.url", "").(string)
+		}
+
+		return Routing.NewUrlGenerator(app.Make("router").(*Routing.Router).GetRoutes(), rootUrl)
+	})
+}
+
+/**
+ * Register the response factory implementation.
+ *
+ * @return void
+ */
+func (this *RoutingServiceProvider) registerResponseFactory() {
+	this.App.Singleton("response.factory", func(app Container.Container) interface{} {
+		return Routing.NewResponseFactory(app.Make("url").(*Routing.UrlGenerator))
 	})
 }
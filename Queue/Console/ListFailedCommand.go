@@ -0,0 +1,57 @@
+package Console
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Console"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"github.com/larisgo/framework/Queue"
+	"text/tabwriter"
+)
+
+type ListFailedCommand struct {
+	*Console.Command
+	App Foundation.Application `inject:"app"`
+}
+
+func NewListFailedCommand() (this *ListFailedCommand) {
+	this = &ListFailedCommand{}
+	this.Command = Console.NewCommand("queue:failed", "List all of the failed queue jobs")
+	return this
+}
+
+/**
+ * Execute the console command.
+ *
+ * @return int
+ */
+func (this *ListFailedCommand) Handle() int {
+	jobs := this.App.Make("queue.failer").(QueueContract.FailedJobProvider).All()
+
+	if len(jobs) == 0 {
+		this.Info("No failed jobs!")
+		return 0
+	}
+
+	table := tabwriter.NewWriter(this.Output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tConnection\tQueue\tJob\tFailed At")
+	for _, job := range jobs {
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", job.Id, job.Connection, job.Queue, this.extractJobName(job.Payload), job.FailedAt.Format("2006-01-02 15:04:05"))
+	}
+	table.Flush()
+
+	return 0
+}
+
+/**
+ * Extract the failed job name from the payload.
+ *
+ * @param  string  payload
+ * @return string
+ */
+func (this *ListFailedCommand) extractJobName(payload string) string {
+	if _payload, err := Queue.ParsePayload(payload); err == nil {
+		return _payload.DisplayName
+	}
+	return ""
+}
@@ -0,0 +1,95 @@
+package Console
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Console"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"github.com/larisgo/framework/Queue"
+)
+
+type RetryCommand struct {
+	*Console.Command
+	App Foundation.Application `inject:"app"`
+}
+
+func NewRetryCommand() (this *RetryCommand) {
+	this = &RetryCommand{}
+	this.Command = Console.NewCommand("queue:retry", "Retry failed queue jobs, by their IDs or \"all\"")
+	return this
+}
+
+/**
+ * Execute the console command.
+ *
+ * @return int
+ */
+func (this *RetryCommand) Handle() int {
+	failer := this.App.Make("queue.failer").(QueueContract.FailedJobProvider)
+	manager := this.App.Make("queue").(*Queue.QueueManager)
+
+	ids := this.getJobIds(failer)
+	if len(ids) == 0 {
+		this.Error("No failed job IDs were given.")
+		return 1
+	}
+
+	status := 0
+	for _, id := range ids {
+		job, ok := failer.Find(id)
+		if !ok {
+			this.Error(fmt.Sprintf("Unable to find failed job with ID [%s].", id))
+			status = 1
+			continue
+		}
+
+		if err := this.retryJob(manager, job); err != nil {
+			this.Error(fmt.Sprintf("Unable to retry failed job [%s]: %s", id, err.Error()))
+			status = 1
+			continue
+		}
+
+		failer.Forget(id)
+
+		this.Info(fmt.Sprintf("The failed job [%s] has been pushed back onto the queue!", id))
+	}
+
+	return status
+}
+
+/**
+ * Get the job IDs to be retried.
+ *
+ * @param  QueueContract.FailedJobProvider  failer
+ * @return []string
+ */
+func (this *RetryCommand) getJobIds(failer QueueContract.FailedJobProvider) []string {
+	ids := this.Arguments()
+
+	if len(ids) == 1 && ids[0] == "all" {
+		ids = []string{}
+		for _, job := range failer.All() {
+			ids = append(ids, job.Id)
+		}
+	}
+
+	return ids
+}
+
+/**
+ * Retry the queue job, with its attempts reset.
+ *
+ * @param  *Queue.QueueManager  manager
+ * @param  QueueContract.FailedJob  job
+ * @return error
+ */
+func (this *RetryCommand) retryJob(manager *Queue.QueueManager, job QueueContract.FailedJob) error {
+	payload, err := Queue.ParsePayload(job.Payload)
+	if err != nil {
+		return err
+	}
+	payload.Attempts = 0
+
+	_, err = manager.Connection(job.Connection).PushRaw(payload.String(), job.Queue)
+	return err
+}
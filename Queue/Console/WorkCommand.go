@@ -0,0 +1,176 @@
+package Console
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/larisgo/framework/Console"
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	EventsContract "github.com/larisgo/framework/Contracts/Events"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"github.com/larisgo/framework/Queue"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+type WorkCommand struct {
+	*Console.Command
+	App Foundation.Application `inject:"app"`
+
+	queue         string
+	concurrency   int
+	timeout       int
+	stopTimeout   int
+	tries         int
+	backoff       int
+	sleep         int
+	stopWhenEmpty bool
+	maxJobs       int
+
+	/**
+	 * Indicates if the command listens for the queue events already.
+	 *
+	 * @var bool
+	 */
+	listening bool
+}
+
+func NewWorkCommand() (this *WorkCommand) {
+	this = &WorkCommand{}
+	this.Command = Console.NewCommand("queue:work", "Start processing jobs on the queue as a daemon")
+	return this
+}
+
+/**
+ * Configure the options of the console command.
+ *
+ * @param  flag.FlagSet  input
+ * @return void
+ */
+func (this *WorkCommand) Configure(input *flag.FlagSet) {
+	options := Queue.NewWorkerOptions()
+
+	input.StringVar(&this.queue, "queue", "", "The names of the queues to work, separated by commas")
+	input.IntVar(&this.concurrency, "concurrency", options.Concurrency, "The number of jobs to process at the same time")
+	input.IntVar(&this.timeout, "timeout", int(options.Timeout/time.Second), "The number of seconds a job may run")
+	input.IntVar(&this.stopTimeout, "stop-timeout", int(options.StopTimeout/time.Second), "The number of seconds a timed out job is given to stop before the worker abandons it and exits")
+	input.IntVar(&this.tries, "tries", options.MaxTries, "Number of times to attempt a job before logging it failed")
+	input.IntVar(&this.backoff, "backoff", int(options.Backoff/time.Second), "The number of seconds to wait before the first retry, doubled for every retry after")
+	input.IntVar(&this.sleep, "sleep", int(options.Sleep/time.Second), "Number of seconds to sleep when no job is available")
+	input.BoolVar(&this.stopWhenEmpty, "stop-when-empty", false, "Stop when the queue is empty")
+	input.IntVar(&this.maxJobs, "max-jobs", 0, "The number of jobs to process before stopping")
+}
+
+/**
+ * Execute the console command.
+ *
+ * @return int
+ */
+func (this *WorkCommand) Handle() int {
+	manager := this.App.Make("queue").(*Queue.QueueManager)
+
+	connection := this.Argument(0, manager.GetDefaultDriver())
+
+	this.listenForEvents()
+
+	// The worker stops taking jobs on an interrupt or a termination signal,
+	// while the jobs it is running are given the chance to finish.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	go func() {
+		select {
+		case <-signals:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return this.App.Make("queue.worker").(*Queue.Worker).Daemon(ctx, connection, this.getQueue(connection), this.gatherWorkerOptions())
+}
+
+/**
+ * Gather all of the queue worker options as a single object.
+ *
+ * @return *Queue.WorkerOptions
+ */
+func (this *WorkCommand) gatherWorkerOptions() *Queue.WorkerOptions {
+	options := Queue.NewWorkerOptions()
+	options.Concurrency = this.concurrency
+	options.Timeout = time.Duration(this.timeout) * time.Second
+	options.StopTimeout = time.Duration(this.stopTimeout) * time.Second
+	options.MaxTries = this.tries
+	options.Backoff = time.Duration(this.backoff) * time.Second
+	options.Sleep = time.Duration(this.sleep) * time.Second
+	options.StopWhenEmpty = this.stopWhenEmpty
+	options.MaxJobs = this.maxJobs
+	return options
+}
+
+/**
+ * Get the queue name for the worker.
+ *
+ * @param  string  connection
+ * @return string
+ */
+func (this *WorkCommand) getQueue(connection string) string {
+	if this.queue != "" {
+		return this.queue
+	}
+	if config, ok := this.App.Make("config").(RepositoryContract.Repository); ok {
+		if queue, ok := config.Get("queue.connections." + connection + ".queue").(string); ok && queue != "" {
+			return queue
+		}
+	}
+	return "default"
+}
+
+/**
+ * Listen for the queue events in order to update the console output.
+ *
+ * @return void
+ */
+func (this *WorkCommand) listenForEvents() {
+	events, ok := this.App.Make("events").(EventsContract.Dispatcher)
+	if !ok || this.listening {
+		return
+	}
+	this.listening = true
+
+	events.Listen(&Queue.JobProcessing{}, func(event *Queue.JobProcessing) {
+		this.writeOutput(event.Job, "starting")
+	})
+	events.Listen(&Queue.JobProcessed{}, func(event *Queue.JobProcessed) {
+		this.writeOutput(event.Job, "success")
+	})
+	events.Listen(&Queue.JobFailed{}, func(event *Queue.JobFailed) {
+		this.writeOutput(event.Job, "failed")
+	})
+}
+
+/**
+ * Write the status output for the queue worker.
+ *
+ * @param  QueueContract.Job  job
+ * @param  string  status
+ * @return void
+ */
+func (this *WorkCommand) writeOutput(job QueueContract.Job, status string) {
+	prefix := fmt.Sprintf("[%s][%s] ", time.Now().Format("2006-01-02 15:04:05"), job.GetJobId())
+
+	switch status {
+	case "starting":
+		this.Comment(prefix + "Processing: " + job.GetName())
+	case "success":
+		this.Info(prefix + "Processed:  " + job.GetName())
+	case "failed":
+		this.Error(prefix + "Failed:     " + job.GetName())
+	}
+}
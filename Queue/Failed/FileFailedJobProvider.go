@@ -0,0 +1,166 @@
+package Failed
+
+import (
+	"encoding/json"
+	"fmt"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+/**
+ * Keep each failed job in a file of the given directory.
+ */
+type FileFailedJobProvider struct {
+	/**
+	 * The directory of the failed jobs.
+	 *
+	 * @var string
+	 */
+	path string
+}
+
+/**
+ * Create a new file failed job provider.
+ *
+ * @param  string  path
+ * @return *FileFailedJobProvider
+ */
+func NewFileFailedJobProvider(path string) (this *FileFailedJobProvider) {
+	this = &FileFailedJobProvider{}
+	this.path = path
+	return this
+}
+
+/**
+ * Log a failed job into storage, under the identifier of its payload.
+ *
+ * @param  string  connection
+ * @param  string  queue
+ * @param  string  payload
+ * @param  error  exception
+ * @return string
+ */
+func (this *FileFailedJobProvider) Log(connection string, queue string, payload string, exception error) string {
+	now := time.Now()
+
+	var body struct {
+		Uuid string `json:"uuid"`
+	}
+	json.Unmarshal([]byte(payload), &body)
+	if body.Uuid == "" {
+		body.Uuid = fmt.Sprintf("%x", now.UnixNano())
+	}
+
+	job := QueueContract.FailedJob{
+		Id:         body.Uuid,
+		Connection: connection,
+		Queue:      queue,
+		Payload:    payload,
+		FailedAt:   now,
+	}
+	if exception != nil {
+		job.Exception = exception.Error()
+	}
+
+	// a job failing again replaces its earlier record
+	this.Forget(job.Id)
+
+	os.MkdirAll(this.path, 0755)
+	raw, _ := json.Marshal(job)
+	ioutil.WriteFile(filepath.Join(this.path, fmt.Sprintf("%020d-%s.json", now.UnixNano(), job.Id)), raw, 0644)
+
+	return job.Id
+}
+
+/**
+ * Get a list of all of the failed jobs, the oldest first.
+ *
+ * @return []QueueContract.FailedJob
+ */
+func (this *FileFailedJobProvider) All() []QueueContract.FailedJob {
+	jobs := []QueueContract.FailedJob{}
+
+	files, _ := ioutil.ReadDir(this.path)
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+		if job, ok := this.read(filepath.Join(this.path, file.Name())); ok {
+			jobs = append(jobs, job)
+		}
+	}
+
+	return jobs
+}
+
+/**
+ * Get a single failed job.
+ *
+ * @param  string  id
+ * @return QueueContract.FailedJob, bool
+ */
+func (this *FileFailedJobProvider) Find(id string) (QueueContract.FailedJob, bool) {
+	if file := this.file(id); file != "" {
+		return this.read(file)
+	}
+	return QueueContract.FailedJob{}, false
+}
+
+/**
+ * Delete a single failed job from storage.
+ *
+ * @param  string  id
+ * @return bool
+ */
+func (this *FileFailedJobProvider) Forget(id string) bool {
+	if file := this.file(id); file != "" {
+		return os.Remove(file) == nil
+	}
+	return false
+}
+
+/**
+ * Flush all of the failed jobs from storage.
+ *
+ * @return void
+ */
+func (this *FileFailedJobProvider) Flush() {
+	files, _ := filepath.Glob(filepath.Join(this.path, "*.json"))
+	for _, file := range files {
+		os.Remove(file)
+	}
+}
+
+/**
+ * Get the file of the given failed job.
+ *
+ * @param  string  id
+ * @return string
+ */
+func (this *FileFailedJobProvider) file(id string) string {
+	if id == "" || strings.ContainsAny(id, `/\*?[`) {
+		return ""
+	}
+	if files, _ := filepath.Glob(filepath.Join(this.path, "*-"+id+".json")); len(files) > 0 {
+		return files[0]
+	}
+	return ""
+}
+
+/**
+ * Read a failed job from its file.
+ *
+ * @param  string  file
+ * @return QueueContract.FailedJob, bool
+ */
+func (this *FileFailedJobProvider) read(file string) (job QueueContract.FailedJob, ok bool) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return job, false
+	}
+	return job, json.Unmarshal(raw, &job) == nil
+}
@@ -0,0 +1,71 @@
+package Failed
+
+import (
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+)
+
+/**
+ * Forget the failed jobs right away.
+ */
+type NullFailedJobProvider struct {
+}
+
+/**
+ * Create a new null failed job provider.
+ *
+ * @return *NullFailedJobProvider
+ */
+func NewNullFailedJobProvider() (this *NullFailedJobProvider) {
+	this = &NullFailedJobProvider{}
+	return this
+}
+
+/**
+ * Log a failed job into storage.
+ *
+ * @param  string  connection
+ * @param  string  queue
+ * @param  string  payload
+ * @param  error  exception
+ * @return string
+ */
+func (this *NullFailedJobProvider) Log(connection string, queue string, payload string, exception error) string {
+	return ""
+}
+
+/**
+ * Get a list of all of the failed jobs.
+ *
+ * @return []QueueContract.FailedJob
+ */
+func (this *NullFailedJobProvider) All() []QueueContract.FailedJob {
+	return []QueueContract.FailedJob{}
+}
+
+/**
+ * Get a single failed job.
+ *
+ * @param  string  id
+ * @return QueueContract.FailedJob, bool
+ */
+func (this *NullFailedJobProvider) Find(id string) (QueueContract.FailedJob, bool) {
+	return QueueContract.FailedJob{}, false
+}
+
+/**
+ * Delete a single failed job from storage.
+ *
+ * @param  string  id
+ * @return bool
+ */
+func (this *NullFailedJobProvider) Forget(id string) bool {
+	return false
+}
+
+/**
+ * Flush all of the failed jobs from storage.
+ *
+ * @return void
+ */
+func (this *NullFailedJobProvider) Flush() {
+}
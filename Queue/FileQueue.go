@@ -0,0 +1,310 @@
+package Queue
+
+import (
+	"fmt"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/**
+ * A durable queue that keeps each job in a file of the directory of its
+ * queue, named after the moment it becomes available. A worker reserves a
+ * job by moving its file into the ".reserved" directory, so several workers
+ * and processes may share the same directory. A file that does not hold a
+ * valid payload is moved into the ".failed" directory, where it is kept for
+ * inspection.
+ */
+type FileQueue struct {
+	*Queue
+
+	/**
+	 * The directory of the queues.
+	 *
+	 * @var string
+	 */
+	path string
+
+	/**
+	 * The time a reserved job may run before it is released again.
+	 *
+	 * @var time.Duration
+	 */
+	retryAfter time.Duration
+}
+
+/**
+ * Create a new file queue instance.
+ *
+ * @param  string  path
+ * @param  string  _default
+ * @param  time.Duration  retryAfter
+ * @return *FileQueue
+ */
+func NewFileQueue(path string, _default string, retryAfter time.Duration) (this *FileQueue) {
+	this = &FileQueue{Queue: NewQueue(_default)}
+	this.path = path
+	this.retryAfter = retryAfter
+	return this
+}
+
+/**
+ * Get the directory of the given queue, creating it when needed.
+ *
+ * @param  string  queue
+ * @return string
+ */
+func (this *FileQueue) directory(queue string) string {
+	directory := filepath.Join(this.path, queue)
+	os.MkdirAll(filepath.Join(directory, ".reserved"), 0755)
+	return directory
+}
+
+/**
+ * Get the size of the queue.
+ *
+ * @param  string  queue
+ * @return int
+ */
+func (this *FileQueue) Size(queue ...string) (size int) {
+	directory := this.directory(this.getQueue(queue))
+	for _, dir := range []string{directory, filepath.Join(directory, ".reserved")} {
+		files, _ := ioutil.ReadDir(dir)
+		for _, file := range files {
+			if !file.IsDir() && strings.HasSuffix(file.Name(), ".json") {
+				size++
+			}
+		}
+	}
+	return size
+}
+
+/**
+ * Push a new job onto the queue.
+ *
+ * @param  QueueContract.ShouldQueue  job
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *FileQueue) Push(job QueueContract.ShouldQueue, queue ...string) (string, error) {
+	return this.Later(0, job, queue...)
+}
+
+/**
+ * Push a raw payload onto the queue.
+ *
+ * @param  string  raw
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *FileQueue) PushRaw(raw string, queue ...string) (string, error) {
+	payload, err := ParsePayload(raw)
+	if err != nil {
+		return "", err
+	}
+	return payload.Uuid, this.pushPayload(payload, this.getQueue(queue), 0)
+}
+
+/**
+ * Push a new job onto the queue after a delay.
+ *
+ * @param  time.Duration  delay
+ * @param  QueueContract.ShouldQueue  job
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *FileQueue) Later(delay time.Duration, job QueueContract.ShouldQueue, queue ...string) (string, error) {
+	payload, err := CreatePayload(job)
+	if err != nil {
+		return "", err
+	}
+	return payload.Uuid, this.pushPayload(payload, this.getQueue(queue), delay)
+}
+
+/**
+ * Write a payload into the given queue, available after a delay.
+ *
+ * @param  Payload  payload
+ * @param  string  queue
+ * @param  time.Duration  delay
+ * @return error
+ */
+func (this *FileQueue) pushPayload(payload Payload, queue string, delay time.Duration) error {
+	name := fmt.Sprintf("%020d-%s.json", time.Now().Add(delay).UnixNano(), payload.Uuid)
+	return this.write(filepath.Join(this.directory(queue), name), payload)
+}
+
+/**
+ * Write a payload into a file at once, so readers never see half of it.
+ *
+ * @param  string  path
+ * @param  Payload  payload
+ * @return error
+ */
+func (this *FileQueue) write(path string, payload Payload) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.WriteString(payload.String()); err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+/**
+ * Pop the next job off of the queue.
+ *
+ * @param  string  queue
+ * @return QueueContract.Job, error
+ */
+func (this *FileQueue) Pop(queue ...string) (QueueContract.Job, error) {
+	name := this.getQueue(queue)
+	directory := this.directory(name)
+
+	this.releaseExpired(directory)
+
+	files, err := ioutil.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, file := range files {
+		availableAt, id, ok := this.parseName(file.Name())
+		if file.IsDir() || !ok {
+			continue
+		}
+		// the files are sorted by the moment they become available
+		if availableAt > now.UnixNano() {
+			break
+		}
+
+		reserved := filepath.Join(directory, ".reserved", id+".json")
+		if os.Rename(filepath.Join(directory, file.Name()), reserved) != nil {
+			// another worker reserved the job first
+			continue
+		}
+		os.Chtimes(reserved, now, now)
+
+		raw, err := ioutil.ReadFile(reserved)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := ParsePayload(string(raw))
+		if err != nil {
+			this.moveToFailed(directory, reserved)
+			return nil, err
+		}
+		payload.Attempts++
+		if err := this.write(reserved, payload); err != nil {
+			return nil, err
+		}
+
+		return newJob(this, this.container, id, payload, this.connectionName, name), nil
+	}
+
+	return nil, nil
+}
+
+/**
+ * Move a reserved file that cannot be parsed out of the queue, keeping it in
+ * the ".failed" directory so its content is not lost.
+ *
+ * @param  string  directory
+ * @param  string  reserved
+ * @return void
+ */
+func (this *FileQueue) moveToFailed(directory string, reserved string) {
+	failed := filepath.Join(directory, ".failed")
+	os.MkdirAll(failed, 0755)
+	os.Rename(reserved, filepath.Join(failed, filepath.Base(reserved)))
+}
+
+/**
+ * Release the reserved jobs that have run longer than the retry time, e.g.
+ * because the worker running them has died.
+ *
+ * @param  string  directory
+ * @return void
+ */
+func (this *FileQueue) releaseExpired(directory string) {
+	if this.retryAfter <= 0 {
+		return
+	}
+
+	files, _ := ioutil.ReadDir(filepath.Join(directory, ".reserved"))
+	expiredAt := time.Now().Add(-this.retryAfter)
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".json") || file.ModTime().After(expiredAt) {
+			continue
+		}
+		id := strings.TrimSuffix(file.Name(), ".json")
+		os.Rename(
+			filepath.Join(directory, ".reserved", file.Name()),
+			filepath.Join(directory, fmt.Sprintf("%020d-%s.json", time.Now().UnixNano(), id)),
+		)
+	}
+}
+
+/**
+ * Parse the moment a job becomes available and its identifier from the name
+ * of its file.
+ *
+ * @param  string  name
+ * @return int64, string, bool
+ */
+func (this *FileQueue) parseName(name string) (int64, string, bool) {
+	if !strings.HasSuffix(name, ".json") {
+		return 0, "", false
+	}
+	parts := strings.SplitN(strings.TrimSuffix(name, ".json"), "-", 2)
+	if len(parts) != 2 {
+		return 0, "", false
+	}
+	availableAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return availableAt, parts[1], true
+}
+
+/**
+ * Delete a reserved job from the queue.
+ *
+ * @param  *Job  job
+ * @return error
+ */
+func (this *FileQueue) deleteReserved(job *Job) error {
+	err := os.Remove(filepath.Join(this.directory(job.queue), ".reserved", job.id+".json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+/**
+ * Release a reserved job back onto the queue.
+ *
+ * @param  *Job  job
+ * @param  time.Duration  delay
+ * @return error
+ */
+func (this *FileQueue) release(job *Job, delay time.Duration) error {
+	if err := this.pushPayload(job.payload, job.queue, delay); err != nil {
+		return err
+	}
+	return this.deleteReserved(job)
+}
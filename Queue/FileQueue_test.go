@@ -0,0 +1,37 @@
+package Queue
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestInvalidPayloadIsMovedToTheFailedDirectory(t *testing.T) {
+	path, err := ioutil.TempDir("", "queue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(path)
+
+	queue := NewFileQueue(path, "default", 0)
+	directory := queue.directory("default")
+	if err := ioutil.WriteFile(filepath.Join(directory, "00000000000000000001-broken.json"), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if job, err := queue.Pop(); err == nil || job != nil {
+		t.Fatalf("expected a parse error, got %v, %v", job, err)
+	}
+
+	raw, err := ioutil.ReadFile(filepath.Join(directory, ".failed", "broken.json"))
+	if err != nil {
+		t.Fatalf("the invalid payload was not kept: %v", err)
+	}
+	if string(raw) != "{not json" {
+		t.Fatalf("unexpected content %q", raw)
+	}
+	if size := queue.Size(); size != 0 {
+		t.Fatalf("expected an empty queue, got %d", size)
+	}
+}
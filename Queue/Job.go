@@ -0,0 +1,345 @@
+package Queue
+
+import (
+	"context"
+	"github.com/larisgo/framework/Contracts/Container"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"reflect"
+	"sync"
+	"time"
+)
+
+/**
+ * The storage a job was taken from.
+ */
+type jobDriver interface {
+	/**
+	 * Delete a reserved job from the queue.
+	 *
+	 * @param  *Job  job
+	 * @return error
+	 */
+	deleteReserved(*Job) error
+
+	/**
+	 * Release a reserved job back onto the queue.
+	 *
+	 * @param  *Job  job
+	 * @param  time.Duration  delay
+	 * @return error
+	 */
+	release(*Job, time.Duration) error
+}
+
+type Job struct {
+	/**
+	 * The storage the job was taken from.
+	 *
+	 * @var jobDriver
+	 */
+	driver jobDriver
+
+	/**
+	 * The IoC container instance.
+	 *
+	 * @var Container.Container
+	 */
+	container Container.Container
+
+	/**
+	 * The job identifier.
+	 *
+	 * @var string
+	 */
+	id string
+
+	/**
+	 * The payload of the job.
+	 *
+	 * @var Payload
+	 */
+	payload Payload
+
+	/**
+	 * The name of the connection the job belongs to.
+	 *
+	 * @var string
+	 */
+	connectionName string
+
+	/**
+	 * The name of the queue the job belongs to.
+	 *
+	 * @var string
+	 */
+	queue string
+
+	/**
+	 * The job that was rebuilt from the payload.
+	 *
+	 * @var QueueContract.ShouldQueue
+	 */
+	instance QueueContract.ShouldQueue
+
+	/**
+	 * Indicates if the job has been deleted, released or has failed.
+	 *
+	 * @var bool
+	 */
+	deleted  bool
+	released bool
+	failed   bool
+
+	lock sync.Mutex
+}
+
+/**
+ * Create a new job instance.
+ *
+ * @param  jobDriver  driver
+ * @param  Container.Container  container
+ * @param  string  id
+ * @param  Payload  payload
+ * @param  string  connectionName
+ * @param  string  queue
+ * @return *Job
+ */
+func newJob(driver jobDriver, container Container.Container, id string, payload Payload, connectionName string, queue string) (this *Job) {
+	this = &Job{}
+	this.driver = driver
+	this.container = container
+	this.id = id
+	this.payload = payload
+	this.connectionName = connectionName
+	this.queue = queue
+	return this
+}
+
+/**
+ * Get the job identifier.
+ *
+ * @return string
+ */
+func (this *Job) GetJobId() string {
+	return this.id
+}
+
+/**
+ * Get the payload of the job.
+ *
+ * @return Payload
+ */
+func (this *Job) Payload() Payload {
+	return this.payload
+}
+
+/**
+ * Get the raw body of the job.
+ *
+ * @return string
+ */
+func (this *Job) GetRawBody() string {
+	return this.payload.String()
+}
+
+/**
+ * Fire the job, rebuilding it from its payload first. Fields tagged with
+ * "inject" are resolved from the container when the job is a struct pointer.
+ *
+ * @param  context.Context  ctx
+ * @return error
+ */
+func (this *Job) Fire(ctx context.Context) error {
+	instance := this.payload.Resolve()
+
+	if this.container != nil {
+		if t := reflect.TypeOf(instance); t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct {
+			this.container.Build(instance, this.payload.Job)
+		}
+	}
+
+	this.lock.Lock()
+	this.instance = instance
+	this.lock.Unlock()
+
+	return instance.Handle(ctx)
+}
+
+/**
+ * Release the job back into the queue after the given delay.
+ *
+ * @param  time.Duration  delay
+ * @return error
+ */
+func (this *Job) Release(delay time.Duration) error {
+	this.lock.Lock()
+	this.released = true
+	this.lock.Unlock()
+
+	return this.driver.release(this, delay)
+}
+
+/**
+ * Determine if the job was released back into the queue.
+ *
+ * @return bool
+ */
+func (this *Job) IsReleased() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	return this.released
+}
+
+/**
+ * Delete the job from the queue.
+ *
+ * @return error
+ */
+func (this *Job) Delete() error {
+	this.lock.Lock()
+	this.deleted = true
+	this.lock.Unlock()
+
+	return this.driver.deleteReserved(this)
+}
+
+/**
+ * Determine if the job has been deleted.
+ *
+ * @return bool
+ */
+func (this *Job) IsDeleted() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	return this.deleted
+}
+
+/**
+ * Determine if the job has been deleted or released.
+ *
+ * @return bool
+ */
+func (this *Job) IsDeletedOrReleased() bool {
+	return this.IsDeleted() || this.IsReleased()
+}
+
+/**
+ * Get the number of times the job has been attempted.
+ *
+ * @return int
+ */
+func (this *Job) Attempts() int {
+	return this.payload.Attempts
+}
+
+/**
+ * Determine if the job has been marked as a failure.
+ *
+ * @return bool
+ */
+func (this *Job) HasFailed() bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	return this.failed
+}
+
+/**
+ * Mark the job as "failed".
+ *
+ * @return void
+ */
+func (this *Job) MarkAsFailed() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.failed = true
+}
+
+/**
+ * Delete the job, call the "failed" hook of the job and mark it as failed.
+ *
+ * @param  error  err
+ * @return void
+ */
+func (this *Job) Fail(err error) {
+	this.MarkAsFailed()
+
+	if this.IsDeleted() {
+		return
+	}
+
+	this.Delete()
+
+	this.lock.Lock()
+	instance := this.instance
+	this.lock.Unlock()
+
+	if instance == nil {
+		// the job never got to run, so it is rebuilt for its hook when possible
+		func() {
+			defer func() { recover() }()
+			instance = this.payload.Resolve()
+		}()
+	}
+
+	if job, ok := instance.(FailedT); ok {
+		job.Failed(err)
+	}
+}
+
+/**
+ * Get the number of times to attempt a job, zero when the worker decides.
+ *
+ * @return int
+ */
+func (this *Job) MaxTries() int {
+	return this.payload.MaxTries
+}
+
+/**
+ * Get the number of seconds to wait before retrying a job.
+ *
+ * @return []int
+ */
+func (this *Job) Backoff() []int {
+	return this.payload.Backoff
+}
+
+/**
+ * Get the time the job may run, zero when the worker decides.
+ *
+ * @return time.Duration
+ */
+func (this *Job) Timeout() time.Duration {
+	return time.Duration(this.payload.Timeout) * time.Millisecond
+}
+
+/**
+ * Get the name of the queued job.
+ *
+ * @return string
+ */
+func (this *Job) GetName() string {
+	return this.payload.DisplayName
+}
+
+/**
+ * Get the name of the connection the job belongs to.
+ *
+ * @return string
+ */
+func (this *Job) GetConnectionName() string {
+	return this.connectionName
+}
+
+/**
+ * Get the name of the queue the job belongs to.
+ *
+ * @return string
+ */
+func (this *Job) GetQueue() string {
+	return this.queue
+}
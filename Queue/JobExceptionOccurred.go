@@ -0,0 +1,43 @@
+package Queue
+
+import (
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+)
+
+/**
+ * Fired when an attempt of a job returned an error.
+ */
+type JobExceptionOccurred struct {
+	/**
+	 * The connection name.
+	 *
+	 * @var string
+	 */
+	ConnectionName string
+
+	/**
+	 * The job instance.
+	 *
+	 * @var QueueContract.Job
+	 */
+	Job QueueContract.Job
+
+	/**
+	 * The error of the job.
+	 *
+	 * @var error
+	 */
+	Exception error
+}
+
+/**
+ * Create a new event instance.
+ *
+ * @param  string  connectionName
+ * @param  QueueContract.Job  job
+ * @param  error  exception
+ * @return *JobExceptionOccurred
+ */
+func NewJobExceptionOccurred(connectionName string, job QueueContract.Job, exception error) *JobExceptionOccurred {
+	return &JobExceptionOccurred{ConnectionName: connectionName, Job: job, Exception: exception}
+}
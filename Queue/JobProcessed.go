@@ -0,0 +1,35 @@
+package Queue
+
+import (
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+)
+
+/**
+ * Fired after a worker ran a job.
+ */
+type JobProcessed struct {
+	/**
+	 * The connection name.
+	 *
+	 * @var string
+	 */
+	ConnectionName string
+
+	/**
+	 * The job instance.
+	 *
+	 * @var QueueContract.Job
+	 */
+	Job QueueContract.Job
+}
+
+/**
+ * Create a new event instance.
+ *
+ * @param  string  connectionName
+ * @param  QueueContract.Job  job
+ * @return *JobProcessed
+ */
+func NewJobProcessed(connectionName string, job QueueContract.Job) *JobProcessed {
+	return &JobProcessed{ConnectionName: connectionName, Job: job}
+}
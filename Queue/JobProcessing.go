@@ -0,0 +1,35 @@
+package Queue
+
+import (
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+)
+
+/**
+ * Fired before a worker runs a job.
+ */
+type JobProcessing struct {
+	/**
+	 * The connection name.
+	 *
+	 * @var string
+	 */
+	ConnectionName string
+
+	/**
+	 * The job instance.
+	 *
+	 * @var QueueContract.Job
+	 */
+	Job QueueContract.Job
+}
+
+/**
+ * Create a new event instance.
+ *
+ * @param  string  connectionName
+ * @param  QueueContract.Job  job
+ * @return *JobProcessing
+ */
+func NewJobProcessing(connectionName string, job QueueContract.Job) *JobProcessing {
+	return &JobProcessing{ConnectionName: connectionName, Job: job}
+}
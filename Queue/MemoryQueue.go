@@ -0,0 +1,205 @@
+package Queue
+
+import (
+	"errors"
+	"fmt"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"sync"
+	"time"
+)
+
+/**
+ * A queue that keeps its jobs in buffered channels. The jobs are lost when
+ * the process exits, so it suits jobs that may be dropped.
+ */
+type MemoryQueue struct {
+	*Queue
+
+	/**
+	 * The capacity of the channel of each queue.
+	 *
+	 * @var int
+	 */
+	buffer int
+
+	/**
+	 * The channels of the queues.
+	 *
+	 * @var map[string]chan Payload
+	 */
+	channels map[string]chan Payload
+
+	/**
+	 * The number of delayed jobs of each queue.
+	 *
+	 * @var map[string]int
+	 */
+	delayed map[string]int
+
+	lock sync.Mutex
+}
+
+/**
+ * Create a new memory queue instance.
+ *
+ * @param  string  _default
+ * @param  int  buffer
+ * @return *MemoryQueue
+ */
+func NewMemoryQueue(_default string, buffer int) (this *MemoryQueue) {
+	this = &MemoryQueue{Queue: NewQueue(_default)}
+	this.buffer = buffer
+	if this.buffer <= 0 {
+		this.buffer = 1024
+	}
+	this.channels = map[string]chan Payload{}
+	this.delayed = map[string]int{}
+	return this
+}
+
+/**
+ * Get the channel of the given queue.
+ *
+ * @param  string  queue
+ * @return chan Payload
+ */
+func (this *MemoryQueue) channel(queue string) chan Payload {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if _, ok := this.channels[queue]; !ok {
+		this.channels[queue] = make(chan Payload, this.buffer)
+	}
+	return this.channels[queue]
+}
+
+/**
+ * Get the size of the queue.
+ *
+ * @param  string  queue
+ * @return int
+ */
+func (this *MemoryQueue) Size(queue ...string) int {
+	name := this.getQueue(queue)
+	channel := this.channel(name)
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	return len(channel) + this.delayed[name]
+}
+
+/**
+ * Push a new job onto the queue.
+ *
+ * @param  QueueContract.ShouldQueue  job
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *MemoryQueue) Push(job QueueContract.ShouldQueue, queue ...string) (string, error) {
+	return this.Later(0, job, queue...)
+}
+
+/**
+ * Push a raw payload onto the queue.
+ *
+ * @param  string  raw
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *MemoryQueue) PushRaw(raw string, queue ...string) (string, error) {
+	payload, err := ParsePayload(raw)
+	if err != nil {
+		return "", err
+	}
+	return payload.Uuid, this.pushPayload(payload, this.getQueue(queue), 0)
+}
+
+/**
+ * Push a new job onto the queue after a delay.
+ *
+ * @param  time.Duration  delay
+ * @param  QueueContract.ShouldQueue  job
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *MemoryQueue) Later(delay time.Duration, job QueueContract.ShouldQueue, queue ...string) (string, error) {
+	payload, err := CreatePayload(job)
+	if err != nil {
+		return "", err
+	}
+	return payload.Uuid, this.pushPayload(payload, this.getQueue(queue), delay)
+}
+
+/**
+ * Push a payload onto the given queue after a delay.
+ *
+ * @param  Payload  payload
+ * @param  string  queue
+ * @param  time.Duration  delay
+ * @return error
+ */
+func (this *MemoryQueue) pushPayload(payload Payload, queue string, delay time.Duration) error {
+	channel := this.channel(queue)
+
+	if delay > 0 {
+		this.lock.Lock()
+		this.delayed[queue]++
+		this.lock.Unlock()
+
+		time.AfterFunc(delay, func() {
+			channel <- payload
+
+			this.lock.Lock()
+			this.delayed[queue]--
+			this.lock.Unlock()
+		})
+		return nil
+	}
+
+	select {
+	case channel <- payload:
+		return nil
+	default:
+		return errors.New(fmt.Sprintf("Queue [%s] is full.", queue))
+	}
+}
+
+/**
+ * Pop the next job off of the queue.
+ *
+ * @param  string  queue
+ * @return QueueContract.Job, error
+ */
+func (this *MemoryQueue) Pop(queue ...string) (QueueContract.Job, error) {
+	name := this.getQueue(queue)
+
+	select {
+	case payload := <-this.channel(name):
+		payload.Attempts++
+		return newJob(this, this.container, payload.Uuid, payload, this.connectionName, name), nil
+	default:
+		return nil, nil
+	}
+}
+
+/**
+ * Delete a reserved job from the queue.
+ *
+ * @param  *Job  job
+ * @return error
+ */
+func (this *MemoryQueue) deleteReserved(job *Job) error {
+	return nil
+}
+
+/**
+ * Release a reserved job back onto the queue.
+ *
+ * @param  *Job  job
+ * @param  time.Duration  delay
+ * @return error
+ */
+func (this *MemoryQueue) release(job *Job, delay time.Duration) error {
+	return this.pushPayload(job.payload, job.queue, delay)
+}
@@ -0,0 +1,203 @@
+package Queue
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"github.com/larisgo/framework/Errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+/**
+ * A job that decides how many times it may be attempted.
+ */
+type TriesT interface {
+	Tries() int
+}
+
+/**
+ * A job that decides how long it may run.
+ */
+type TimeoutT interface {
+	Timeout() time.Duration
+}
+
+/**
+ * A job that decides the seconds to wait before each retry.
+ */
+type BackoffT interface {
+	Backoff() []int
+}
+
+/**
+ * A job that wants to know when it failed for good.
+ */
+type FailedT interface {
+	Failed(error)
+}
+
+/**
+ * The serialised form of a queued job. The timeout is kept in milliseconds,
+ * so a timeout of less than a second is not lost.
+ */
+type Payload struct {
+	Uuid        string          `json:"uuid"`
+	DisplayName string          `json:"displayName"`
+	Job         string          `json:"job"`
+	Data        json.RawMessage `json:"data"`
+	MaxTries    int             `json:"maxTries,omitempty"`
+	Timeout     int64           `json:"timeoutMs,omitempty"`
+	Backoff     []int           `json:"backoff,omitempty"`
+	Attempts    int             `json:"attempts"`
+	PushedAt    int64           `json:"pushedAt"`
+}
+
+var (
+	/**
+	 * The job types that can be taken off a queue, keyed by their names.
+	 *
+	 * @var map[string]reflect.Type
+	 */
+	jobs = map[string]reflect.Type{}
+
+	jobsLock sync.RWMutex
+)
+
+/**
+ * Register the given job types, so a worker knows how to rebuild them from
+ * their payloads. Jobs pushed in the same process are registered as well.
+ *
+ * @param  QueueContract.ShouldQueue  job
+ * @return void
+ */
+func Register(job ...QueueContract.ShouldQueue) {
+	jobsLock.Lock()
+	defer jobsLock.Unlock()
+
+	for _, j := range job {
+		jobs[NameOf(j)] = reflect.TypeOf(j)
+	}
+}
+
+/**
+ * Get the name a job is registered under, its package path and type name.
+ *
+ * @param  QueueContract.ShouldQueue  job
+ * @return string
+ */
+func NameOf(job QueueContract.ShouldQueue) string {
+	t := reflect.TypeOf(job)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
+
+/**
+ * Create a payload for the given job.
+ *
+ * @param  QueueContract.ShouldQueue  job
+ * @return Payload, error
+ */
+func CreatePayload(job QueueContract.ShouldQueue) (payload Payload, err error) {
+	Register(job)
+
+	payload = Payload{
+		Uuid:        newUuid(),
+		DisplayName: reflect.TypeOf(job).String(),
+		Job:         NameOf(job),
+		PushedAt:    time.Now().Unix(),
+	}
+	if payload.Data, err = json.Marshal(job); err != nil {
+		return payload, err
+	}
+	if j, ok := job.(TriesT); ok {
+		payload.MaxTries = j.Tries()
+	}
+	if j, ok := job.(TimeoutT); ok {
+		// A timeout is rounded up, as a timeout of zero would let the job run
+		// for as long as the worker allows.
+		payload.Timeout = int64((j.Timeout() + time.Millisecond - 1) / time.Millisecond)
+	}
+	if j, ok := job.(BackoffT); ok {
+		payload.Backoff = j.Backoff()
+	}
+
+	return payload, nil
+}
+
+/**
+ * Parse the raw body of a job.
+ *
+ * @param  string  raw
+ * @return Payload, error
+ */
+func ParsePayload(raw string) (payload Payload, err error) {
+	err = json.Unmarshal([]byte(raw), &payload)
+	return payload, err
+}
+
+/**
+ * Get the raw body of the payload.
+ *
+ * @return string
+ */
+func (this Payload) String() string {
+	raw, _ := json.Marshal(this)
+	return string(raw)
+}
+
+/**
+ * Rebuild the job of the payload.
+ *
+ * @return QueueContract.ShouldQueue
+ *
+ * @throws Errors.RuntimeException
+ */
+func (this Payload) Resolve() QueueContract.ShouldQueue {
+	jobsLock.RLock()
+	t, ok := jobs[this.Job]
+	jobsLock.RUnlock()
+
+	if !ok {
+		panic(Errors.NewRuntimeException(fmt.Sprintf("Job [%s] is not registered.", this.Job)))
+	}
+
+	pointer := t.Kind() == reflect.Ptr
+	if pointer {
+		t = t.Elem()
+	}
+
+	value := reflect.New(t)
+	if len(this.Data) > 0 {
+		if err := json.Unmarshal(this.Data, value.Interface()); err != nil {
+			panic(Errors.NewRuntimeException(fmt.Sprintf("Unable to rebuild job [%s]: %s", this.Job, err.Error())))
+		}
+	}
+	if !pointer {
+		value = value.Elem()
+	}
+
+	return value.Interface().(QueueContract.ShouldQueue)
+}
+
+/**
+ * Create a random identifier in the form of a version 4 UUID.
+ *
+ * @return string
+ */
+func newUuid() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	h := hex.EncodeToString(b)
+	return h[0:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
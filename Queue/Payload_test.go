@@ -0,0 +1,36 @@
+package Queue
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+type quickJob struct{}
+
+func (this *quickJob) Handle(context.Context) error {
+	return nil
+}
+
+func (this *quickJob) Timeout() time.Duration {
+	return 1500 * time.Microsecond
+}
+
+func TestSubSecondTimeoutIsKeptInThePayload(t *testing.T) {
+	queue := NewMemoryQueue("default", 1)
+	if _, err := queue.Push(&quickJob{}); err != nil {
+		t.Fatal(err)
+	}
+
+	job, err := queue.Pop()
+	if err != nil || job == nil {
+		t.Fatalf("expected the pushed job, got %v, %v", job, err)
+	}
+	if !strings.Contains(job.GetRawBody(), `"timeoutMs":2`) {
+		t.Errorf("expected the timeout in milliseconds, rounded up, in %s", job.GetRawBody())
+	}
+	if timeout := job.Timeout(); timeout != 2*time.Millisecond {
+		t.Errorf("expected a timeout of 2ms, got %v", timeout)
+	}
+}
@@ -0,0 +1,106 @@
+package Queue
+
+import (
+	"context"
+	"fmt"
+	"github.com/larisgo/framework/Contracts/Container"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"runtime/debug"
+)
+
+type Queue struct {
+	/**
+	 * The IoC container instance.
+	 *
+	 * @var Container.Container
+	 */
+	container Container.Container
+
+	/**
+	 * The connection name for the queue.
+	 *
+	 * @var string
+	 */
+	connectionName string
+
+	/**
+	 * The name of the default queue.
+	 *
+	 * @var string
+	 */
+	_default string
+}
+
+/**
+ * Create a new queue instance.
+ *
+ * @param  string  _default
+ * @return *Queue
+ */
+func NewQueue(_default string) (this *Queue) {
+	this = &Queue{}
+	this._default = _default
+	if this._default == "" {
+		this._default = "default"
+	}
+	return this
+}
+
+/**
+ * Get the connection name for the queue.
+ *
+ * @return string
+ */
+func (this *Queue) GetConnectionName() string {
+	return this.connectionName
+}
+
+/**
+ * Set the connection name for the queue.
+ *
+ * @param  string  name
+ * @return void
+ */
+func (this *Queue) SetConnectionName(name string) {
+	this.connectionName = name
+}
+
+/**
+ * Set the IoC container instance.
+ *
+ * @param  Container.Container  container
+ * @return void
+ */
+func (this *Queue) SetContainer(container Container.Container) {
+	this.container = container
+}
+
+/**
+ * Get the queue or return the default.
+ *
+ * @param  []string  queue
+ * @return string
+ */
+func (this *Queue) getQueue(queue []string) string {
+	if len(queue) > 0 && queue[0] != "" {
+		return queue[0]
+	}
+	return this._default
+}
+
+/**
+ * Fire the given job, turning a panic of the job into an error.
+ *
+ * @param  context.Context  ctx
+ * @param  QueueContract.Job  job
+ * @return error
+ */
+func fire(ctx context.Context, job QueueContract.Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return job.Fire(ctx)
+}
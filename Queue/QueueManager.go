@@ -0,0 +1,269 @@
+package Queue
+
+import (
+	"fmt"
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"github.com/larisgo/framework/Errors"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/**
+ * Create a queue connection from its configuration.
+ */
+type Connector func(config map[string]interface{}) QueueContract.Queue
+
+type QueueManager struct {
+	/**
+	 * The application instance.
+	 *
+	 * @var Foundation.Application
+	 */
+	app Foundation.Application
+
+	/**
+	 * The array of resolved queue connections.
+	 *
+	 * @var map[string]QueueContract.Queue
+	 */
+	connections map[string]QueueContract.Queue
+
+	/**
+	 * The array of resolved queue connectors.
+	 *
+	 * @var map[string]Connector
+	 */
+	connectors map[string]Connector
+
+	lock sync.Mutex
+}
+
+/**
+ * Create a new queue manager instance.
+ *
+ * @param  Foundation.Application  app
+ * @return *QueueManager
+ */
+func NewQueueManager(app Foundation.Application) (this *QueueManager) {
+	this = &QueueManager{}
+	this.app = app
+	this.connections = map[string]QueueContract.Queue{}
+	this.connectors = map[string]Connector{}
+
+	this.AddConnector("sync", func(config map[string]interface{}) QueueContract.Queue {
+		return NewSyncQueue()
+	})
+	this.AddConnector("memory", func(config map[string]interface{}) QueueContract.Queue {
+		return NewMemoryQueue(configString(config, "queue"), configInt(config, "buffer"))
+	})
+	this.AddConnector("file", func(config map[string]interface{}) QueueContract.Queue {
+		path := configString(config, "path")
+		if path == "" {
+			path = this.app.StoragePath("framework/queue")
+		}
+		retryAfter := 90
+		if _, ok := config["retry_after"]; ok {
+			retryAfter = configInt(config, "retry_after")
+		}
+		return NewFileQueue(path, configString(config, "queue"), time.Duration(retryAfter)*time.Second)
+	})
+
+	return this
+}
+
+/**
+ * Add a queue connection resolver.
+ *
+ * @param  string  driver
+ * @param  Connector  resolver
+ * @return void
+ */
+func (this *QueueManager) AddConnector(driver string, resolver Connector) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.connectors[driver] = resolver
+}
+
+/**
+ * Resolve a queue connection instance.
+ *
+ * @param  string  name
+ * @return QueueContract.Queue
+ */
+func (this *QueueManager) Connection(name ...string) QueueContract.Queue {
+	name = append(name, "")
+	if name[0] == "" {
+		name[0] = this.GetDefaultDriver()
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	// If the connection has not been resolved yet we will resolve it now as all
+	// of the connections are resolved when they are actually needed so we do
+	// not make any unnecessary connection to the various queue end-points.
+	if _, ok := this.connections[name[0]]; !ok {
+		this.connections[name[0]] = this.resolve(name[0])
+	}
+
+	return this.connections[name[0]]
+}
+
+/**
+ * Resolve a queue connection.
+ *
+ * @param  string  name
+ * @return QueueContract.Queue
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *QueueManager) resolve(name string) QueueContract.Queue {
+	config := this.getConfig(name)
+	if config == nil {
+		panic(Errors.NewInvalidArgumentException(fmt.Sprintf("The [%s] queue connection has not been configured.", name)))
+	}
+
+	driver := configString(config, "driver")
+	connector, ok := this.connectors[driver]
+	if !ok {
+		panic(Errors.NewInvalidArgumentException(fmt.Sprintf("No connector for [%s].", driver)))
+	}
+
+	connection := connector(config)
+	connection.SetConnectionName(name)
+	if c, ok := connection.(interface{ SetContainer(Container.Container) }); ok {
+		c.SetContainer(this.app)
+	}
+
+	return connection
+}
+
+/**
+ * Get the queue connection configuration.
+ *
+ * @param  string  name
+ * @return map[string]interface{}
+ */
+func (this *QueueManager) getConfig(name string) map[string]interface{} {
+	if config, ok := this.app.Make("config").(RepositoryContract.Repository); ok {
+		if connection, ok := config.Get("queue.connections." + name).(map[string]interface{}); ok {
+			return connection
+		}
+	}
+
+	// The sync connection is always there, so jobs run even without a config.
+	if name == "sync" {
+		return map[string]interface{}{"driver": "sync"}
+	}
+
+	return nil
+}
+
+/**
+ * Get the name of the default queue connection.
+ *
+ * @return string
+ */
+func (this *QueueManager) GetDefaultDriver() string {
+	if config, ok := this.app.Make("config").(RepositoryContract.Repository); ok {
+		if name, ok := config.Get("queue.default").(string); ok && name != "" {
+			return name
+		}
+	}
+	return "sync"
+}
+
+/**
+ * Get the size of a queue of the default connection.
+ *
+ * @param  string  queue
+ * @return int
+ */
+func (this *QueueManager) Size(queue ...string) int {
+	return this.Connection().Size(queue...)
+}
+
+/**
+ * Push a new job onto a queue of the default connection.
+ *
+ * @param  QueueContract.ShouldQueue  job
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *QueueManager) Push(job QueueContract.ShouldQueue, queue ...string) (string, error) {
+	return this.Connection().Push(job, queue...)
+}
+
+/**
+ * Push a raw payload onto a queue of the default connection.
+ *
+ * @param  string  payload
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *QueueManager) PushRaw(payload string, queue ...string) (string, error) {
+	return this.Connection().PushRaw(payload, queue...)
+}
+
+/**
+ * Push a new job onto a queue of the default connection after a delay.
+ *
+ * @param  time.Duration  delay
+ * @param  QueueContract.ShouldQueue  job
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *QueueManager) Later(delay time.Duration, job QueueContract.ShouldQueue, queue ...string) (string, error) {
+	return this.Connection().Later(delay, job, queue...)
+}
+
+/**
+ * Pop the next job off of a queue of the default connection.
+ *
+ * @param  string  queue
+ * @return QueueContract.Job, error
+ */
+func (this *QueueManager) Pop(queue ...string) (QueueContract.Job, error) {
+	return this.Connection().Pop(queue...)
+}
+
+/**
+ * Get a string from a configuration.
+ *
+ * @param  map[string]interface{}  config
+ * @param  string  key
+ * @return string
+ */
+func configString(config map[string]interface{}, key string) string {
+	if value, ok := config[key]; ok && value != nil {
+		return fmt.Sprint(value)
+	}
+	return ""
+}
+
+/**
+ * Get an integer from a configuration.
+ *
+ * @param  map[string]interface{}  config
+ * @param  string  key
+ * @return int
+ */
+func configInt(config map[string]interface{}, key string) int {
+	switch value := config[key].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	case string:
+		i, _ := strconv.Atoi(value)
+		return i
+	}
+	return 0
+}
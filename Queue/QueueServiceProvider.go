@@ -0,0 +1,98 @@
+package Queue
+
+import (
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	"github.com/larisgo/framework/Contracts/Container"
+	EventsContract "github.com/larisgo/framework/Contracts/Events"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"github.com/larisgo/framework/Queue/Failed"
+	"github.com/larisgo/framework/Support"
+)
+
+type QueueServiceProvider struct {
+	*Support.ServiceProvider
+}
+
+func NewQueueServiceProvider(app Foundation.Application) (this *QueueServiceProvider) {
+	this = &QueueServiceProvider{ServiceProvider: Support.NewServiceProvider(app)}
+	return this
+}
+
+/**
+ * Register the service provider.
+ *
+ * @return void
+ */
+func (this *QueueServiceProvider) Register() {
+	this.registerManager()
+	this.registerConnection()
+	this.registerWorker()
+	this.registerFailedJobServices()
+}
+
+/**
+ * Register the queue manager.
+ *
+ * @return void
+ */
+func (this *QueueServiceProvider) registerManager() {
+	this.App.Singleton("queue", func(app Container.Container) interface{} {
+		return NewQueueManager(this.App)
+	})
+}
+
+/**
+ * Register the default queue connection binding.
+ *
+ * @return void
+ */
+func (this *QueueServiceProvider) registerConnection() {
+	this.App.Singleton("queue.connection", func(app Container.Container) interface{} {
+		return app.Make("queue").(*QueueManager).Connection()
+	})
+}
+
+/**
+ * Register the queue worker.
+ *
+ * @return void
+ */
+func (this *QueueServiceProvider) registerWorker() {
+	this.App.Singleton("queue.worker", func(app Container.Container) interface{} {
+		events, _ := app.Make("events").(EventsContract.Dispatcher)
+
+		return NewWorker(
+			app.Make("queue").(*QueueManager),
+			events,
+			app.Make("queue.failer").(QueueContract.FailedJobProvider),
+		)
+	})
+}
+
+/**
+ * Register the failed job services, from the "queue.failed" configuration.
+ *
+ * @return void
+ */
+func (this *QueueServiceProvider) registerFailedJobServices() {
+	this.App.Singleton("queue.failer", func(app Container.Container) interface{} {
+		config := map[string]interface{}{}
+		if repository, ok := app.Make("config").(RepositoryContract.Repository); ok {
+			if failed, ok := repository.Get("queue.failed").(map[string]interface{}); ok {
+				config = failed
+			}
+		}
+
+		if configString(config, "driver") == "null" {
+			return Failed.NewNullFailedJobProvider()
+		}
+
+		path := configString(config, "path")
+		if path == "" {
+			path = this.App.StoragePath("framework/failed-jobs")
+		}
+
+		return Failed.NewFileFailedJobProvider(path)
+	})
+}
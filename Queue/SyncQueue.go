@@ -0,0 +1,116 @@
+package Queue
+
+import (
+	"context"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"time"
+)
+
+/**
+ * A queue that runs its jobs right away, in the goroutine pushing them.
+ */
+type SyncQueue struct {
+	*Queue
+}
+
+/**
+ * Create a new sync queue instance.
+ *
+ * @return *SyncQueue
+ */
+func NewSyncQueue() (this *SyncQueue) {
+	this = &SyncQueue{Queue: NewQueue("")}
+	return this
+}
+
+/**
+ * Get the size of the queue.
+ *
+ * @param  string  queue
+ * @return int
+ */
+func (this *SyncQueue) Size(queue ...string) int {
+	return 0
+}
+
+/**
+ * Push a new job onto the queue, running it right away.
+ *
+ * @param  QueueContract.ShouldQueue  job
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *SyncQueue) Push(job QueueContract.ShouldQueue, queue ...string) (string, error) {
+	payload, err := CreatePayload(job)
+	if err != nil {
+		return "", err
+	}
+	return this.PushRaw(payload.String(), queue...)
+}
+
+/**
+ * Push a raw payload onto the queue, running it right away.
+ *
+ * @param  string  raw
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *SyncQueue) PushRaw(raw string, queue ...string) (string, error) {
+	payload, err := ParsePayload(raw)
+	if err != nil {
+		return "", err
+	}
+	payload.Attempts++
+
+	job := newJob(this, this.container, payload.Uuid, payload, this.connectionName, this.getQueue(queue))
+
+	if err := fire(context.Background(), job); err != nil {
+		job.Fail(err)
+		return payload.Uuid, err
+	}
+
+	return payload.Uuid, nil
+}
+
+/**
+ * Push a new job onto the queue after a delay, the delay is ignored.
+ *
+ * @param  time.Duration  delay
+ * @param  QueueContract.ShouldQueue  job
+ * @param  string  queue
+ * @return string, error
+ */
+func (this *SyncQueue) Later(delay time.Duration, job QueueContract.ShouldQueue, queue ...string) (string, error) {
+	return this.Push(job, queue...)
+}
+
+/**
+ * Pop the next job off of the queue.
+ *
+ * @param  string  queue
+ * @return QueueContract.Job, error
+ */
+func (this *SyncQueue) Pop(queue ...string) (QueueContract.Job, error) {
+	return nil, nil
+}
+
+/**
+ * Delete a reserved job from the queue.
+ *
+ * @param  *Job  job
+ * @return error
+ */
+func (this *SyncQueue) deleteReserved(job *Job) error {
+	return nil
+}
+
+/**
+ * Release a reserved job back onto the queue.
+ *
+ * @param  *Job  job
+ * @param  time.Duration  delay
+ * @return error
+ */
+func (this *SyncQueue) release(job *Job, delay time.Duration) error {
+	return nil
+}
@@ -0,0 +1,342 @@
+package Queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	EventsContract "github.com/larisgo/framework/Contracts/Events"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Worker struct {
+	/**
+	 * The queue manager instance.
+	 *
+	 * @var *QueueManager
+	 */
+	manager *QueueManager
+
+	/**
+	 * The event dispatcher instance, nil when no events are fired.
+	 *
+	 * @var EventsContract.Dispatcher
+	 */
+	events EventsContract.Dispatcher
+
+	/**
+	 * The failed job provider instance.
+	 *
+	 * @var QueueContract.FailedJobProvider
+	 */
+	failer QueueContract.FailedJobProvider
+
+	/**
+	 * Set when a timed out job was abandoned while it was still running, after
+	 * which the worker takes no more jobs.
+	 *
+	 * @var int32
+	 */
+	abandoned int32
+}
+
+/**
+ * Create a new queue worker.
+ *
+ * @param  *QueueManager  manager
+ * @param  EventsContract.Dispatcher  events
+ * @param  QueueContract.FailedJobProvider  failer
+ * @return *Worker
+ */
+func NewWorker(manager *QueueManager, events EventsContract.Dispatcher, failer QueueContract.FailedJobProvider) (this *Worker) {
+	this = &Worker{}
+	this.manager = manager
+	this.events = events
+	this.failer = failer
+	return this
+}
+
+/**
+ * Listen to the given queues until the context is done. The queues are a
+ * comma separated list, checked in order for every job.
+ *
+ * Jobs that are running when the context is done are finished first. The
+ * worker stops with a status of 1 when it had to abandon a timed out job.
+ *
+ * @param  context.Context  ctx
+ * @param  string  connectionName
+ * @param  string  queue
+ * @param  *WorkerOptions  options
+ * @return int
+ */
+func (this *Worker) Daemon(ctx context.Context, connectionName string, queue string, options *WorkerOptions) int {
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var claimed int64
+	var wg sync.WaitGroup
+
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for ctx.Err() == nil && atomic.LoadInt32(&this.abandoned) == 0 {
+				// a slot is claimed before a job is popped, so the workers
+				// never process more jobs than they were told to
+				if options.MaxJobs > 0 && atomic.AddInt64(&claimed, 1) > int64(options.MaxJobs) {
+					return
+				}
+
+				if this.RunNextJob(connectionName, queue, options) {
+					continue
+				}
+
+				if options.MaxJobs > 0 {
+					atomic.AddInt64(&claimed, -1)
+				}
+				if options.StopWhenEmpty {
+					return
+				}
+
+				select {
+				case <-ctx.Done():
+				case <-time.After(options.Sleep):
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if atomic.LoadInt32(&this.abandoned) != 0 {
+		return 1
+	}
+
+	return 0
+}
+
+/**
+ * Process the next job on the queue, if there is one.
+ *
+ * @param  string  connectionName
+ * @param  string  queue
+ * @param  *WorkerOptions  options
+ * @return bool
+ */
+func (this *Worker) RunNextJob(connectionName string, queue string, options *WorkerOptions) bool {
+	job, err := this.getNextJob(this.manager.Connection(connectionName), queue)
+	if err != nil || job == nil {
+		return false
+	}
+
+	this.Process(connectionName, job, options)
+
+	return true
+}
+
+/**
+ * Get the next job from the queue connection.
+ *
+ * @param  QueueContract.Queue  connection
+ * @param  string  queue
+ * @return QueueContract.Job, error
+ */
+func (this *Worker) getNextJob(connection QueueContract.Queue, queue string) (QueueContract.Job, error) {
+	for _, name := range strings.Split(queue, ",") {
+		job, err := connection.Pop(strings.TrimSpace(name))
+		if err != nil || job != nil {
+			return job, err
+		}
+	}
+	return nil, nil
+}
+
+/**
+ * Process the given job from the queue.
+ *
+ * @param  string  connectionName
+ * @param  QueueContract.Job  job
+ * @param  *WorkerOptions  options
+ * @return void
+ */
+func (this *Worker) Process(connectionName string, job QueueContract.Job, options *WorkerOptions) {
+	// A job reserved again after its worker died may have used up its attempts
+	// already, in which case it is failed right away instead of run again.
+	if tries := this.maxTries(job, options); tries > 0 && job.Attempts() > tries {
+		this.failJob(connectionName, job, errors.New(fmt.Sprintf("%s has been attempted too many times.", job.GetName())))
+		return
+	}
+
+	this.dispatch(NewJobProcessing(connectionName, job))
+
+	abandoned, err := this.runJob(job, options)
+	if abandoned {
+		// The job is still running, so it is failed rather than released to be
+		// run a second time next to it, and the worker stops taking jobs.
+		atomic.StoreInt32(&this.abandoned, 1)
+		this.dispatch(NewJobExceptionOccurred(connectionName, job, err))
+		this.failJob(connectionName, job, err)
+		return
+	}
+	if err != nil {
+		this.handleJobException(connectionName, job, options, err)
+		return
+	}
+
+	if !job.IsDeletedOrReleased() {
+		job.Delete()
+	}
+
+	this.dispatch(NewJobProcessed(connectionName, job))
+}
+
+/**
+ * Run the job within its timeout.
+ *
+ * The context of the job is cancelled on timeout and the worker waits up to
+ * the stop timeout for the job to stop, so a job is never released while it
+ * is still running. A goroutine cannot be killed, so a job ignoring its
+ * context past the stop timeout is abandoned: it keeps running in the
+ * background and abandoned is returned as true.
+ *
+ * @param  QueueContract.Job  job
+ * @param  *WorkerOptions  options
+ * @return bool, error
+ */
+func (this *Worker) runJob(job QueueContract.Job, options *WorkerOptions) (abandoned bool, err error) {
+	timeout := job.Timeout()
+	if timeout <= 0 {
+		timeout = options.Timeout
+	}
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	}
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- fire(ctx, job)
+	}()
+
+	select {
+	case err := <-done:
+		return false, err
+	case <-ctx.Done():
+		cancel()
+	}
+
+	err = errors.New(fmt.Sprintf("%s has timed out.", job.GetName()))
+
+	select {
+	case <-done:
+		return false, err
+	case <-time.After(options.StopTimeout):
+		return true, err
+	}
+}
+
+/**
+ * Handle an error that occurred while the job was running.
+ *
+ * @param  string  connectionName
+ * @param  QueueContract.Job  job
+ * @param  *WorkerOptions  options
+ * @param  error  err
+ * @return void
+ */
+func (this *Worker) handleJobException(connectionName string, job QueueContract.Job, options *WorkerOptions, err error) {
+	this.dispatch(NewJobExceptionOccurred(connectionName, job, err))
+
+	if tries := this.maxTries(job, options); tries > 0 && job.Attempts() >= tries {
+		this.failJob(connectionName, job, err)
+		return
+	}
+
+	if !job.IsDeletedOrReleased() {
+		job.Release(this.calculateBackoff(job, options))
+	}
+}
+
+/**
+ * Fail the job, logging it into the failed job storage.
+ *
+ * @param  string  connectionName
+ * @param  QueueContract.Job  job
+ * @param  error  err
+ * @return void
+ */
+func (this *Worker) failJob(connectionName string, job QueueContract.Job, err error) {
+	job.Fail(err)
+
+	if this.failer != nil {
+		this.failer.Log(connectionName, job.GetQueue(), job.GetRawBody(), err)
+	}
+
+	this.dispatch(NewJobFailed(connectionName, job, err))
+}
+
+/**
+ * Get the number of times to attempt the job.
+ *
+ * @param  QueueContract.Job  job
+ * @param  *WorkerOptions  options
+ * @return int
+ */
+func (this *Worker) maxTries(job QueueContract.Job, options *WorkerOptions) int {
+	if tries := job.MaxTries(); tries > 0 {
+		return tries
+	}
+	return options.MaxTries
+}
+
+/**
+ * Calculate the delay before the next attempt of the job, from the backoff of
+ * the job or else doubling the backoff of the worker for every attempt.
+ *
+ * @param  QueueContract.Job  job
+ * @param  *WorkerOptions  options
+ * @return time.Duration
+ */
+func (this *Worker) calculateBackoff(job QueueContract.Job, options *WorkerOptions) time.Duration {
+	attempts := job.Attempts()
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	if backoff := job.Backoff(); len(backoff) > 0 {
+		if attempts > len(backoff) {
+			return time.Duration(backoff[len(backoff)-1]) * time.Second
+		}
+		return time.Duration(backoff[attempts-1]) * time.Second
+	}
+
+	delay := options.Backoff
+	for i := 1; i < attempts && (options.MaxBackoff <= 0 || delay < options.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if options.MaxBackoff > 0 && delay > options.MaxBackoff {
+		delay = options.MaxBackoff
+	}
+
+	return delay
+}
+
+/**
+ * Dispatch the given event when there is an event dispatcher.
+ *
+ * @param  interface{}  event
+ * @return void
+ */
+func (this *Worker) dispatch(event interface{}) {
+	if this.events != nil {
+		this.events.Dispatch(event)
+	}
+}
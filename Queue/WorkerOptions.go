@@ -0,0 +1,89 @@
+package Queue
+
+import (
+	"time"
+)
+
+type WorkerOptions struct {
+	/**
+	 * The number of jobs processed at the same time.
+	 *
+	 * @var int
+	 */
+	Concurrency int
+
+	/**
+	 * The time a job may run, unless the job decides.
+	 *
+	 * @var time.Duration
+	 */
+	Timeout time.Duration
+
+	/**
+	 * The time a timed out job is given to stop once its context is cancelled.
+	 * A job still running after that is abandoned and the worker stops.
+	 *
+	 * @var time.Duration
+	 */
+	StopTimeout time.Duration
+
+	/**
+	 * The number of times to attempt a job, unless the job decides. Zero
+	 * attempts a job until it succeeds.
+	 *
+	 * @var int
+	 */
+	MaxTries int
+
+	/**
+	 * The delay before the first retry of a job, doubled for every retry after.
+	 *
+	 * @var time.Duration
+	 */
+	Backoff time.Duration
+
+	/**
+	 * The longest delay before a retry of a job.
+	 *
+	 * @var time.Duration
+	 */
+	MaxBackoff time.Duration
+
+	/**
+	 * The time to sleep when no job is available.
+	 *
+	 * @var time.Duration
+	 */
+	Sleep time.Duration
+
+	/**
+	 * Indicates if the worker should stop when the queue is empty.
+	 *
+	 * @var bool
+	 */
+	StopWhenEmpty bool
+
+	/**
+	 * The number of jobs to process before stopping, zero for no limit.
+	 *
+	 * @var int
+	 */
+	MaxJobs int
+}
+
+/**
+ * Create a new worker options instance.
+ *
+ * @return *WorkerOptions
+ */
+func NewWorkerOptions() (this *WorkerOptions) {
+	this = &WorkerOptions{}
+	this.Concurrency = 1
+	this.Timeout = 60 * time.Second
+	this.StopTimeout = 5 * time.Second
+	this.MaxTries = 1
+	this.Backoff = time.Second
+	this.MaxBackoff = time.Hour
+	this.Sleep = 3 * time.Second
+	return this
+}
@@ -0,0 +1,120 @@
+package Queue
+
+import (
+	"context"
+	QueueContract "github.com/larisgo/framework/Contracts/Queue"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type slowJob struct {
+	QueueContract.Job
+
+	running int32
+}
+
+func (this *slowJob) Fire(ctx context.Context) error {
+	atomic.StoreInt32(&this.running, 1)
+	defer atomic.StoreInt32(&this.running, 0)
+
+	<-ctx.Done()
+	time.Sleep(50 * time.Millisecond)
+
+	return ctx.Err()
+}
+
+func (this *slowJob) Timeout() time.Duration {
+	return 10 * time.Millisecond
+}
+
+func (this *slowJob) GetName() string {
+	return "SlowJob"
+}
+
+func TestTimedOutJobHasStoppedWhenTheWorkerReturns(t *testing.T) {
+	job := &slowJob{}
+
+	abandoned, err := NewWorker(nil, nil, nil).runJob(job, NewWorkerOptions())
+	if err == nil {
+		t.Fatal("expected the job to time out")
+	}
+	if abandoned {
+		t.Fatal("the job stopped within the stop timeout and should not be abandoned")
+	}
+	if atomic.LoadInt32(&job.running) != 0 {
+		t.Fatal("the job was still running when the worker moved on")
+	}
+}
+
+type stubbornJob struct {
+	QueueContract.Job
+
+	release chan struct{}
+	failed  error
+}
+
+func (this *stubbornJob) Fire(context.Context) error {
+	<-this.release
+
+	return nil
+}
+
+func (this *stubbornJob) Timeout() time.Duration {
+	return 5 * time.Millisecond
+}
+
+func (this *stubbornJob) GetName() string {
+	return "StubbornJob"
+}
+
+func (this *stubbornJob) Attempts() int {
+	return 1
+}
+
+func (this *stubbornJob) MaxTries() int {
+	return 3
+}
+
+func (this *stubbornJob) Fail(err error) {
+	this.failed = err
+}
+
+func (this *stubbornJob) GetQueue() string {
+	return "default"
+}
+
+func (this *stubbornJob) GetRawBody() string {
+	return "{}"
+}
+
+func TestJobIgnoringItsContextIsAbandonedAfterTheStopTimeout(t *testing.T) {
+	job := &stubbornJob{release: make(chan struct{})}
+	defer close(job.release)
+
+	options := NewWorkerOptions()
+	options.StopTimeout = 10 * time.Millisecond
+
+	worker := NewWorker(nil, nil, nil)
+	finished := make(chan struct{})
+	go func() {
+		worker.Process("memory", job, options)
+		close(finished)
+	}()
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Fatal("the worker kept waiting for a job ignoring its context")
+	}
+
+	if job.failed == nil {
+		t.Error("expected the abandoned job to be failed instead of released")
+	}
+	if atomic.LoadInt32(&worker.abandoned) == 0 {
+		t.Error("expected the worker to stop taking jobs")
+	}
+	if status := worker.Daemon(context.Background(), "memory", "default", options); status != 1 {
+		t.Errorf("expected the worker to stop with a status of 1, got %d", status)
+	}
+}
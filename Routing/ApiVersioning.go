@@ -0,0 +1,338 @@
+package Routing
+
+import (
+	"github.com/larisgo/framework/Http"
+	"mime"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+/**
+ * A vendor media type, capturing its subtype without the "vnd." prefix and
+ * the structured syntax suffix, "app.v2" for "application/vnd.app.v2+json".
+ *
+ * @var *regexp.Regexp
+ */
+var vendorMediaTypePattern = regexp.MustCompile(`^application/vnd\.([^+]+)(?:\+[\w.-]+)?$`)
+
+type ApiDeprecation struct {
+	/**
+	 * The moment the version was deprecated, zero when it is unknown.
+	 *
+	 * @var time.Time
+	 */
+	DeprecatedAt time.Time
+
+	/**
+	 * The moment the version stops being served, zero when it is unknown.
+	 *
+	 * @var time.Time
+	 */
+	Sunset time.Time
+
+	/**
+	 * A link to the documentation of the deprecation.
+	 *
+	 * @var string
+	 */
+	Link string
+}
+
+/**
+ * Select the version of an API route from the URL prefix, a vendor media type
+ * in the Accept header ("application/vnd.app.v2+json") or a custom header.
+ *
+ * A route whose URI contains its version as a segment, "v1/users", is picked
+ * by the URL alone. Other versions of the same URI are picked by the version
+ * the request asks for, or by the default version when it asks for none.
+ */
+type ApiVersioning struct {
+	/**
+	 * The vendor of the media types, "app" for "application/vnd.app.v2+json".
+	 *
+	 * @var string
+	 */
+	vendor string
+
+	/**
+	 * The header that carries the requested version.
+	 *
+	 * @var string
+	 */
+	header string
+
+	/**
+	 * The version used when the request does not ask for one.
+	 *
+	 * @var string
+	 */
+	_default string
+
+	/**
+	 * The deprecated versions.
+	 *
+	 * @var map[string]ApiDeprecation
+	 */
+	deprecations map[string]ApiDeprecation
+
+	lock sync.RWMutex
+}
+
+/**
+ * Create a new API versioning instance.
+ *
+ * @return *ApiVersioning
+ */
+func NewApiVersioning() (this *ApiVersioning) {
+	this = &ApiVersioning{}
+	this.header = "Api-Version"
+	this.deprecations = map[string]ApiDeprecation{}
+	return this
+}
+
+/**
+ * Read the requested version from the vendor media types of the Accept header.
+ *
+ * @param  string  vendor
+ * @return this
+ */
+func (this *ApiVersioning) UseMediaType(vendor string) *ApiVersioning {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.vendor = vendor
+
+	return this
+}
+
+/**
+ * Read the requested version from the given header.
+ *
+ * @param  string  header
+ * @return this
+ */
+func (this *ApiVersioning) UseHeader(header string) *ApiVersioning {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.header = header
+
+	return this
+}
+
+/**
+ * Set the version used when the request does not ask for one.
+ *
+ * @param  string  version
+ * @return this
+ */
+func (this *ApiVersioning) Default(version string) *ApiVersioning {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this._default = version
+
+	return this
+}
+
+/**
+ * Get the version used when the request does not ask for one.
+ *
+ * @return string
+ */
+func (this *ApiVersioning) GetDefault() string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	return this._default
+}
+
+/**
+ * Mark the given version as deprecated.
+ *
+ * @param  string  version
+ * @param  ApiDeprecation  deprecation
+ * @return this
+ */
+func (this *ApiVersioning) Deprecate(version string, deprecation ...ApiDeprecation) *ApiVersioning {
+	deprecation = append(deprecation, ApiDeprecation{})
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.deprecations[this.normalize(version)] = deprecation[0]
+
+	return this
+}
+
+/**
+ * Get the deprecation of the given version.
+ *
+ * @param  string  version
+ * @return ApiDeprecation, bool
+ */
+func (this *ApiVersioning) GetDeprecation(version string) (ApiDeprecation, bool) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	deprecation, ok := this.deprecations[this.normalize(version)]
+	return deprecation, ok
+}
+
+/**
+ * Get the version the request asks for, or an empty string.
+ *
+ * @param  Http.Request  request
+ * @return string
+ */
+func (this *ApiVersioning) RequestedVersion(request *Http.Request) string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	if this.vendor != "" {
+		if version := this.versionFromAccept(request.Headers.Get("Accept")); version != "" {
+			return version
+		}
+	}
+
+	if this.header != "" {
+		return this.normalize(request.Headers.Get(this.header))
+	}
+
+	return ""
+}
+
+/**
+ * Get the version of the vendor media type in the Accept header, either as
+ * "application/vnd.app.v2+json" or "application/vnd.app+json; version=2".
+ *
+ * @param  string  accept
+ * @return string
+ */
+func (this *ApiVersioning) versionFromAccept(accept string) string {
+	vendor := strings.ToLower(this.vendor)
+
+	for _, item := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+		m := vendorMediaTypePattern.FindStringSubmatch(mediaType)
+		if m == nil {
+			continue
+		}
+		if strings.HasPrefix(m[1], vendor+".") && len(m[1]) > len(vendor)+1 {
+			return this.normalize(m[1][len(vendor)+1:])
+		}
+		if m[1] == vendor {
+			if version, ok := params["version"]; ok {
+				return this.normalize(version)
+			}
+		}
+	}
+
+	return ""
+}
+
+/**
+ * Determine if the request should be served by the given version of a route.
+ *
+ * @param  Route  route
+ * @param  Http.Request  request
+ * @return bool
+ */
+func (this *ApiVersioning) Matches(route *Route, request *Http.Request) bool {
+	version := this.normalize(route.GetVersion())
+
+	// The version in the URL was already matched by the path of the route, so
+	// there is nothing left to decide for routes versioned by their prefix.
+	if version == "" || this.isVersionedByUri(route) {
+		return true
+	}
+
+	if requested := this.RequestedVersion(request); requested != "" {
+		return requested == version
+	}
+
+	_default := this.normalize(this.GetDefault())
+
+	return _default == "" || _default == version
+}
+
+/**
+ * Determine if the URI of the route contains its version as a segment.
+ *
+ * @param  Route  route
+ * @return bool
+ */
+func (this *ApiVersioning) isVersionedByUri(route *Route) bool {
+	version := this.normalize(route.GetVersion())
+	for _, segment := range strings.Split(route.Uri(), "/") {
+		if this.normalize(segment) == version {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Add the version and deprecation headers to the response of a route.
+ *
+ * @param  Route  route
+ * @param  Http.Response  response
+ * @return Http.Response
+ */
+func (this *ApiVersioning) AddHeaders(route *Route, response *Http.Response) *Http.Response {
+	version := route.GetVersion()
+	if version == "" {
+		return response
+	}
+
+	response.Header("Api-Version", version)
+
+	// Versions picked by headers must not be mixed up by shared caches.
+	if !this.isVersionedByUri(route) {
+		this.lock.RLock()
+		if this.vendor != "" {
+			response.Header("Vary", "Accept", false)
+		}
+		if this.header != "" {
+			response.Header("Vary", this.header, false)
+		}
+		this.lock.RUnlock()
+	}
+
+	if deprecation, ok := this.GetDeprecation(version); ok {
+		if deprecation.DeprecatedAt.IsZero() {
+			response.Header("Deprecation", "true")
+		} else {
+			response.Header("Deprecation", "@"+strconv.FormatInt(deprecation.DeprecatedAt.Unix(), 10))
+		}
+		if !deprecation.Sunset.IsZero() {
+			response.Header("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+		}
+		if deprecation.Link != "" {
+			response.Header("Link", "<"+deprecation.Link+`>; rel="deprecation"`, false)
+		}
+	}
+
+	return response
+}
+
+/**
+ * Normalize a version, "V2", "v2" and "2" are the same version.
+ *
+ * @param  string  version
+ * @return string
+ */
+func (this *ApiVersioning) normalize(version string) string {
+	version = strings.ToLower(strings.TrimSpace(version))
+	if len(version) > 1 && version[0] == 'v' && version[1] >= '0' && version[1] <= '9' {
+		return version[1:]
+	}
+	return version
+}
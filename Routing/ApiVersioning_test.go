@@ -0,0 +1,36 @@
+package Routing
+
+import (
+	"testing"
+)
+
+func TestVersionFromAccept(t *testing.T) {
+	versioning := NewApiVersioning().UseMediaType("App")
+
+	for accept, expected := range map[string]string{
+		"application/vnd.app.v2+json":                     "2",
+		"application/vnd.app.v3":                          "3",
+		"application/vnd.app+json; version=4":             "4",
+		"text/html, application/vnd.app.v5+json;q=0.9":    "5",
+		"APPLICATION/VND.APP.V6+JSON":                     "6",
+		"application/vnd.app+json":                        "",
+		"application/vnd.app.+json":                       "",
+		"application/vnd.other.v2+json":                   "",
+		"application/vnd.application.v2+json":             "",
+		"application/json; version=2":                     "",
+		"application/vnd.other+json; version=2, text/csv": "",
+	} {
+		if version := versioning.versionFromAccept(accept); version != expected {
+			t.Errorf("versionFromAccept(%q) = %q, expected %q", accept, version, expected)
+		}
+	}
+}
+
+func BenchmarkVersionFromAccept(b *testing.B) {
+	versioning := NewApiVersioning().UseMediaType("app")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		versioning.versionFromAccept("text/html, application/vnd.app.v2+json")
+	}
+}
@@ -1,5 +1,11 @@
 package Routing
 
+import (
+	"encoding/json"
+	"regexp"
+	"sync"
+)
+
 type CompiledRoute struct {
 	variables     map[string]string
 	tokens        [][]string
@@ -9,6 +15,10 @@ type CompiledRoute struct {
 	hostVariables map[string]string
 	hostRegex     string
 	hostTokens    [][]string
+
+	pathRegexp *regexp.Regexp
+	hostRegexp *regexp.Regexp
+	compile    sync.Once
 }
 
 func NewCompiledRoute(staticPrefix string, regex string, tokens [][]string, pathVariables map[string]string, hostRegex string, hostTokens [][]string, hostVariables map[string]string, variables map[string]string) (this *CompiledRoute) {
@@ -25,6 +35,24 @@ func NewCompiledRoute(staticPrefix string, regex string, tokens [][]string, path
 	return this
 }
 
+/**
+ * Compile the regular expressions of the route.
+ *
+ * They are compiled on the first match instead of when the route is created,
+ * so loading a cache of many routes does not compile every one of them at boot,
+ * while matching a request and binding its parameters never compiles them twice.
+ *
+ * @return void
+ */
+func (this *CompiledRoute) compileRegexps() {
+	this.compile.Do(func() {
+		this.pathRegexp = regexp.MustCompile(this.regex)
+		if this.hostRegex != "" {
+			this.hostRegexp = regexp.MustCompile(this.hostRegex)
+		}
+	})
+}
+
 /**
  * Returns the static prefix.
  *
@@ -43,6 +71,17 @@ func (this *CompiledRoute) GetRegex() string {
 	return this.regex
 }
 
+/**
+ * Returns the compiled regex.
+ *
+ * @return *regexp.Regexp The compiled regex
+ */
+func (this *CompiledRoute) GetPathRegexp() *regexp.Regexp {
+	this.compileRegexps()
+
+	return this.pathRegexp
+}
+
 /**
  * Returns the host regex.
  *
@@ -52,6 +91,17 @@ func (this *CompiledRoute) GetHostRegex() string {
 	return this.hostRegex
 }
 
+/**
+ * Returns the compiled host regex.
+ *
+ * @return *regexp.Regexp|nil The compiled host regex or nil
+ */
+func (this *CompiledRoute) GetHostRegexp() *regexp.Regexp {
+	this.compileRegexps()
+
+	return this.hostRegexp
+}
+
 /**
  * Returns the tokens.
  *
@@ -96,3 +146,58 @@ func (this *CompiledRoute) GetPathVariables() map[string]string {
 func (this *CompiledRoute) GetHostVariables() map[string]string {
 	return this.hostVariables
 }
+
+/**
+ * The serialized form of a compiled route.
+ */
+type compiledRouteData struct {
+	Variables     map[string]string `json:"vars"`
+	Tokens        [][]string        `json:"path_tokens"`
+	StaticPrefix  string            `json:"path_prefix"`
+	Regex         string            `json:"path_regex"`
+	PathVariables map[string]string `json:"path_vars"`
+	HostVariables map[string]string `json:"host_vars"`
+	HostRegex     string            `json:"host_regex"`
+	HostTokens    [][]string        `json:"host_tokens"`
+}
+
+/**
+ * Serialize the compiled route.
+ *
+ * @return []byte
+ */
+func (this *CompiledRoute) MarshalJSON() ([]byte, error) {
+	return json.Marshal(compiledRouteData{
+		Variables:     this.variables,
+		Tokens:        this.tokens,
+		StaticPrefix:  this.staticPrefix,
+		Regex:         this.regex,
+		PathVariables: this.pathVariables,
+		HostVariables: this.hostVariables,
+		HostRegex:     this.hostRegex,
+		HostTokens:    this.hostTokens,
+	})
+}
+
+/**
+ * Unserialize a compiled route.
+ *
+ * @param  []byte  data
+ * @return error
+ */
+func (this *CompiledRoute) UnmarshalJSON(data []byte) error {
+	var _data compiledRouteData
+	if err := json.Unmarshal(data, &_data); err != nil {
+		return err
+	}
+	this.staticPrefix = _data.StaticPrefix
+	this.regex = _data.Regex
+	this.tokens = _data.Tokens
+	this.pathVariables = _data.PathVariables
+	this.hostRegex = _data.HostRegex
+	this.hostTokens = _data.HostTokens
+	this.hostVariables = _data.HostVariables
+	this.variables = _data.Variables
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package Console
+
+import (
+	"github.com/larisgo/framework/Console"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Routing"
+)
+
+type RouteCacheCommand struct {
+	*Console.Command
+	App Foundation.Application `inject:"app"`
+}
+
+func NewRouteCacheCommand() (this *RouteCacheCommand) {
+	this = &RouteCacheCommand{}
+	this.Command = Console.NewCommand("route:cache", "Create a route cache file for faster route registration")
+	return this
+}
+
+/**
+ * Execute the console command.
+ *
+ * @return int
+ */
+func (this *RouteCacheCommand) Handle() int {
+	this.Call("route:clear")
+
+	routes := this.App.Make("router").(*Routing.Router).GetRoutes()
+
+	if routes.Count() == 0 {
+		this.Error("Your application doesn't have any routes.")
+		return 1
+	}
+
+	if err := Routing.NewRouteCache(routes).Save(this.App.GetCachedRoutesPath()); err != nil {
+		this.Error(err.Error())
+		return 1
+	}
+
+	this.Info("Routes cached successfully!")
+
+	return 0
+}
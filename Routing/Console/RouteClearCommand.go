@@ -0,0 +1,34 @@
+package Console
+
+import (
+	"github.com/larisgo/framework/Console"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"os"
+)
+
+type RouteClearCommand struct {
+	*Console.Command
+	App Foundation.Application `inject:"app"`
+}
+
+func NewRouteClearCommand() (this *RouteClearCommand) {
+	this = &RouteClearCommand{}
+	this.Command = Console.NewCommand("route:clear", "Remove the route cache file")
+	return this
+}
+
+/**
+ * Execute the console command.
+ *
+ * @return int
+ */
+func (this *RouteClearCommand) Handle() int {
+	if err := os.Remove(this.App.GetCachedRoutesPath()); err != nil && !os.IsNotExist(err) {
+		this.Error(err.Error())
+		return 1
+	}
+
+	this.Info("Route cache cleared!")
+
+	return 0
+}
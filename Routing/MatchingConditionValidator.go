@@ -0,0 +1,21 @@
+package Routing
+
+import (
+	"github.com/larisgo/framework/Http"
+)
+
+type ConditionValidator struct {
+}
+
+func NewConditionValidator() ValidatorInterface {
+	return ConditionValidator{}
+}
+
+func (this ConditionValidator) matches(route *Route, request *Http.Request) bool {
+	for _, condition := range route.GetConditions() {
+		if !condition(request) {
+			return false
+		}
+	}
+	return true
+}
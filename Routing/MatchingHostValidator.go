@@ -2,7 +2,6 @@ package Routing
 
 import (
 	"github.com/larisgo/framework/Http"
-	"regexp"
 )
 
 type HostValidator struct {
@@ -13,10 +12,10 @@ func NewHostValidator() ValidatorInterface {
 }
 
 func (this HostValidator) matches(route *Route, request *Http.Request) bool {
-	_regex := route.GetCompiled().GetHostRegex()
-	if _regex == "" {
+	_regexp := route.GetCompiled().GetHostRegexp()
+	if _regexp == nil {
 		return true
 	}
 
-	return regexp.MustCompile(_regex).MatchString(request.GetHost())
+	return _regexp.MatchString(request.GetHost())
 }
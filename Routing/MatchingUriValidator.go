@@ -2,7 +2,6 @@ package Routing
 
 import (
 	"github.com/larisgo/framework/Http"
-	"regexp"
 )
 
 type UriValidator struct {
@@ -20,5 +19,5 @@ func (this UriValidator) matches(route *Route, request *Http.Request) bool {
 		path = "/" + path
 	}
 
-	return regexp.MustCompile(route.GetCompiled().GetRegex()).MatchString(path)
+	return route.GetCompiled().GetPathRegexp().MatchString(path)
 }
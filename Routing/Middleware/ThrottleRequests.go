@@ -0,0 +1,193 @@
+package Middleware
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"github.com/larisgo/framework/Cache"
+	"github.com/larisgo/framework/Cache/RateLimiting"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+	"github.com/larisgo/framework/Routing"
+	"strconv"
+	"time"
+)
+
+/**
+ * Limit the number of requests a client may send to a route, either with a
+ * named limiter, "throttle:api", or a fixed rate, "throttle:60,1" for sixty
+ * requests per minute.
+ */
+type ThrottleRequests struct {
+	/**
+	 * The rate limiter instance.
+	 *
+	 * @var *Cache.RateLimiter
+	 */
+	Limiter *Cache.RateLimiter `inject:"limiter"`
+}
+
+/**
+ * Create a new request throttler.
+ *
+ * @param  *Cache.RateLimiter  limiter
+ * @return *ThrottleRequests
+ */
+func NewThrottleRequests(limiter *Cache.RateLimiter) (this *ThrottleRequests) {
+	this = &ThrottleRequests{}
+	this.Limiter = limiter
+	return this
+}
+
+/**
+ * Handle an incoming request.
+ *
+ * @param  Http.Request  request
+ * @param  Http.Next  next
+ * @param  string  parameters
+ * @return Http.Response
+ *
+ * @throws Errors.ThrottleRequestsException
+ */
+func (this *ThrottleRequests) Handle(request *Http.Request, next Http.Next, parameters ...string) *Http.Response {
+	parameters = append(parameters, "60", "1", "")
+
+	if _, err := strconv.Atoi(parameters[0]); err != nil {
+		return this.handleRequestUsingNamedLimiter(request, next, parameters[0])
+	}
+
+	maxAttempts, _ := strconv.Atoi(parameters[0])
+	decayMinutes, err := strconv.Atoi(parameters[1])
+	if err != nil {
+		decayMinutes = 1
+	}
+
+	limit := RateLimiting.PerMinute(maxAttempts, decayMinutes).By(parameters[2] + this.resolveRequestSignature(request))
+
+	return this.handleRequest(request, next, limit)
+}
+
+/**
+ * Handle an incoming request using the limit of the named limiter.
+ *
+ * @param  Http.Request  request
+ * @param  Http.Next  next
+ * @param  string  name
+ * @return Http.Response
+ */
+func (this *ThrottleRequests) handleRequestUsingNamedLimiter(request *Http.Request, next Http.Next, name string) *Http.Response {
+	limiter := this.Limiter.Limiter(name)
+	if limiter == nil {
+		panic(Errors.NewInvalidArgumentException(fmt.Sprintf("Rate limiter [%s] is not defined.", name)))
+	}
+
+	limit := limiter(request)
+	if limit == nil || limit.Unlimited() {
+		return next(request)
+	}
+
+	// Limits without a key of their own fall back to the route and the client
+	// address, so one client can't use up the attempts of everybody else.
+	key := limit.Key
+	if key == "" {
+		key = this.resolveRequestSignature(request)
+	}
+
+	return this.handleRequest(request, next, RateLimiting.NewLimit(name+":"+key, limit.MaxAttempts, limit.DecaySeconds).Response(limit.ResponseCallback))
+}
+
+/**
+ * Handle an incoming request within the given limit.
+ *
+ * @param  Http.Request  request
+ * @param  Http.Next  next
+ * @param  *RateLimiting.Limit  limit
+ * @return Http.Response
+ *
+ * @throws Errors.ThrottleRequestsException
+ */
+func (this *ThrottleRequests) handleRequest(request *Http.Request, next Http.Next, limit *RateLimiting.Limit) *Http.Response {
+	key := this.hash(limit.Key)
+
+	if !this.Limiter.Acquire(key, limit.MaxAttempts, limit.DecaySeconds) {
+		headers := this.getHeaders(limit.MaxAttempts, 0, this.Limiter.AvailableIn(key))
+
+		if limit.ResponseCallback != nil {
+			return this.addHeaders(limit.ResponseCallback(request, headers), headers)
+		}
+
+		panic(Errors.NewThrottleRequestsException("Too Many Attempts.", headers))
+	}
+
+	response := next(request)
+
+	return this.addHeaders(response, this.getHeaders(limit.MaxAttempts, this.Limiter.RemainingAttempts(key, limit.MaxAttempts), -1))
+}
+
+/**
+ * Resolve request signature, made of the domain and URI of the route and the
+ * client address, so each route is limited separately for every client.
+ *
+ * @param  Http.Request  request
+ * @return string
+ *
+ * @throws Errors.RuntimeException
+ */
+func (this *ThrottleRequests) resolveRequestSignature(request *Http.Request) string {
+	route, ok := request.Route().(*Routing.Route)
+	if !ok || route == nil {
+		panic(Errors.NewRuntimeException("Unable to generate the request signature. Route unavailable."))
+	}
+
+	return route.GetDomain() + "|" + route.Uri() + "|" + request.Ip()
+}
+
+/**
+ * Get the rate limiting headers.
+ *
+ * @param  int  maxAttempts
+ * @param  int  remainingAttempts
+ * @param  int  retryAfter
+ * @return map[string][]string
+ */
+func (this *ThrottleRequests) getHeaders(maxAttempts int, remainingAttempts int, retryAfter int) map[string][]string {
+	headers := map[string][]string{
+		"X-Ratelimit-Limit":     {strconv.Itoa(maxAttempts)},
+		"X-Ratelimit-Remaining": {strconv.Itoa(remainingAttempts)},
+	}
+
+	if retryAfter >= 0 {
+		headers["Retry-After"] = []string{strconv.Itoa(retryAfter)}
+		headers["X-Ratelimit-Reset"] = []string{strconv.FormatInt(time.Now().Unix()+int64(retryAfter), 10)}
+	}
+
+	return headers
+}
+
+/**
+ * Add the limit header information to the given response.
+ *
+ * @param  Http.Response  response
+ * @param  map[string][]string  headers
+ * @return Http.Response
+ */
+func (this *ThrottleRequests) addHeaders(response *Http.Response, headers map[string][]string) *Http.Response {
+	for key, values := range headers {
+		for _, value := range values {
+			response.Header(key, value)
+		}
+	}
+
+	return response
+}
+
+/**
+ * Hash the key of a limit.
+ *
+ * @param  string  key
+ * @return string
+ */
+func (this *ThrottleRequests) hash(key string) string {
+	sum := sha1.Sum([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,56 @@
+package Middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larisgo/framework/Cache"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+	"github.com/larisgo/framework/Routing"
+)
+
+func respond(request *Http.Request) *Http.Response {
+	return Http.NewResponse("ok", Http.HTTP_OK)
+}
+
+func throttle(t *testing.T, middleware *ThrottleRequests, router *Routing.Router, target string) (throttled bool) {
+	t.Helper()
+
+	request := Http.NewRequest(nil, httptest.NewRecorder(), httptest.NewRequest("GET", target, nil))
+	route := router.GetRoutes().Match(request)
+	request.SetRouteResolver(func() interface{} {
+		return route
+	})
+
+	defer func() {
+		if err := recover(); err != nil {
+			if _, ok := err.(Errors.ThrottleRequestsException); !ok {
+				t.Fatalf("unexpected panic: %v", err)
+			}
+			throttled = true
+		}
+	}()
+
+	middleware.Handle(request, respond, "1", "1")
+
+	return false
+}
+
+func TestEachRouteIsThrottledSeparatelyForTheSameClient(t *testing.T) {
+	router := Routing.NewRouter(nil)
+	router.Get("a", respond)
+	router.Get("b", respond)
+
+	middleware := NewThrottleRequests(Cache.NewRateLimiter(Cache.NewArrayStore()))
+
+	if throttle(t, middleware, router, "/a") {
+		t.Fatal("the first request to /a was throttled")
+	}
+	if throttle(t, middleware, router, "/b") {
+		t.Fatal("the first request to /b was throttled by the attempts on /a")
+	}
+	if !throttle(t, middleware, router, "/a") {
+		t.Fatal("the second request to /a was not throttled")
+	}
+}
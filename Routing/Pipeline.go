@@ -0,0 +1,148 @@
+package Routing
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+	"strings"
+)
+
+type Pipeline struct {
+	/**
+	 * The container implementation.
+	 *
+	 * @var Container.Container
+	 */
+	container Container.Container
+
+	/**
+	 * The request being passed through the pipeline.
+	 *
+	 * @var Http.Request
+	 */
+	passable *Http.Request
+
+	/**
+	 * The array of middleware.
+	 *
+	 * @var []interface{}
+	 */
+	pipes []interface{}
+}
+
+/**
+ * Create a new middleware pipeline.
+ *
+ * @param  Container.Container  container
+ * @return *Pipeline
+ */
+func NewPipeline(container Container.Container) (this *Pipeline) {
+	this = &Pipeline{}
+	this.container = container
+	this.pipes = []interface{}{}
+	return this
+}
+
+/**
+ * Set the request being sent through the pipeline.
+ *
+ * @param  Http.Request  passable
+ * @return this
+ */
+func (this *Pipeline) Send(passable *Http.Request) *Pipeline {
+	this.passable = passable
+
+	return this
+}
+
+/**
+ * Set the array of middleware.
+ *
+ * Each pipe may be an Http.Middleware, a middleware function, or the name of
+ * a middleware bound in the container optionally followed by ":" and a comma
+ * separated list of parameters.
+ *
+ * @param  []interface{}  pipes
+ * @return this
+ */
+func (this *Pipeline) Through(pipes []interface{}) *Pipeline {
+	this.pipes = pipes
+
+	return this
+}
+
+/**
+ * Run the pipeline with a final destination callback.
+ *
+ * @param  Http.Next  destination
+ * @return Http.Response
+ */
+func (this *Pipeline) Then(destination Http.Next) *Http.Response {
+	pipeline := destination
+	for i := len(this.pipes) - 1; i >= 0; i -= 1 {
+		pipeline = this.carry(pipeline, this.pipes[i])
+	}
+
+	return pipeline(this.passable)
+}
+
+/**
+ * Get a closure that represents a slice of the application onion.
+ *
+ * @param  Http.Next  stack
+ * @param  interface{}  pipe
+ * @return Http.Next
+ */
+func (this *Pipeline) carry(stack Http.Next, pipe interface{}) Http.Next {
+	return func(passable *Http.Request) *Http.Response {
+		middleware, parameters := this.resolve(pipe)
+
+		return middleware.Handle(passable, stack, parameters...)
+	}
+}
+
+/**
+ * Resolve the given pipe into a middleware instance and its parameters.
+ *
+ * @param  interface{}  pipe
+ * @return Http.Middleware, []string
+ */
+func (this *Pipeline) resolve(pipe interface{}) (Http.Middleware, []string) {
+	switch _pipe := pipe.(type) {
+	case Http.Middleware:
+		return _pipe, []string{}
+	case func(*Http.Request, Http.Next, ...string) *Http.Response:
+		return Http.MiddlewareFunc(_pipe), []string{}
+	case string:
+		// If the pipe is a string we will parse the string and resolve the class out
+		// of the dependency injection container. We can then build a callable and
+		// execute the pipe function giving in the parameters that are required.
+		name, parameters := this.parsePipeString(_pipe)
+
+		if this.container != nil {
+			if middleware, ok := this.container.Make(name).(Http.Middleware); ok {
+				return middleware, parameters
+			}
+		}
+
+		panic(Errors.NewBindingResolutionException(fmt.Sprintf(`Middleware [%s] is not bound to an Http.Middleware.`, name)))
+	}
+
+	panic(Errors.NewInvalidArgumentException(fmt.Sprintf(`Middleware [%T] is not an Http.Middleware.`, pipe)))
+}
+
+/**
+ * Parse full pipe string to get name and parameters.
+ *
+ * @param  string  pipe
+ * @return string, []string
+ */
+func (this *Pipeline) parsePipeString(pipe string) (string, []string) {
+	segments := strings.SplitN(pipe, ":", 2)
+	if len(segments) == 1 || segments[1] == "" {
+		return segments[0], []string{}
+	}
+
+	return segments[0], strings.Split(segments[1], ",")
+}
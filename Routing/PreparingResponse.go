@@ -0,0 +1,32 @@
+package Routing
+
+import (
+	"github.com/larisgo/framework/Http"
+)
+
+type PreparingResponse struct {
+	/**
+	 * The request instance.
+	 *
+	 * @var *Http.Request
+	 */
+	Request *Http.Request
+
+	/**
+	 * The response instance.
+	 *
+	 * @var *Http.Response
+	 */
+	Response *Http.Response
+}
+
+/**
+ * Create a new event instance.
+ *
+ * @param  *Http.Request  request
+ * @param  *Http.Response  response
+ * @return *PreparingResponse
+ */
+func NewPreparingResponse(request *Http.Request, response *Http.Response) *PreparingResponse {
+	return &PreparingResponse{Request: request, Response: response}
+}
@@ -0,0 +1,81 @@
+package Routing
+
+import (
+	"github.com/larisgo/framework/Http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/**
+ * The placeholders of a redirect destination, with the slash before them,
+ * such as "/{page}" or "/{page?}".
+ *
+ * @var *regexp.Regexp
+ */
+var redirectPlaceholderPattern = regexp.MustCompile(`(/?)\{(\w+)\??\}`)
+
+type RedirectController struct {
+}
+
+/**
+ * Create a new redirect controller instance.
+ *
+ * @return *RedirectController
+ */
+func NewRedirectController() *RedirectController {
+	return &RedirectController{}
+}
+
+/**
+ * Redirect to the destination of the route, with the route parameters
+ * substituted into its placeholders.
+ *
+ * @param  Http.Request  request
+ * @return Http.Response
+ */
+func (this *RedirectController) Handle(request *Http.Request) *Http.Response {
+	destination := request.Attributes.Get("destination")
+
+	status, err := strconv.Atoi(request.Attributes.Get("status"))
+	if err != nil {
+		status = Http.HTTP_FOUND
+	}
+
+	return Http.NewRedirectResponse(this.substituteParameters(destination, request), status)
+}
+
+/**
+ * Replace the placeholders of the destination with the route parameters.
+ *
+ * Placeholders without a value are removed along with the slash before them,
+ * so "/new/{page?}" redirects to "/new" when the page is missing.
+ *
+ * @param  string  destination
+ * @param  Http.Request  request
+ * @return string
+ */
+func (this *RedirectController) substituteParameters(destination string, request *Http.Request) string {
+	var substituted strings.Builder
+	last := 0
+
+	// The submatches of every placeholder are found along with it, so they do
+	// not have to be matched again for each placeholder that is replaced.
+	for _, m := range redirectPlaceholderPattern.FindAllStringSubmatchIndex(destination, -1) {
+		substituted.WriteString(destination[last:m[0]])
+		last = m[1]
+
+		slash, name := destination[m[2]:m[3]], destination[m[4]:m[5]]
+		if name == "destination" || name == "status" {
+			substituted.WriteString(destination[m[0]:m[1]])
+			continue
+		}
+		if value := request.Attributes.Get(name); value != "" {
+			substituted.WriteString(slash + url.PathEscape(value))
+		}
+	}
+	substituted.WriteString(destination[last:])
+
+	return substituted.String()
+}
@@ -0,0 +1,23 @@
+package Routing
+
+import (
+	"testing"
+)
+
+func TestRedirectSubstitutesTheRouteParameters(t *testing.T) {
+	router := NewRouter(nil)
+	router.Redirect("docs/{section}/{page?}", "/manual/{section}/{page?}#top")
+	router.PermanentRedirect("blog/{slug}", "https://example.com/{destination}/{slug}")
+
+	for target, expected := range map[string]string{
+		"/docs/routing/redirects": "/manual/routing/redirects#top",
+		"/docs/routing":           "/manual/routing#top",
+		"/docs/a%20b/c":           "/manual/a%20b/c#top",
+		"/blog/hello":             "https://example.com/{destination}/hello",
+	} {
+		response := router.Dispatch(newTestRequest("GET", target))
+		if location := response.Headers.Get("Location"); location != expected {
+			t.Errorf("%s: expected a redirect to %s, got %s", target, expected, location)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package Routing
+
+import (
+	"github.com/larisgo/framework/Http"
+	"github.com/larisgo/framework/Http/HttpFoundation"
+	"time"
+)
+
+type ResponseFactory struct {
+	/**
+	 * The URL generator instance.
+	 *
+	 * @var *UrlGenerator
+	 */
+	url *UrlGenerator
+}
+
+/**
+ * Create a new response factory instance.
+ *
+ * @param  *UrlGenerator  url
+ * @return *ResponseFactory
+ */
+func NewResponseFactory(url *UrlGenerator) (this *ResponseFactory) {
+	this = &ResponseFactory{}
+	this.url = url
+	return this
+}
+
+/**
+ * Create a new response instance. Maps, slices and structs are sent as JSON.
+ *
+ * @param  interface{}  content
+ * @param  int  status
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) Make(content interface{}, status ...int) *Http.Response {
+	status = append(status, Http.HTTP_OK)
+
+	if content == nil {
+		content = ""
+	}
+
+	return Http.NewResponse(content, status[0])
+}
+
+/**
+ * Create a new "no content" response.
+ *
+ * @param  int  status
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) NoContent(status ...int) *Http.Response {
+	status = append(status, Http.HTTP_NO_CONTENT)
+
+	return Http.NewResponse("", status[0])
+}
+
+/**
+ * Create a new JSON response instance.
+ *
+ * @param  interface{}  data
+ * @param  int  status
+ * @param  int  options  A bit field of Http.JSON_* options
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) Json(data interface{}, status int, options ...int) *Http.Response {
+	options = append(options, 0)
+
+	return Http.NewJsonResponse(data, status, options[0])
+}
+
+/**
+ * Create a new JSONP response instance.
+ *
+ * @param  string  callback
+ * @param  interface{}  data
+ * @param  int  status
+ * @param  int  options  A bit field of Http.JSON_* options
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) Jsonp(callback string, data interface{}, status int, options ...int) *Http.Response {
+	return this.Json(data, status, options...).WithCallback(callback)
+}
+
+/**
+ * Create a new streamed response instance.
+ *
+ * @param  func(*Http.StreamWriter)  callback
+ * @param  int  status
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) Stream(callback func(*Http.StreamWriter), status ...int) *Http.Response {
+	status = append(status, Http.HTTP_OK)
+
+	return Http.NewStreamedResponse(callback, status[0])
+}
+
+/**
+ * Create a new response streaming server-sent events.
+ *
+ * @param  func(*Http.EventStream)  callback
+ * @param  time.Duration  heartbeat
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) EventStream(callback func(*Http.EventStream), heartbeat ...time.Duration) *Http.Response {
+	heartbeat = append(heartbeat, 0)
+
+	return Http.NewEventStreamResponse(callback, heartbeat[0])
+}
+
+/**
+ * Create a new file download response.
+ *
+ * @param  string  file
+ * @param  string  name  The name the file is downloaded as, the name of the file when empty
+ * @param  map[string][]string  headers
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) Download(file string, name string, headers ...map[string][]string) *Http.Response {
+	return Http.NewBinaryFileResponse(file, Http.HTTP_OK, headers...).SetContentDisposition(HttpFoundation.DISPOSITION_ATTACHMENT, name)
+}
+
+/**
+ * Return the raw contents of a binary file.
+ *
+ * @param  string  file
+ * @param  map[string][]string  headers
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) File(file string, headers ...map[string][]string) *Http.Response {
+	return Http.NewBinaryFileResponse(file, Http.HTTP_OK, headers...)
+}
+
+/**
+ * Create a new redirect response to the given path.
+ *
+ * @param  string  path
+ * @param  int  status
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) Redirect(path string, status ...int) *Http.Response {
+	status = append(status, Http.HTTP_FOUND)
+
+	return Http.NewRedirectResponse(this.url.To(path), status[0])
+}
+
+/**
+ * Create a new redirect response to a named route.
+ *
+ * @param  string  route
+ * @param  map[string]interface{}  parameters
+ * @param  int  status
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) RedirectToRoute(route string, parameters map[string]interface{}, status ...int) *Http.Response {
+	status = append(status, Http.HTTP_FOUND)
+
+	return Http.NewRedirectResponse(this.url.Route(route, parameters), status[0])
+}
+
+/**
+ * Create a new redirect response to the previous location.
+ *
+ * @param  Http.Request  request
+ * @param  int  status
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) Back(request *Http.Request, status ...int) *Http.Response {
+	status = append(status, Http.HTTP_FOUND)
+
+	return Http.NewRedirectResponse(this.url.Previous(request), status[0])
+}
+
+/**
+ * Create a new "created" response, pointing to the location of the created
+ * resource.
+ *
+ * @param  string  location
+ * @param  interface{}  body
+ * @return *Http.Response
+ */
+func (this *ResponseFactory) Created(location string, body ...interface{}) *Http.Response {
+	body = append(body, nil)
+
+	response := this.Make(body[0], Http.HTTP_CREATED)
+	if location != "" {
+		response.Header("Location", this.url.To(location))
+	}
+
+	return response
+}
@@ -0,0 +1,32 @@
+package Routing
+
+import (
+	"github.com/larisgo/framework/Http"
+)
+
+type ResponsePrepared struct {
+	/**
+	 * The request instance.
+	 *
+	 * @var *Http.Request
+	 */
+	Request *Http.Request
+
+	/**
+	 * The response instance.
+	 *
+	 * @var *Http.Response
+	 */
+	Response *Http.Response
+}
+
+/**
+ * Create a new event instance.
+ *
+ * @param  *Http.Request  request
+ * @param  *Http.Response  response
+ * @return *ResponsePrepared
+ */
+func NewResponsePrepared(request *Http.Request, response *Http.Response) *ResponsePrepared {
+	return &ResponsePrepared{Request: request, Response: response}
+}
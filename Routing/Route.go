@@ -21,12 +21,28 @@ type Route struct {
 	router         *Router
 	http           bool
 	https          bool
+
+	/**
+	 * The conditions the request must satisfy to match the route.
+	 *
+	 * @var []func(*Http.Request) bool
+	 */
+	conditions []func(*Http.Request) bool
+
+	/**
+	 * The expressions of the conditions added through Condition.
+	 *
+	 * @var []string
+	 */
+	expressions []string
 }
 
 func NewRoute(methods map[string]bool, uri string, action Action) (this *Route) {
 	this = &Route{}
 	this.uri = uri
 	this.methods = methods
+	this.defaults = map[string]string{}
+	this.wheres = map[string]string{}
 	this.Action = this.parseAction(action)
 
 	_, HasGET := this.methods["GET"]
@@ -117,8 +133,13 @@ func (this *Route) compileRoute() *CompiledRoute {
 func (this *Route) Bind(request *Http.Request) *Route {
 	this.compileRoute()
 
-	for key, value := range NewRouteParameterBinder(this).Parameters(request) {
+	binder := NewRouteParameterBinder(this)
+	for key, value := range binder.Parameters(request) {
 		request.Attributes.Add(key, value)
+		request.SetRouteParameter(key, value)
+	}
+	for key, value := range binder.HostParameters(request) {
+		request.SetHostParameter(key, value)
 	}
 
 	return this
@@ -208,6 +229,79 @@ func (this *Route) WhereArray(expressions map[string]string) *Route {
 	return this
 }
 
+/**
+ * Add a predicate the request must satisfy to match the route.
+ *
+ * @param  func(*Http.Request) bool  condition
+ * @return this
+ */
+func (this *Route) When(condition func(*Http.Request) bool) *Route {
+	this.conditions = append(this.conditions, condition)
+
+	return this
+}
+
+/**
+ * Add a condition expression the request must satisfy to match the route.
+ *
+ * @param  string  expression
+ * @return this
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *Route) Condition(expression string) *Route {
+	this.expressions = append(this.expressions, expression)
+
+	return this.When(NewRouteCondition(expression).Evaluate)
+}
+
+/**
+ * Get the conditions the request must satisfy to match the route.
+ *
+ * @return []func(*Http.Request) bool
+ */
+func (this *Route) GetConditions() []func(*Http.Request) bool {
+	return this.conditions
+}
+
+/**
+ * Get the condition expressions of the route.
+ *
+ * @return string
+ */
+func (this *Route) GetCondition() string {
+	if len(this.expressions) == 0 {
+		return ""
+	}
+	return "(" + strings.Join(this.expressions, ") and (") + ")"
+}
+
+/**
+ * Set the API version served by the route.
+ *
+ * @param  string  version
+ * @return this
+ */
+func (this *Route) Version(version string) *Route {
+	if this.Action.Version == "" {
+		this.When(func(request *Http.Request) bool {
+			return this.router == nil || this.router.Versioning().Matches(this, request)
+		})
+	}
+	this.Action.Version = version
+
+	return this
+}
+
+/**
+ * Get the API version served by the route.
+ *
+ * @return string
+ */
+func (this *Route) GetVersion() string {
+	return this.Action.Version
+}
+
 /**
  * Mark this route as a fallback route.
  *
@@ -424,6 +518,7 @@ func (this *Route) GetValidators() []ValidatorInterface {
 	Validators = []ValidatorInterface{
 		NewUriValidator(), NewMethodValidator(),
 		NewSchemeValidator(), NewHostValidator(),
+		NewConditionValidator(),
 	}
 	return Validators
 }
@@ -12,6 +12,7 @@ type routeAction struct {
 	Uses       Action
 	As         string
 	Middleware []string
+	Version    string
 }
 
 /**
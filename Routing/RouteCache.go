@@ -0,0 +1,209 @@
+package Routing
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type CachedRoute struct {
+	Methods    []string `json:"methods"`
+	Domain     string   `json:"domain"`
+	Uri        string   `json:"uri"`
+	Name       string   `json:"name"`
+	Middleware []string `json:"middleware"`
+	Uses       string   `json:"uses"`
+	IsFallback bool     `json:"fallback"`
+
+	/**
+	 * A digest of the requirements, defaults and conditions of the route, so
+	 * that changing any of them invalidates the compiled route.
+	 *
+	 * @var string
+	 */
+	Fingerprint string         `json:"fingerprint"`
+	Compiled    *CompiledRoute `json:"compiled"`
+}
+
+type RouteCache struct {
+	Routes []*CachedRoute `json:"routes"`
+
+	index map[string]*CachedRoute
+}
+
+/**
+ * Create a new route cache from the given routes.
+ *
+ * Every route is compiled from scratch so that the cache never carries over
+ * compiled data that was loaded from a previous cache file.
+ *
+ * @param  RouteCollection  routes
+ * @return *RouteCache
+ */
+func NewRouteCache(routes *RouteCollection) (this *RouteCache) {
+	this = &RouteCache{Routes: []*CachedRoute{}}
+
+	for _, route := range routes.GetRoutes() {
+		this.Routes = append(this.Routes, &CachedRoute{
+			Methods:     routeCacheMethods(route),
+			Domain:      route.GetDomain(),
+			Uri:         route.Uri(),
+			Name:        route.GetName(),
+			Middleware:  route.GetMiddleware(),
+			Uses:        routeCacheUses(route),
+			IsFallback:  route.IsFallback,
+			Fingerprint: routeCacheFingerprint(route),
+			Compiled:    NewRouteCompiler(route).Compile(),
+		})
+	}
+
+	return this
+}
+
+/**
+ * Load the route cache from the given file.
+ *
+ * @param  string  path
+ * @return *RouteCache, error
+ */
+func LoadRouteCache(path string) (*RouteCache, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	this := &RouteCache{}
+	if err := json.Unmarshal(data, this); err != nil {
+		return nil, err
+	}
+
+	return this, nil
+}
+
+/**
+ * Write the route cache to the given file.
+ *
+ * @param  string  path
+ * @return error
+ */
+func (this *RouteCache) Save(path string) error {
+	data, err := json.Marshal(this)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+/**
+ * Get the cached compiled version of the given route.
+ *
+ * A cached entry is only used when it still describes the route as it was
+ * registered: same verbs, domain, URI, requirements, defaults, conditions,
+ * name, middleware and handler.
+ *
+ * @param  Route  route
+ * @return *CompiledRoute|nil
+ */
+func (this *RouteCache) CompiledFor(route *Route) *CompiledRoute {
+	if this.index == nil {
+		this.index = map[string]*CachedRoute{}
+		for _, cached := range this.Routes {
+			this.index[cached.key()] = cached
+		}
+	}
+
+	cached, ok := this.index[strings.Join(routeCacheMethods(route), "|")+route.GetDomain()+route.Uri()+"#"+routeCacheFingerprint(route)]
+	if !ok {
+		return nil
+	}
+
+	if cached.Name != route.GetName() || cached.Uses != routeCacheUses(route) || cached.IsFallback != route.IsFallback ||
+		strings.Join(cached.Middleware, ",") != strings.Join(route.GetMiddleware(), ",") {
+		return nil
+	}
+
+	return cached.Compiled
+}
+
+/**
+ * Get the key identifying the cached route.
+ *
+ * @return string
+ */
+func (this *CachedRoute) key() string {
+	return strings.Join(this.Methods, "|") + this.Domain + this.Uri + "#" + this.Fingerprint
+}
+
+/**
+ * Get the sorted HTTP verbs of the given route.
+ *
+ * @param  Route  route
+ * @return []string
+ */
+func routeCacheMethods(route *Route) []string {
+	methods := []string{}
+	for method, _ := range route.Methods() {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	return methods
+}
+
+/**
+ * Get a reference to the handler of the given route.
+ *
+ * Handlers are closures and cannot be serialized, so we will store the name of
+ * the function instead, which lets us detect routes whose handler changed.
+ *
+ * @param  Route  route
+ * @return string
+ */
+func routeCacheUses(route *Route) string {
+	if route.Action.Uses == nil {
+		return ""
+	}
+	if fn := runtime.FuncForPC(reflect.ValueOf(route.Action.Uses).Pointer()); fn != nil {
+		return fn.Name()
+	}
+	return ""
+}
+
+/**
+ * Get a digest of the requirements, defaults and conditions of the route.
+ *
+ * Conditions given as closures cannot be serialized, so only their number is
+ * part of the digest, along with the expressions of the other conditions.
+ *
+ * @param  Route  route
+ * @return string
+ */
+func routeCacheFingerprint(route *Route) string {
+	parts := []string{}
+	for name, expression := range route.wheres {
+		parts = append(parts, "where:"+name+"="+expression)
+	}
+	for key, value := range route.defaults {
+		parts = append(parts, "default:"+key+"="+value)
+	}
+	sort.Strings(parts)
+
+	parts = append(parts, "condition:"+route.GetCondition(), "conditions:"+strconv.Itoa(len(route.GetConditions())))
+
+	hash := sha1.Sum([]byte(strings.Join(parts, "\n")))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,49 @@
+package Routing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCachedRoutesAreNotCompiledUntilTheyAreMatched(t *testing.T) {
+	dir, err := ioutil.TempDir("", "routes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "routes.json")
+
+	router := NewRouter(nil)
+	router.Get("users/{id}", respond("user")).Where("id", "[0-9]+")
+	if err := NewRouteCache(router.GetRoutes()).Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err := LoadRouteCache(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiled := cache.Routes[0].Compiled
+	if compiled.pathRegexp != nil {
+		t.Fatalf("expected loading the cache not to compile the route")
+	}
+
+	fresh := NewRouter(nil)
+	route := fresh.Get("users/{id}", respond("user")).Where("id", "[0-9]+")
+	if loaded := fresh.GetRoutes().SetRouteCache(cache); loaded != 1 {
+		t.Fatalf("expected 1 route loaded from the cache, got %d", loaded)
+	}
+
+	request := newTestRequest("GET", "/users/42")
+	if matched := matchRoute(t, fresh, request); matched != route {
+		t.Fatalf("expected the cached route to match")
+	}
+	if route.GetCompiled() != compiled || compiled.pathRegexp == nil {
+		t.Fatalf("expected the cached route to be compiled when it was matched")
+	}
+	if id := NewRouteParameterBinder(route).Parameters(request)["id"]; id != "42" {
+		t.Errorf("expected id 42, got %v", id)
+	}
+}
@@ -1,21 +1,47 @@
 package Routing
 
 import (
+	"fmt"
 	"github.com/larisgo/framework/Errors"
 	"github.com/larisgo/framework/Http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 type RouteCollection struct {
-	routes     map[string]map[string]*Route
-	allRoutes  map[string]*Route
+	/**
+	 * The routes keyed by HTTP verb, in the order they were registered.
+	 *
+	 * @var map[string][]*Route
+	 */
+	routes map[string][]*Route
+
+	/**
+	 * A flattened list of all of the routes, in the order they were registered.
+	 *
+	 * @var []*Route
+	 */
+	allRoutes []*Route
+
 	nameList   map[string]*Route
 	actionList map[string]*Route
+
+	/**
+	 * The compiled route trees keyed by HTTP verb, read without locking by
+	 * the requests being matched.
+	 *
+	 * @var atomic.Value  map[string]*RouteTree
+	 */
+	trees     atomic.Value
+	treesLock sync.Mutex
 }
 
 func NewRouteCollection() (this *RouteCollection) {
 	this = &RouteCollection{}
-	this.routes = map[string]map[string]*Route{}
-	this.allRoutes = map[string]*Route{}
+	this.routes = map[string][]*Route{}
+	this.allRoutes = []*Route{}
 	this.nameList = map[string]*Route{}
 	this.actionList = map[string]*Route{}
 	return this
@@ -30,6 +56,8 @@ func NewRouteCollection() (this *RouteCollection) {
 func (this *RouteCollection) Add(route *Route) *Route {
 	this.addToCollections(route)
 
+	this.forgetRouteTrees()
+
 	this.addLookups(route)
 
 	return route
@@ -38,22 +66,19 @@ func (this *RouteCollection) Add(route *Route) *Route {
 /**
  * Add the given route to the arrays of routes.
  *
+ * Every route is kept, even when registered again for the same domain and URI.
+ * The route trees decide which of them is matched, once their conditions are
+ * known.
+ *
  * @param  Routing\Route  route
  * @return void
  */
 func (this *RouteCollection) addToCollections(route *Route) {
-	domainAndUri := route.GetDomain() + route.Uri()
-	var method string
-	for method, _ = range route.Methods() {
-		if _, ok := this.routes[method]; ok {
-			this.routes[method][domainAndUri] = route
-		} else {
-			this.routes[method] = map[string]*Route{
-				domainAndUri: route,
-			}
-		}
+	for method, _ := range route.Methods() {
+		this.routes[method] = append(this.routes[method], route)
 	}
-	this.allRoutes[method+domainAndUri] = route
+
+	this.allRoutes = append(this.allRoutes, route)
 }
 
 /**
@@ -107,6 +132,29 @@ func (this *RouteCollection) RefreshNameLookups() {
 	}
 }
 
+/**
+ * Use the compiled routes of the given route cache.
+ *
+ * Routes which are missing from the cache or changed since it was written are
+ * left untouched and will be compiled when they are first matched.
+ *
+ * @param  RouteCache  cache
+ * @return int  The number of routes loaded from the cache
+ */
+func (this *RouteCollection) SetRouteCache(cache *RouteCache) int {
+	loaded := 0
+	for _, route := range this.allRoutes {
+		if compiled := cache.CompiledFor(route); compiled != nil {
+			route.compiled = compiled
+			loaded += 1
+		}
+	}
+
+	this.forgetRouteTrees()
+
+	return loaded
+}
+
 /**
  * Find the first route matching a given request.
  *
@@ -116,7 +164,11 @@ func (this *RouteCollection) RefreshNameLookups() {
  * @throws Errors.NotFoundHttpException
  */
 func (this *RouteCollection) Match(request *Http.Request) *Route {
-	routes := this.Get(request.GetMethod())
+	path := "/" + strings.TrimLeft(request.Path(), "/")
+
+	trees := this.getRouteTrees()
+
+	routes := routeTreeFor(trees, request.GetMethod()).Candidates(path)
 
 	// First, we will see if we can find a matching route for this current request
 	// method. If we can, great, we can just return it so that it can be called
@@ -130,7 +182,7 @@ func (this *RouteCollection) Match(request *Http.Request) *Route {
 	// If no route was found we will now check if a matching route is specified by
 	// another HTTP verb. If it is we will need to throw a MethodNotAllowed and
 	// inform the user agent of which HTTP verb it should use for this route.
-	others := this.checkForAlternateVerbs(request)
+	others := this.checkForAlternateVerbs(request, path, trees)
 
 	if len(others) > 0 {
 		return this.getRouteForMethods(request, others)
@@ -142,23 +194,15 @@ func (this *RouteCollection) Match(request *Http.Request) *Route {
 /**
  * Determine if a route in the array matches the request.
  *
- * @param  array  $routes
- * @param  \Illuminate\Http\Request  $request
- * @param  bool  $includingMethod
- * @return \Illuminate\Routing\Route|null
+ * The routes are expected in matching order, with fallback routes last.
+ *
+ * @param  []*Route  routes
+ * @param  Http.Request  request
+ * @param  bool  includingMethod
+ * @return Routing\Route|nil
  */
-func (this *RouteCollection) matchAgainstRoutes(routes map[string]*Route, request *Http.Request, includingMethod bool) *Route {
-	_fallbacks := []*Route{}
-	_routes := []*Route{}
+func (this *RouteCollection) matchAgainstRoutes(routes []*Route, request *Http.Request, includingMethod bool) *Route {
 	for _, route := range routes {
-		if route.IsFallback {
-			_fallbacks = append(_fallbacks, route)
-		} else {
-			_routes = append(_routes, route)
-		}
-	}
-	_routes = append(_routes, _fallbacks...)
-	for _, route := range _routes {
 		if route.Matches(request, includingMethod) {
 			return route
 		}
@@ -169,20 +213,22 @@ func (this *RouteCollection) matchAgainstRoutes(routes map[string]*Route, reques
 /**
  * Determine if any routes match on another HTTP verb.
  *
- * @param  \Illuminate\Http\Request  $request
- * @return array
+ * @param  Http.Request  request
+ * @param  string  path
+ * @param  map[string]*RouteTree  trees
+ * @return map[string]bool
  */
-func (this *RouteCollection) checkForAlternateVerbs(request *Http.Request) map[string]bool {
-	methods := Verbs // copy
-	delete(methods, request.GetMethod())
-
+func (this *RouteCollection) checkForAlternateVerbs(request *Http.Request, path string, trees map[string]*RouteTree) map[string]bool {
 	// Here we will spin through all verbs except for the current request verb and
 	// check to see if any routes respond to them. If they do, we will return a
 	// proper error response with the correct headers on the response string.
 	others := map[string]bool{}
 
-	for method, _ := range methods {
-		if this.matchAgainstRoutes(this.Get(method), request, false) != nil {
+	for method, _ := range Verbs {
+		if method == request.GetMethod() {
+			continue
+		}
+		if this.matchAgainstRoutes(routeTreeFor(trees, method).Candidates(path), request, false) != nil {
 			others[method] = true
 		}
 	}
@@ -190,6 +236,69 @@ func (this *RouteCollection) checkForAlternateVerbs(request *Http.Request) map[s
 	return others
 }
 
+/**
+ * Get the compiled route trees keyed by HTTP verb.
+ *
+ * The trees are built the first time a request is matched, once every route
+ * had a chance to be fully configured, and rebuilt after any route is added.
+ * Once built, they are shared by the requests without taking any lock.
+ *
+ * @return map[string]*RouteTree
+ */
+func (this *RouteCollection) getRouteTrees() map[string]*RouteTree {
+	if trees, _ := this.trees.Load().(map[string]*RouteTree); trees != nil {
+		return trees
+	}
+
+	this.treesLock.Lock()
+	defer this.treesLock.Unlock()
+
+	if trees, _ := this.trees.Load().(map[string]*RouteTree); trees != nil {
+		return trees
+	}
+
+	trees := map[string]*RouteTree{}
+	for verb, routes := range this.routes {
+		trees[verb] = NewRouteTree(routes)
+	}
+	this.trees.Store(trees)
+
+	return trees
+}
+
+/**
+ * Forget the compiled route trees.
+ *
+ * @return void
+ */
+func (this *RouteCollection) forgetRouteTrees() {
+	this.treesLock.Lock()
+	defer this.treesLock.Unlock()
+
+	this.trees.Store(map[string]*RouteTree(nil))
+}
+
+/**
+ * The tree of the verbs without any route.
+ *
+ * @var *RouteTree
+ */
+var emptyRouteTree = NewRouteTree(nil)
+
+/**
+ * Get the route tree of the given HTTP verb.
+ *
+ * @param  map[string]*RouteTree  trees
+ * @param  string  method
+ * @return *RouteTree
+ */
+func routeTreeFor(trees map[string]*RouteTree, method string) *RouteTree {
+	if tree, ok := trees[method]; ok {
+		return tree
+	}
+	return emptyRouteTree
+}
+
 /**
  * Get a route (if necessary) that responds when other available methods are present.
  *
@@ -201,34 +310,49 @@ func (this *RouteCollection) checkForAlternateVerbs(request *Http.Request) map[s
  */
 func (this *RouteCollection) getRouteForMethods(request *Http.Request, methods map[string]bool) *Route {
 	if request.Method() == "OPTIONS" {
+		allow := []string{}
+		for method, _ := range methods {
+			allow = append(allow, method)
+		}
+		sort.Strings(allow)
+
 		return NewRoute(map[string]bool{"OPTIONS": true}, request.Path(), Action(func(*Http.Request) *Http.Response {
-			return Http.NewResponse("", 200)
-			// return new Response('', 200, ['Allow' => implode(',', $methods)]);
+			return Http.NewResponse("", 200, map[string][]string{"Allow": {strings.Join(allow, ", ")}})
 		})).Bind(request)
 	}
 
-	return this.methodNotAllowed(methods)
+	return this.methodNotAllowed(methods, request.Method())
 }
 
 /**
  * Throw a method not allowed HTTP exception.
  *
- * @param  array  $others
+ * @param  map[string]bool  others
+ * @param  string  method
  * @return void
  *
  * @throws Errors.MethodNotAllowedHttpException
  */
-func (this *RouteCollection) methodNotAllowed(others map[string]bool) *Route {
-	panic(Errors.NewMethodNotAllowedHttpException(others, "Method Not Allowed"))
+func (this *RouteCollection) methodNotAllowed(others map[string]bool, method string) *Route {
+	allow := []string{}
+	for other, _ := range others {
+		allow = append(allow, other)
+	}
+	sort.Strings(allow)
+
+	panic(Errors.NewMethodNotAllowedHttpException(others, fmt.Sprintf(
+		"The %s method is not supported for this route. Supported methods: %s.",
+		method, strings.Join(allow, ", "),
+	)))
 }
 
 /**
  * Get routes from the collection by method.
  *
  * @param  string  method
- * @return map[string]*Route
+ * @return []*Route
  */
-func (this *RouteCollection) Get(method ...string) map[string]*Route {
+func (this *RouteCollection) Get(method ...string) []*Route {
 	if len(method) > 0 {
 		if routes, ok := this.routes[method[0]]; ok {
 			return routes
@@ -266,18 +390,18 @@ func (this *RouteCollection) GetByName(name string) *Route {
 /**
  * Get all of the routes in the collection.
  *
- * @return map[string]*Route
+ * @return []*Route
  */
-func (this *RouteCollection) GetRoutes() map[string]*Route {
+func (this *RouteCollection) GetRoutes() []*Route {
 	return this.allRoutes
 }
 
 /**
  * Get all of the routes keyed by their HTTP verb / method.
  *
- * @return map[string]*Route
+ * @return map[string][]*Route
  */
-func (this *RouteCollection) GetRoutesByMethod() map[string]map[string]*Route {
+func (this *RouteCollection) GetRoutesByMethod() map[string][]*Route {
 	return this.routes
 }
 
@@ -0,0 +1,207 @@
+package Routing
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+)
+
+func newTestRequest(method string, target string, headers ...map[string]string) *Http.Request {
+	request := httptest.NewRequest(method, target, nil)
+	for _, header := range headers {
+		for key, value := range header {
+			request.Header.Set(key, value)
+		}
+	}
+
+	return Http.NewRequest(nil, httptest.NewRecorder(), request)
+}
+
+func respond(content string) func(*Http.Request) *Http.Response {
+	return func(*Http.Request) *Http.Response {
+		return Http.NewResponse(content, Http.HTTP_OK)
+	}
+}
+
+func matchRoute(t *testing.T, router *Router, request *Http.Request) (route *Route) {
+	t.Helper()
+
+	defer func() {
+		if err := recover(); err != nil {
+			t.Fatalf("%s %s did not match: %v", request.GetMethod(), request.Path(), err)
+		}
+	}()
+
+	return router.GetRoutes().Match(request)
+}
+
+func TestConditionalRouteRegisteredAfterPlainRouteKeepsBoth(t *testing.T) {
+	router := NewRouter(nil)
+	v1 := router.Get("users", respond("v1"))
+	v2 := router.Get("users", respond("v2")).Condition(`header("X-Api-Version") == "2"`)
+
+	if route := matchRoute(t, router, newTestRequest("GET", "/users")); route != v1 {
+		t.Errorf("expected the plain route without the header, got %v", route.Uri())
+	}
+	if route := matchRoute(t, router, newTestRequest("GET", "/users", map[string]string{"X-Api-Version": "2"})); route != v2 {
+		t.Errorf("expected the conditional route with the header")
+	}
+}
+
+func TestConditionalRouteIsTestedBeforePlainRouteRegisteredAfterIt(t *testing.T) {
+	router := NewRouter(nil)
+	v2 := router.Get("users", respond("v2")).When(func(request *Http.Request) bool {
+		return request.Headers.Get("X-Api-Version") == "2"
+	})
+	v1 := router.Get("users", respond("v1"))
+
+	if route := matchRoute(t, router, newTestRequest("GET", "/users", map[string]string{"X-Api-Version": "2"})); route != v2 {
+		t.Errorf("expected the conditional route with the header")
+	}
+	if route := matchRoute(t, router, newTestRequest("GET", "/users")); route != v1 {
+		t.Errorf("expected the plain route without the header")
+	}
+}
+
+func TestPlainRouteRegisteredAgainReplacesThePreviousOne(t *testing.T) {
+	router := NewRouter(nil)
+	router.Get("users", respond("first"))
+	second := router.Get("users", respond("second"))
+
+	if route := matchRoute(t, router, newTestRequest("GET", "/users")); route != second {
+		t.Errorf("expected the route registered last")
+	}
+}
+
+func TestConditionalRouteAloneDoesNotMatchWithoutItsCondition(t *testing.T) {
+	router := NewRouter(nil)
+	router.Get("users", respond("v2")).Condition(`header("X-Api-Version") == "2"`)
+
+	defer func() {
+		if _, ok := recover().(Errors.NotFoundHttpException); !ok {
+			t.Errorf("expected a NotFoundHttpException")
+		}
+	}()
+
+	router.GetRoutes().Match(newTestRequest("GET", "/users"))
+}
+
+func newBenchmarkRouter(size int) *Router {
+	router := NewRouter(nil)
+	for i := 0; i < size; i++ {
+		router.Get(fmt.Sprintf("resource%d", i), respond("index"))
+		router.Get(fmt.Sprintf("resource%d/{id}", i), respond("show"))
+		router.Post(fmt.Sprintf("resource%d/{id}/comments", i), respond("comment"))
+	}
+	return router
+}
+
+func benchmarkTreeMatch(b *testing.B, size int) {
+	router := newBenchmarkRouter(size)
+	request := newTestRequest("GET", fmt.Sprintf("/resource%d/42", size-1))
+	routes := router.GetRoutes()
+	routes.Match(request)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if routes.Match(request) == nil {
+			b.Fatal("no route matched")
+		}
+	}
+}
+
+func benchmarkLinearMatch(b *testing.B, size int) {
+	router := newBenchmarkRouter(size)
+	request := newTestRequest("GET", fmt.Sprintf("/resource%d/42", size-1))
+	routes := router.GetRoutes()
+	candidates := routes.Get("GET")
+	routes.matchAgainstRoutes(candidates, request, true)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if routes.matchAgainstRoutes(candidates, request, true) == nil {
+			b.Fatal("no route matched")
+		}
+	}
+}
+
+func BenchmarkTreeMatch100(b *testing.B)    { benchmarkTreeMatch(b, 100) }
+func BenchmarkTreeMatch1000(b *testing.B)   { benchmarkTreeMatch(b, 1000) }
+func BenchmarkLinearMatch100(b *testing.B)  { benchmarkLinearMatch(b, 100) }
+func BenchmarkLinearMatch1000(b *testing.B) { benchmarkLinearMatch(b, 1000) }
+
+func BenchmarkTreeMatchParallel(b *testing.B) {
+	router := newBenchmarkRouter(1000)
+	routes := router.GetRoutes()
+	routes.Match(newTestRequest("GET", "/resource0"))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		request := newTestRequest("GET", "/resource999/42")
+		for pb.Next() {
+			routes.Match(request)
+		}
+	})
+}
+
+func TestStaticRouteRegisteredBeforeParameterRouteIsMatchedFirst(t *testing.T) {
+	router := NewRouter(nil)
+	me := router.Get("users/me", respond("me"))
+	show := router.Get("users/{id}", respond("show"))
+
+	if route := matchRoute(t, router, newTestRequest("GET", "/users/me")); route != me {
+		t.Errorf("expected /users/me to match the static route, got %v", route.Uri())
+	}
+	if route := matchRoute(t, router, newTestRequest("GET", "/users/42")); route != show {
+		t.Errorf("expected /users/42 to match the parameter route, got %v", route.Uri())
+	}
+}
+
+func TestParameterRouteRegisteredBeforeStaticRouteIsMatchedFirst(t *testing.T) {
+	router := NewRouter(nil)
+	show := router.Get("users/{id}", respond("show"))
+	router.Get("users/me", respond("me"))
+
+	// routes are matched in the order they were registered, like a linear scan
+	if route := matchRoute(t, router, newTestRequest("GET", "/users/me")); route != show {
+		t.Errorf("expected /users/me to match the parameter route registered first, got %v", route.Uri())
+	}
+	if route := matchRoute(t, router, newTestRequest("GET", "/users/42")); route != show {
+		t.Errorf("expected /users/42 to match the parameter route, got %v", route.Uri())
+	}
+}
+
+func TestParameterRouteWithConstraintLetsStaticRouteRegisteredAfterItMatch(t *testing.T) {
+	router := NewRouter(nil)
+	show := router.Get("users/{id}", respond("show")).Where("id", "[0-9]+")
+	me := router.Get("users/me", respond("me"))
+
+	if route := matchRoute(t, router, newTestRequest("GET", "/users/me")); route != me {
+		t.Errorf("expected /users/me to match the static route, got %v", route.Uri())
+	}
+	if route := matchRoute(t, router, newTestRequest("GET", "/users/42")); route != show {
+		t.Errorf("expected /users/42 to match the parameter route, got %v", route.Uri())
+	}
+}
+
+func TestRouteTreeCountsEveryRouteOnce(t *testing.T) {
+	router := NewRouter(nil)
+	router.Get("users", respond("users"))
+	router.Get("users", respond("users")).Condition(`header("X-Api-Version") == "2"`)
+	router.Get("users/{id}", respond("user"))
+	router.Fallback(respond("missing"))
+
+	routes := router.GetRoutes().GetRoutes()
+	if count := NewRouteTree(routes).Count(); count != len(routes) {
+		t.Errorf("expected the tree to count %d routes, got %d", len(routes), count)
+	}
+	if count := NewRouteTree(nil).Count(); count != 0 {
+		t.Errorf("expected an empty tree to count no route, got %d", count)
+	}
+}
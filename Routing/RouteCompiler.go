@@ -26,7 +26,7 @@ func NewRouteCompiler(route *Route) *RouteCompiler {
 func (this *RouteCompiler) Compile() *CompiledRoute {
 	optionals := this.getOptionalParameters()
 	uri := regexp.MustCompile(`\{(\w+?)\?\}`).ReplaceAllString(this.route.Uri(), `{$1}`)
-	return NewSymfonyRoute(uri, optionals, map[string]string{}, map[string]interface{}{"utf8": true}, this.route.GetDomain(), map[string]bool{}, map[string]bool{}, "").Compile()
+	return NewSymfonyRoute(uri, optionals, this.route.wheres, map[string]interface{}{"utf8": true}, this.route.GetDomain(), map[string]bool{}, map[string]bool{}, this.route.GetCondition()).Compile()
 }
 
 /**
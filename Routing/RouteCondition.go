@@ -0,0 +1,414 @@
+package Routing
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+	"mime"
+	"regexp"
+	"strings"
+)
+
+const (
+	conditionTokenEnd = iota
+	conditionTokenName
+	conditionTokenString
+	conditionTokenPunctuation
+)
+
+type conditionToken struct {
+	kind  int
+	value string
+}
+
+/**
+ * A value read from the request while a condition is evaluated.
+ */
+type conditionValue func(*Http.Request) string
+
+/**
+ * A route condition written in a small expression language, e.g.
+ *
+ *  header("X-Api-Version") == "2" and method in ["GET", "HEAD"]
+ *  content_type matches "^application/(.+\+)?json$" or not query("legacy")
+ *
+ * Values are the variables method, path, host, scheme and content_type, the
+ * functions header("name") and query("name"), and string or number literals.
+ * They are combined with ==, !=, matches, in, not in, and, or, not and
+ * parentheses; a value standing alone is true when it is not empty.
+ */
+type RouteCondition struct {
+	/**
+	 * The expression the condition was parsed from.
+	 *
+	 * @var string
+	 */
+	expression string
+
+	/**
+	 * The tokens of the expression.
+	 *
+	 * @var []conditionToken
+	 */
+	tokens []conditionToken
+
+	/**
+	 * The position of the parser in the tokens.
+	 *
+	 * @var int
+	 */
+	position int
+
+	/**
+	 * The compiled predicate of the expression.
+	 *
+	 * @var func(*Http.Request) bool
+	 */
+	predicate func(*Http.Request) bool
+}
+
+/**
+ * Create a new route condition from the given expression.
+ *
+ * @param  string  expression
+ * @return *RouteCondition
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func NewRouteCondition(expression string) (this *RouteCondition) {
+	this = &RouteCondition{expression: expression}
+	this.tokens = this.tokenize(expression)
+	this.predicate = this.parseOr()
+
+	if token := this.current(); token.kind != conditionTokenEnd {
+		this.syntaxError(fmt.Sprintf(`unexpected "%s"`, token.value))
+	}
+
+	return this
+}
+
+/**
+ * Get the expression of the condition.
+ *
+ * @return string
+ */
+func (this *RouteCondition) String() string {
+	return this.expression
+}
+
+/**
+ * Determine if the given request satisfies the condition.
+ *
+ * @param  *Http.Request  request
+ * @return bool
+ */
+func (this *RouteCondition) Evaluate(request *Http.Request) bool {
+	return this.predicate(request)
+}
+
+/**
+ * Split the expression into tokens.
+ *
+ * @param  string  expression
+ * @return []conditionToken
+ */
+func (this *RouteCondition) tokenize(expression string) (tokens []conditionToken) {
+	for i := 0; i < len(expression); {
+		c := expression[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			var value strings.Builder
+			j := i + 1
+			for ; j < len(expression) && expression[j] != c; j++ {
+				// only the quote and the backslash are escaped, so patterns keep theirs
+				if expression[j] == '\\' && j+1 < len(expression) && (expression[j+1] == c || expression[j+1] == '\\') {
+					j++
+				}
+				value.WriteByte(expression[j])
+			}
+			if j >= len(expression) {
+				this.syntaxError("unterminated string")
+			}
+			tokens = append(tokens, conditionToken{conditionTokenString, value.String()})
+			i = j + 1
+		case c == '_' || c == '.' || c == '-' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9'):
+			j := i
+			for ; j < len(expression); j++ {
+				if c := expression[j]; !(c == '_' || c == '.' || c == '-' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')) {
+					break
+				}
+			}
+			if word := expression[i:j]; word[0] == '.' || word[0] == '-' || ('0' <= word[0] && word[0] <= '9') {
+				// numbers are compared as their text, like every other value
+				tokens = append(tokens, conditionToken{conditionTokenString, word})
+			} else {
+				tokens = append(tokens, conditionToken{conditionTokenName, word})
+			}
+			i = j
+		default:
+			if i+1 < len(expression) {
+				if pair := expression[i : i+2]; pair == "==" || pair == "!=" || pair == "&&" || pair == "||" {
+					tokens = append(tokens, conditionToken{conditionTokenPunctuation, pair})
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("!()[],", rune(c)) {
+				this.syntaxError(fmt.Sprintf(`unexpected character "%c"`, c))
+			}
+			tokens = append(tokens, conditionToken{conditionTokenPunctuation, string(c)})
+			i++
+		}
+	}
+
+	return append(tokens, conditionToken{conditionTokenEnd, ""})
+}
+
+/**
+ * Get the token at the position of the parser.
+ *
+ * @return conditionToken
+ */
+func (this *RouteCondition) current() conditionToken {
+	return this.tokens[this.position]
+}
+
+/**
+ * Advance the parser past the current token when it has the given value.
+ *
+ * @param  string  values
+ * @return bool
+ */
+func (this *RouteCondition) accept(values ...string) bool {
+	token := this.current()
+	if token.kind != conditionTokenName && token.kind != conditionTokenPunctuation {
+		return false
+	}
+	for _, value := range values {
+		if token.value == value {
+			this.position++
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Advance the parser past the given token or fail.
+ *
+ * @param  string  value
+ * @return void
+ */
+func (this *RouteCondition) expect(value string) {
+	if !this.accept(value) {
+		this.syntaxError(fmt.Sprintf(`expected "%s"`, value))
+	}
+}
+
+/**
+ * Parse a chain of "or" operations.
+ *
+ * @return func(*Http.Request) bool
+ */
+func (this *RouteCondition) parseOr() func(*Http.Request) bool {
+	left := this.parseAnd()
+	for this.accept("or", "||") {
+		l, r := left, this.parseAnd()
+		left = func(request *Http.Request) bool {
+			return l(request) || r(request)
+		}
+	}
+	return left
+}
+
+/**
+ * Parse a chain of "and" operations.
+ *
+ * @return func(*Http.Request) bool
+ */
+func (this *RouteCondition) parseAnd() func(*Http.Request) bool {
+	left := this.parseNot()
+	for this.accept("and", "&&") {
+		l, r := left, this.parseNot()
+		left = func(request *Http.Request) bool {
+			return l(request) && r(request)
+		}
+	}
+	return left
+}
+
+/**
+ * Parse a negation or a comparison.
+ *
+ * @return func(*Http.Request) bool
+ */
+func (this *RouteCondition) parseNot() func(*Http.Request) bool {
+	if this.accept("not", "!") {
+		operand := this.parseNot()
+		return func(request *Http.Request) bool {
+			return !operand(request)
+		}
+	}
+	if this.accept("(") {
+		expression := this.parseOr()
+		this.expect(")")
+		return expression
+	}
+	return this.parseComparison()
+}
+
+/**
+ * Parse a comparison between a value and its operand.
+ *
+ * @return func(*Http.Request) bool
+ */
+func (this *RouteCondition) parseComparison() func(*Http.Request) bool {
+	left := this.parseValue()
+
+	switch {
+	case this.accept("=="):
+		right := this.parseValue()
+		return func(request *Http.Request) bool {
+			return left(request) == right(request)
+		}
+	case this.accept("!="):
+		right := this.parseValue()
+		return func(request *Http.Request) bool {
+			return left(request) != right(request)
+		}
+	case this.accept("matches"):
+		token := this.current()
+		if token.kind != conditionTokenString {
+			this.syntaxError(`"matches" expects a string pattern`)
+		}
+		this.position++
+		pattern, err := regexp.Compile(token.value)
+		if err != nil {
+			this.syntaxError(err.Error())
+		}
+		return func(request *Http.Request) bool {
+			return pattern.MatchString(left(request))
+		}
+	case this.accept("in"):
+		return this.parseIn(left)
+	case this.current().kind == conditionTokenName && this.current().value == "not" && this.tokens[this.position+1].value == "in":
+		this.position += 2
+		in := this.parseIn(left)
+		return func(request *Http.Request) bool {
+			return !in(request)
+		}
+	}
+
+	return func(request *Http.Request) bool {
+		return left(request) != ""
+	}
+}
+
+/**
+ * Parse the list of an "in" operation.
+ *
+ * @param  conditionValue  left
+ * @return func(*Http.Request) bool
+ */
+func (this *RouteCondition) parseIn(left conditionValue) func(*Http.Request) bool {
+	this.expect("[")
+	items := []conditionValue{}
+	for !this.accept("]") {
+		if len(items) > 0 {
+			this.expect(",")
+		}
+		items = append(items, this.parseValue())
+	}
+	return func(request *Http.Request) bool {
+		value := left(request)
+		for _, item := range items {
+			if item(request) == value {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+/**
+ * Parse a literal, a variable or a function call.
+ *
+ * @return conditionValue
+ */
+func (this *RouteCondition) parseValue() conditionValue {
+	token := this.current()
+	this.position++
+
+	switch token.kind {
+	case conditionTokenString:
+		return func(*Http.Request) string {
+			return token.value
+		}
+	case conditionTokenName:
+		switch token.value {
+		case "method":
+			return func(request *Http.Request) string {
+				return request.GetMethod()
+			}
+		case "path":
+			return func(request *Http.Request) string {
+				return "/" + strings.TrimLeft(request.Path(), "/")
+			}
+		case "host":
+			return func(request *Http.Request) string {
+				return request.GetHost()
+			}
+		case "scheme":
+			return func(request *Http.Request) string {
+				if request.Secure() {
+					return "https"
+				}
+				return "http"
+			}
+		case "content_type":
+			return func(request *Http.Request) string {
+				contentType := request.Headers.Get("Content-Type")
+				if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+					return mediaType
+				}
+				return strings.ToLower(contentType)
+			}
+		case "header", "query":
+			this.expect("(")
+			name := this.current()
+			if name.kind != conditionTokenString {
+				this.syntaxError(fmt.Sprintf(`"%s" expects a string name`, token.value))
+			}
+			this.position++
+			this.expect(")")
+			if token.value == "header" {
+				return func(request *Http.Request) string {
+					return request.Headers.Get(name.value)
+				}
+			}
+			return func(request *Http.Request) string {
+				return request.Query.Get(name.value)
+			}
+		}
+		this.syntaxError(fmt.Sprintf(`unknown name "%s"`, token.value))
+	case conditionTokenEnd:
+		this.syntaxError("unexpected end of expression")
+	}
+
+	this.syntaxError(fmt.Sprintf(`unexpected "%s"`, token.value))
+	return nil
+}
+
+/**
+ * Fail the parsing of the expression.
+ *
+ * @param  string  message
+ * @return void
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *RouteCondition) syntaxError(message string) {
+	panic(Errors.NewInvalidArgumentException(fmt.Sprintf(`Invalid route condition "%s": %s.`, this.expression, message)))
+}
@@ -4,6 +4,13 @@ import (
 	"strings"
 )
 
+/**
+ * The separator used to keep a list of middleware in a group attribute.
+ *
+ * @var string
+ */
+const MIDDLEWARE_SEPARATOR = "|"
+
 type RouteGroup struct {
 }
 
@@ -18,10 +25,12 @@ func (this *RouteGroup) Merge(_new map[string]string, _old map[string]string) ma
 		delete(_otmp, "domain")
 	}
 	_ntmp = this.formatAs(_ntmp, _otmp)
+	_ntmp = this.formatMiddleware(_ntmp, _otmp)
 	_ntmp["prefix"] = this.formatPrefix(_ntmp, _otmp)
 
 	delete(_otmp, "prefix")
 	delete(_otmp, "as")
+	delete(_otmp, "middleware")
 	for k, v := range _ntmp {
 		_otmp[k] = v
 	}
@@ -73,3 +82,22 @@ func (this *RouteGroup) formatAs(_new map[string]string, _old map[string]string)
 
 	return _new
 }
+
+/**
+ * Format the middleware of the new group attributes.
+ *
+ * @param  array  new
+ * @param  array  old
+ * @return array
+ */
+func (this *RouteGroup) formatMiddleware(_new map[string]string, _old map[string]string) map[string]string {
+	if ov, ook := _old["middleware"]; ook && ov != "" {
+		if nv, nok := _new["middleware"]; nok && nv != "" {
+			_new["middleware"] = ov + MIDDLEWARE_SEPARATOR + nv
+		} else {
+			_new["middleware"] = ov
+		}
+	}
+
+	return _new
+}
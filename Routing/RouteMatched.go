@@ -0,0 +1,32 @@
+package Routing
+
+import (
+	"github.com/larisgo/framework/Http"
+)
+
+type RouteMatched struct {
+	/**
+	 * The route instance.
+	 *
+	 * @var *Route
+	 */
+	Route *Route
+
+	/**
+	 * The request instance.
+	 *
+	 * @var *Http.Request
+	 */
+	Request *Http.Request
+}
+
+/**
+ * Create a new event instance.
+ *
+ * @param  *Route  route
+ * @param  *Http.Request  request
+ * @return *RouteMatched
+ */
+func NewRouteMatched(route *Route, request *Http.Request) *RouteMatched {
+	return &RouteMatched{Route: route, Request: request}
+}
@@ -2,7 +2,6 @@ package Routing
 
 import (
 	"github.com/larisgo/framework/Http"
-	"regexp"
 	"strings"
 )
 
@@ -29,13 +28,27 @@ func (this *RouteParameterBinder) Parameters(request *Http.Request) map[string]s
 	// If the route has a regular expression for the host part of the URI, we will
 	// compile that and get the parameter matches for this domain. We will then
 	// merge them into this parameters array so that this array is completed.
-	if this.route.compiled.GetHostRegex() != "" {
+	if this.route.compiled.GetHostRegexp() != nil {
 		parameters = this.bindHostParameters(request, parameters)
 	}
 
 	return this.replaceDefaults(parameters)
 }
 
+/**
+ * Get the parameters captured from the host of the request.
+ *
+ * @param  Http.Request  request
+ * @return map[string]string
+ */
+func (this *RouteParameterBinder) HostParameters(request *Http.Request) map[string]string {
+	if this.route.compiled.GetHostRegexp() == nil {
+		return map[string]string{}
+	}
+
+	return this.bindHostParameters(request, map[string]string{})
+}
+
 func (this *RouteParameterBinder) combine(key []string, value []string) map[string]string {
 	result := map[string]string{}
 	if len(key) <= len(value) {
@@ -58,7 +71,7 @@ func (this *RouteParameterBinder) combine(key []string, value []string) map[stri
  */
 func (this *RouteParameterBinder) bindPathParameters(request *Http.Request) map[string]string {
 	path := "/" + strings.TrimLeft(request.Path(), "/")
-	_regexp := regexp.MustCompile(this.route.compiled.GetRegex())
+	_regexp := this.route.compiled.GetPathRegexp()
 	result := this.combine(_regexp.SubexpNames()[1:], _regexp.FindStringSubmatch(path)[1:])
 
 	return this.matchToKeys(result)
@@ -72,7 +85,7 @@ func (this *RouteParameterBinder) bindPathParameters(request *Http.Request) map[
  * @return array
  */
 func (this *RouteParameterBinder) bindHostParameters(request *Http.Request, parameters map[string]string) map[string]string {
-	_regexp := regexp.MustCompile(this.route.compiled.GetHostRegex())
+	_regexp := this.route.compiled.GetHostRegexp()
 	result := this.combine(_regexp.SubexpNames()[1:], _regexp.FindStringSubmatch(request.GetHost())[1:])
 
 	for k, v := range this.matchToKeys(result) {
@@ -0,0 +1,86 @@
+package Routing
+
+import (
+	"testing"
+)
+
+func TestWildcardSubdomainIsBoundAsHostParameter(t *testing.T) {
+	router := NewRouter(nil)
+	var dashboard *Route
+	router.Domain("{tenant}.example.com").Group(func(router *Router) {
+		dashboard = router.Get("dashboard/{section}", respond("dashboard"))
+	})
+
+	request := newTestRequest("GET", "http://acme.example.com/dashboard/billing")
+	if route := matchRoute(t, router, request); route != dashboard {
+		t.Fatalf("expected the domain route, got %v", route.Uri())
+	}
+	if tenant := request.HostParameter("tenant"); tenant != "acme" {
+		t.Errorf("expected the tenant host parameter to be acme, got %q", tenant)
+	}
+	if tenant := request.Route("tenant"); tenant != "acme" {
+		t.Errorf("expected the tenant route parameter to be acme, got %v", tenant)
+	}
+	if _, ok := request.HostParameters()["section"]; ok {
+		t.Error("a path parameter was exposed as a host parameter")
+	}
+}
+
+func TestWildcardSubdomainIgnoresThePort(t *testing.T) {
+	router := NewRouter(nil)
+	dashboard := router.Get("dashboard", respond("dashboard")).Domain("{tenant}.example.com")
+
+	request := newTestRequest("GET", "http://acme.example.com:8080/dashboard")
+	if route := matchRoute(t, router, request); route != dashboard {
+		t.Fatalf("expected the domain route, got %v", route.Uri())
+	}
+	if tenant := request.HostParameter("tenant"); tenant != "acme" {
+		t.Errorf("expected the tenant host parameter to be acme, got %q", tenant)
+	}
+}
+
+func TestWildcardSubdomainDoesNotMatchOtherHosts(t *testing.T) {
+	router := NewRouter(nil)
+	router.Get("dashboard", respond("tenant")).Domain("{tenant}.example.com")
+	fallback := router.Get("dashboard", respond("main"))
+
+	for _, target := range []string{
+		"http://example.com/dashboard",
+		"http://acme.example.org/dashboard",
+		"http://[::1]:8080/dashboard",
+	} {
+		request := newTestRequest("GET", target)
+		if route := matchRoute(t, router, request); route != fallback {
+			t.Errorf("expected %s to match the route without domain", target)
+		}
+		if len(request.HostParameters()) != 0 {
+			t.Errorf("expected no host parameters for %s, got %v", target, request.HostParameters())
+		}
+	}
+}
+
+func TestRouteWithoutDomainMatchesIPv6Host(t *testing.T) {
+	router := NewRouter(nil)
+	home := router.Get("home", respond("home"))
+
+	for _, target := range []string{"http://[::1]/home", "http://[2001:db8::1]:8080/home"} {
+		request := newTestRequest("GET", target)
+		if route := matchRoute(t, router, request); route != home {
+			t.Errorf("expected %s to match", target)
+		}
+	}
+}
+
+func TestHostParameterHonoursItsConstraint(t *testing.T) {
+	router := NewRouter(nil)
+	router.Get("dashboard", respond("tenant")).Domain("{tenant}.example.com").Where("tenant", "[a-z]+")
+	fallback := router.Get("dashboard", respond("main"))
+
+	request := newTestRequest("GET", "http://123.example.com/dashboard")
+	if route := matchRoute(t, router, request); route != fallback {
+		t.Errorf("expected the constrained domain route to be skipped")
+	}
+	if tenant := request.HostParameter("tenant", "none"); tenant != "none" {
+		t.Errorf("expected no tenant host parameter, got %q", tenant)
+	}
+}
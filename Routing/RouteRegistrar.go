@@ -0,0 +1,166 @@
+package Routing
+
+import (
+	"github.com/larisgo/framework/Http"
+	"strings"
+)
+
+type RouteRegistrar struct {
+	/**
+	 * The router instance.
+	 *
+	 * @var *Router
+	 */
+	router *Router
+
+	/**
+	 * The attributes to pass on to the router.
+	 *
+	 * @var map[string]string
+	 */
+	attributes map[string]string
+}
+
+/**
+ * Create a new route registrar instance.
+ *
+ * @param  Router  router
+ * @return *RouteRegistrar
+ */
+func NewRouteRegistrar(router *Router) (this *RouteRegistrar) {
+	this = &RouteRegistrar{}
+	this.router = router
+	this.attributes = map[string]string{}
+	return this
+}
+
+/**
+ * Set the value for a given attribute.
+ *
+ * @param  string  key
+ * @param  string  value
+ * @return this
+ */
+func (this *RouteRegistrar) Attribute(key string, value string) *RouteRegistrar {
+	this.attributes[key] = value
+
+	return this
+}
+
+/**
+ * Set the domain the routes respond to.
+ *
+ * @param  string  domain
+ * @return this
+ */
+func (this *RouteRegistrar) Domain(domain string) *RouteRegistrar {
+	return this.Attribute("domain", domain)
+}
+
+/**
+ * Set the prefix of the routes URI.
+ *
+ * @param  string  prefix
+ * @return this
+ */
+func (this *RouteRegistrar) Prefix(prefix string) *RouteRegistrar {
+	return this.Attribute("prefix", prefix)
+}
+
+/**
+ * Set the prefix of the routes name.
+ *
+ * @param  string  name
+ * @return this
+ */
+func (this *RouteRegistrar) As(name string) *RouteRegistrar {
+	return this.Attribute("as", name)
+}
+
+/**
+ * Set the prefix of the routes name.
+ *
+ * @param  string  name
+ * @return this
+ */
+func (this *RouteRegistrar) Name(name string) *RouteRegistrar {
+	return this.As(name)
+}
+
+/**
+ * Set the API version served by the routes.
+ *
+ * @param  string  version
+ * @return this
+ */
+func (this *RouteRegistrar) Version(version string) *RouteRegistrar {
+	return this.Attribute("version", version)
+}
+
+/**
+ * Add middleware to the routes.
+ *
+ * @param  string  middleware
+ * @return this
+ */
+func (this *RouteRegistrar) Middleware(middleware ...string) *RouteRegistrar {
+	if current, ok := this.attributes["middleware"]; ok && current != "" {
+		middleware = append(strings.Split(current, MIDDLEWARE_SEPARATOR), middleware...)
+	}
+
+	return this.Attribute("middleware", strings.Join(middleware, MIDDLEWARE_SEPARATOR))
+}
+
+/**
+ * Create a route group with shared attributes.
+ *
+ * @param  func(*Router)  callback
+ * @return void
+ */
+func (this *RouteRegistrar) Group(callback func(*Router)) {
+	this.router.Group(this.attributes, callback)
+}
+
+/**
+ * Register a new route with the given verbs.
+ *
+ * @param  map[string]bool  methods
+ * @param  string  uri
+ * @param  Action  action
+ * @return *Route
+ */
+func (this *RouteRegistrar) registerRoute(methods map[string]bool, uri string, action func(*Http.Request) *Http.Response) (route *Route) {
+	this.Group(func(router *Router) {
+		route = router.AddRoute(methods, uri, action)
+	})
+
+	return route
+}
+
+func (this *RouteRegistrar) Get(uri string, action func(*Http.Request) *Http.Response) *Route {
+	return this.registerRoute(map[string]bool{"GET": true, "HEAD": true}, uri, action)
+}
+
+func (this *RouteRegistrar) Post(uri string, action func(*Http.Request) *Http.Response) *Route {
+	return this.registerRoute(map[string]bool{"POST": true}, uri, action)
+}
+
+func (this *RouteRegistrar) Put(uri string, action func(*Http.Request) *Http.Response) *Route {
+	return this.registerRoute(map[string]bool{"PUT": true}, uri, action)
+}
+
+func (this *RouteRegistrar) Patch(uri string, action func(*Http.Request) *Http.Response) *Route {
+	return this.registerRoute(map[string]bool{"PATCH": true}, uri, action)
+}
+
+func (this *RouteRegistrar) Delete(uri string, action func(*Http.Request) *Http.Response) *Route {
+	return this.registerRoute(map[string]bool{"DELETE": true}, uri, action)
+}
+
+func (this *RouteRegistrar) Options(uri string, action func(*Http.Request) *Http.Response) *Route {
+	return this.registerRoute(map[string]bool{"OPTIONS": true}, uri, action)
+}
+
+func (this *RouteRegistrar) Any(uri string, action func(*Http.Request) *Http.Response) *Route {
+	return this.registerRoute(Verbs, uri, action)
+}
@@ -0,0 +1,220 @@
+package Routing
+
+import (
+	"sort"
+)
+
+/**
+ * The routes registered for the same domain and URI, along with the
+ * registration position of the first of them so that candidates gathered from
+ * several nodes can be tested in their original order.
+ */
+type routeTreeSlot struct {
+	index  int
+	routes []*Route
+}
+
+type routeTreeNode struct {
+	prefix   string
+	children []*routeTreeNode
+	slots    []*routeTreeSlot
+}
+
+type RouteTree struct {
+	root      *routeTreeNode
+	fallbacks []*routeTreeSlot
+	slots     map[string]*routeTreeSlot
+	size      int
+}
+
+/**
+ * Create a new route tree from the given routes.
+ *
+ * @param  []*Route  routes
+ * @return *RouteTree
+ */
+func NewRouteTree(routes []*Route) (this *RouteTree) {
+	this = &RouteTree{}
+	this.root = &routeTreeNode{}
+	this.fallbacks = []*routeTreeSlot{}
+	this.slots = map[string]*routeTreeSlot{}
+
+	for _, route := range routes {
+		this.Insert(route)
+	}
+
+	return this
+}
+
+/**
+ * Add a route to the tree, indexed by the static prefix of its compiled path.
+ *
+ * @param  *Route  route
+ * @return void
+ */
+func (this *RouteTree) Insert(route *Route) {
+	this.size += 1
+
+	// A route registered again for the same domain and URI joins the slot of the
+	// first one. Which of them is tested is only decided when a request is
+	// matched, as the conditions of a route are added once it is registered.
+	key := route.GetDomain() + route.Uri()
+	if route.IsFallback {
+		key = "fallback:" + key
+	}
+	if slot, ok := this.slots[key]; ok {
+		slot.routes = append(slot.routes, route)
+		return
+	}
+
+	entry := &routeTreeSlot{index: len(this.slots), routes: []*Route{route}}
+	this.slots[key] = entry
+
+	// Fallback routes are always tested after every other route, so we will keep
+	// them aside in registration order instead of indexing them in the tree. A
+	// fallback route usually matches anything so it would be a candidate anyway.
+	if route.IsFallback {
+		this.fallbacks = append(this.fallbacks, entry)
+		return
+	}
+
+	node := this.root
+	prefix := route.compileRoute().GetStaticPrefix()
+
+	for {
+		// Once the whole prefix has been consumed the route belongs to the current
+		// node. Any request path walking through this node shares the prefix, so
+		// the route becomes a candidate for all of those paths.
+		if prefix == "" {
+			node.slots = append(node.slots, entry)
+			return
+		}
+
+		child := node.childFor(prefix[0])
+		if child == nil {
+			node.children = append(node.children, &routeTreeNode{prefix: prefix, slots: []*routeTreeSlot{entry}})
+			return
+		}
+
+		common := commonPrefixLength(prefix, child.prefix)
+
+		// When the new prefix only shares a part of the child's prefix we will split
+		// the child in two, moving its routes and children below a new node which
+		// keeps the remaining part of the original prefix.
+		if common < len(child.prefix) {
+			child.children = []*routeTreeNode{{
+				prefix:   child.prefix[common:],
+				children: child.children,
+				slots:    child.slots,
+			}}
+			child.prefix = child.prefix[:common]
+			child.slots = nil
+		}
+
+		node = child
+		prefix = prefix[common:]
+	}
+}
+
+/**
+ * Get the routes which may match the given path, in registration order and
+ * followed by the fallback routes.
+ *
+ * @param  string  path
+ * @return []*Route
+ */
+func (this *RouteTree) Candidates(path string) []*Route {
+	slots := []*routeTreeSlot{}
+
+	node := this.root
+	for node != nil {
+		slots = append(slots, node.slots...)
+
+		if path == "" {
+			break
+		}
+
+		child := node.childFor(path[0])
+		if child == nil || len(path) < len(child.prefix) || path[:len(child.prefix)] != child.prefix {
+			break
+		}
+
+		node = child
+		path = path[len(child.prefix):]
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].index < slots[j].index
+	})
+
+	routes := []*Route{}
+	for _, slot := range append(slots, this.fallbacks...) {
+		routes = slot.candidates(routes)
+	}
+
+	return routes
+}
+
+/**
+ * Count the number of routes in the tree, fallback routes included.
+ *
+ * @return int
+ */
+func (this *RouteTree) Count() int {
+	return this.size
+}
+
+/**
+ * Append the routes of the slot to test, in order. Routes with conditions are
+ * tested first, in registration order, followed by the last route registered
+ * without any, which replaces the ones registered before it.
+ *
+ * @param  []*Route  routes
+ * @return []*Route
+ */
+func (this *routeTreeSlot) candidates(routes []*Route) []*Route {
+	var unconditional *Route
+	for _, route := range this.routes {
+		if len(route.GetConditions()) > 0 {
+			routes = append(routes, route)
+		} else {
+			unconditional = route
+		}
+	}
+
+	if unconditional != nil {
+		routes = append(routes, unconditional)
+	}
+
+	return routes
+}
+
+/**
+ * Get the child node whose prefix starts with the given byte.
+ *
+ * @param  byte  label
+ * @return *routeTreeNode
+ */
+func (this *routeTreeNode) childFor(label byte) *routeTreeNode {
+	for _, child := range this.children {
+		if child.prefix[0] == label {
+			return child
+		}
+	}
+	return nil
+}
+
+/**
+ * Get the length of the common prefix of the two strings.
+ *
+ * @param  string  a
+ * @param  string  b
+ * @return int
+ */
+func commonPrefixLength(a string, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i += 1
+	}
+	return i
+}
@@ -3,12 +3,17 @@ package Routing
 import (
 	"fmt"
 	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Contracts/Events"
+	"github.com/larisgo/framework/Errors"
 	"github.com/larisgo/framework/Http"
+	"strconv"
 	"strings"
 )
 
 type Action func(*Http.Request) *Http.Response
 
+type Binder func(string) (interface{}, error)
+
 /**
  * All of the verbs supported by the router.
  *
@@ -22,8 +27,19 @@ type Router struct {
 
 	container Container.Container
 
-	middleware       map[string]interface{}
-	middlewareGroups map[string]interface{}
+	/**
+	 * All of the short-hand keys for middlewares.
+	 *
+	 * @var map[string]interface{}
+	 */
+	middleware map[string]interface{}
+
+	/**
+	 * All of the middleware groups.
+	 *
+	 * @var map[string][]interface{}
+	 */
+	middlewareGroups map[string][]interface{}
 
 	/**
 	 * The priority-sorted list of middleware.
@@ -36,9 +52,9 @@ type Router struct {
 	/**
 	 * The registered route value binders.
 	 *
-	 * @var array
+	 * @var map[string]Binder
 	 */
-	binders map[string]interface{}
+	binders map[string]Binder
 	/**
 	 * The globally available parameter patterns.
 	 *
@@ -47,6 +63,41 @@ type Router struct {
 	patterns map[string]string
 
 	groupStack []map[string]string
+
+	/**
+	 * The API versioning of the routes.
+	 *
+	 * @var *ApiVersioning
+	 */
+	versioning *ApiVersioning
+
+	/**
+	 * The callbacks to run when a route has been matched.
+	 *
+	 * @var []func(*RouteMatched)
+	 */
+	matchedCallbacks []func(*RouteMatched)
+
+	/**
+	 * The callbacks to run before a response is prepared.
+	 *
+	 * @var []func(*PreparingResponse)
+	 */
+	preparingCallbacks []func(*PreparingResponse)
+
+	/**
+	 * The callbacks to run after a response has been prepared.
+	 *
+	 * @var []func(*ResponsePrepared)
+	 */
+	preparedCallbacks []func(*ResponsePrepared)
+
+	/**
+	 * The event dispatcher the router events are also sent to.
+	 *
+	 * @var Events.Dispatcher
+	 */
+	events Events.Dispatcher
 }
 
 func NewRouter(container Container.Container) (this *Router) {
@@ -54,7 +105,11 @@ func NewRouter(container Container.Container) (this *Router) {
 	this.container = container
 	this.routes = NewRouteCollection()
 	this.patterns = map[string]string{}
+	this.binders = map[string]Binder{}
+	this.middleware = map[string]interface{}{}
+	this.middlewareGroups = map[string][]interface{}{}
 	this.groupStack = []map[string]string{}
+	this.versioning = NewApiVersioning()
 	return this
 }
 
@@ -101,11 +156,71 @@ func (this *Router) Fallback(action func(*Http.Request) *Http.Response, methods
 	if len(methods) == 0 {
 		methods = []string{"GET"}
 	}
-	return this.Match(methods, fmt.Sprintf("{{%s}}", placeholder), action).Where(placeholder, ".*").Fallback()
+	return this.Match(methods, fmt.Sprintf("{%s}", placeholder), action).Where(placeholder, ".*").Fallback()
+}
+
+/**
+ * Create a redirect from one URI to another.
+ *
+ * @param  string  uri
+ * @param  string  destination
+ * @param  int  status
+ * @return *Route
+ */
+func (this *Router) Redirect(uri string, destination string, status ...int) *Route {
+	status = append(status, Http.HTTP_FOUND)
+
+	return this.Any(uri, NewRedirectController().Handle).
+		Defaults("destination", destination).
+		Defaults("status", strconv.Itoa(status[0]))
+}
+
+/**
+ * Create a permanent redirect from one URI to another.
+ *
+ * @param  string  uri
+ * @param  string  destination
+ * @return *Route
+ */
+func (this *Router) PermanentRedirect(uri string, destination string) *Route {
+	return this.Redirect(uri, destination, Http.HTTP_MOVED_PERMANENTLY)
+}
+
+/**
+ * Register a new route that returns a view.
+ *
+ * @param  string  uri
+ * @param  string  view
+ * @param  map[string]interface{}  data
+ * @param  int  status
+ * @return *Route
+ */
+func (this *Router) View(uri string, view string, data map[string]interface{}, status ...int) *Route {
+	status = append(status, Http.HTTP_OK)
+
+	return this.Match([]string{"GET", "HEAD"}, uri, func(request *Http.Request) *Http.Response {
+		return NewViewController(this.container).Handle(request, data)
+	}).
+		Defaults("view", view).
+		Defaults("status", strconv.Itoa(status[0]))
+}
+
+/**
+ * Register a route serving the files of a directory under the given prefix.
+ *
+ * @param  string  prefix
+ * @param  string  directory
+ * @return *Route
+ */
+func (this *Router) Static(prefix string, directory string) *Route {
+	placeholder := "staticPath"
+
+	return this.Match([]string{"GET", "HEAD"}, fmt.Sprintf("%s/{%s}", strings.TrimRight(prefix, "/"), placeholder), NewStaticController(directory).Handle).
+		Where(placeholder, ".*")
 }
 
 func (this *Router) Match(methods []string, uri string, action func(*Http.Request) *Http.Response) *Route {
-	var _methods map[string]bool
+	_methods := map[string]bool{}
 	for _, v := range methods {
 		_methods[strings.ToUpper(v)] = true
 	}
@@ -117,7 +232,112 @@ func (this *Router) AddRoute(methods map[string]bool, uri string, action func(*H
 }
 
 func (this *Router) createRoute(methods map[string]bool, uri string, action Action) *Route {
-	return NewRoute(methods, this.prefix(uri), action).SetRouter(this)
+	route := NewRoute(methods, this.prefix(uri), action).SetRouter(this)
+
+	// If we have groups that have domains, names or middleware, we'll merge
+	// them into the route before it is added to the collection, so the route
+	// is keyed and matched with the attributes of every group it belongs to.
+	if len(this.groupStack) > 0 {
+		this.mergeGroupAttributesIntoRoute(route)
+	}
+
+	return route
+}
+
+/**
+ * Merge the group stack with the route's action.
+ *
+ * @param  *Route  route
+ * @return void
+ */
+func (this *Router) mergeGroupAttributesIntoRoute(route *Route) {
+	group := this.groupStack[len(this.groupStack)-1]
+
+	if domain, ok := group["domain"]; ok && domain != "" && route.Action.Domain == "" {
+		route.Domain(domain)
+	}
+	if prefix, ok := group["prefix"]; ok {
+		route.Action.Prefix = prefix
+	}
+	if as, ok := group["as"]; ok {
+		route.Action.As = as + route.Action.As
+	}
+	if middleware, ok := group["middleware"]; ok && middleware != "" {
+		route.Action.Middleware = append(strings.Split(middleware, MIDDLEWARE_SEPARATOR), route.Action.Middleware...)
+	}
+	if version, ok := group["version"]; ok && version != "" {
+		route.Version(version)
+	}
+}
+
+/**
+ * Create a route registrar with the given attribute.
+ *
+ * @param  string  key
+ * @param  string  value
+ * @return *RouteRegistrar
+ */
+func (this *Router) Attribute(key string, value string) *RouteRegistrar {
+	return NewRouteRegistrar(this).Attribute(key, value)
+}
+
+/**
+ * Start a route group that responds to the given domain.
+ *
+ * @param  string  domain
+ * @return *RouteRegistrar
+ */
+func (this *Router) Domain(domain string) *RouteRegistrar {
+	return NewRouteRegistrar(this).Domain(domain)
+}
+
+/**
+ * Start a route group with the given URI prefix.
+ *
+ * @param  string  prefix
+ * @return *RouteRegistrar
+ */
+func (this *Router) Prefix(prefix string) *RouteRegistrar {
+	return NewRouteRegistrar(this).Prefix(prefix)
+}
+
+/**
+ * Start a route group with the given name prefix.
+ *
+ * @param  string  name
+ * @return *RouteRegistrar
+ */
+func (this *Router) Name(name string) *RouteRegistrar {
+	return NewRouteRegistrar(this).Name(name)
+}
+
+/**
+ * Start a route group that serves the given API version.
+ *
+ * @param  string  version
+ * @return *RouteRegistrar
+ */
+func (this *Router) Version(version string) *RouteRegistrar {
+	return NewRouteRegistrar(this).Version(version)
+}
+
+/**
+ * Get the API versioning of the routes.
+ *
+ * @return *ApiVersioning
+ */
+func (this *Router) Versioning() *ApiVersioning {
+	return this.versioning
+}
+
+/**
+ * Start a route group with the given middleware.
+ *
+ * @param  string  middleware
+ * @return *RouteRegistrar
+ */
+func (this *Router) Middleware(middleware ...string) *RouteRegistrar {
+	return NewRouteRegistrar(this).Middleware(middleware...)
 }
 
 /**
@@ -258,12 +478,16 @@ func (this *Router) findRoute(request *Http.Request) *Route {
  * @return mixed
  */
 func (this *Router) runRoute(request *Http.Request, route *Route) *Http.Response {
-	// request.SetRouteResolver(func() interface{} {
-	// 	return route
-	// })
-	// $this->events->dispatch(new Events\RouteMatched($route, $request));
+	request.SetRouteResolver(func() interface{} {
+		return route
+	})
+	this.fireMatched(NewRouteMatched(route, request))
 
-	return this.PrepareResponse(request, this.runRouteWithinStack(route, request))
+	this.SubstituteBindings(request)
+
+	// The response of the route is prepared once, at the end of the middleware
+	// stack, so the preparing and prepared listeners are called only once.
+	return this.versioning.AddHeaders(route, this.runRouteWithinStack(route, request))
 }
 
 /**
@@ -277,9 +501,204 @@ func (this *Router) runRouteWithinStack(route *Route, request *Http.Request) *Ht
 	// $shouldSkipMiddleware = $this->container->bound('middleware.disable') &&
 	//                         $this->container->make('middleware.disable') === true;
 
-	// $middleware = $shouldSkipMiddleware ? [] : $this->gatherRouteMiddleware($route);
+	return NewPipeline(this.container).Send(request).Through(this.GatherRouteMiddleware(route)).Then(func(request *Http.Request) *Http.Response {
+		return this.PrepareResponse(request, route.Run(request))
+	})
+}
+
+/**
+ * Gather the middleware for the given route with resolved names.
+ *
+ * @param  Routing\Route  route
+ * @return []interface{}
+ */
+func (this *Router) GatherRouteMiddleware(route *Route) []interface{} {
+	middleware := []interface{}{}
+	for _, name := range route.GetMiddleware() {
+		middleware = append(middleware, this.resolveMiddleware(name)...)
+	}
+
+	return middleware
+}
+
+/**
+ * Resolve the middleware name to the middleware it refers to.
+ *
+ * Groups are expanded to their middleware, and aliases are replaced with the
+ * middleware they were registered with, keeping any ":" parameters.
+ *
+ * @param  string  name
+ * @return []interface{}
+ */
+func (this *Router) resolveMiddleware(name string) []interface{} {
+	// If the middleware is the name of a middleware group, we will return the array
+	// of middlewares that belong to the group. This allows developers to group a
+	// set of middleware under single keys that can be conveniently referenced.
+	if group, ok := this.middlewareGroups[name]; ok {
+		middleware := []interface{}{}
+		for _, item := range group {
+			if _name, ok := item.(string); ok {
+				middleware = append(middleware, this.resolveMiddleware(_name)...)
+			} else {
+				middleware = append(middleware, item)
+			}
+		}
+		return middleware
+	}
+
+	// Finally, when the middleware is simply a string mapped to a middleware we
+	// will get the full middleware and append the parameters, if any, so they
+	// are passed to the middleware when the pipeline runs it on the request.
+	segments := strings.SplitN(name, ":", 2)
+	alias, ok := this.middleware[segments[0]]
+	if !ok {
+		return []interface{}{name}
+	}
+
+	parameters := []string{}
+	if len(segments) > 1 && segments[1] != "" {
+		parameters = strings.Split(segments[1], ",")
+	}
+
+	switch middleware := alias.(type) {
+	case string:
+		if len(parameters) > 0 {
+			return []interface{}{middleware + ":" + strings.Join(parameters, ",")}
+		}
+		return []interface{}{middleware}
+	case Http.Middleware:
+		return []interface{}{this.withParameters(middleware, parameters)}
+	case func(*Http.Request, Http.Next, ...string) *Http.Response:
+		return []interface{}{this.withParameters(Http.MiddlewareFunc(middleware), parameters)}
+	}
+
+	return []interface{}{alias}
+}
+
+/**
+ * Bind the given parameters to the middleware.
+ *
+ * @param  Http.Middleware  middleware
+ * @param  []string  parameters
+ * @return Http.Middleware
+ */
+func (this *Router) withParameters(middleware Http.Middleware, parameters []string) Http.Middleware {
+	if len(parameters) == 0 {
+		return middleware
+	}
+
+	return Http.MiddlewareFunc(func(request *Http.Request, next Http.Next, _ ...string) *Http.Response {
+		return middleware.Handle(request, next, parameters...)
+	})
+}
 
-	return this.PrepareResponse(request, route.Run(request))
+/**
+ * Get all of the defined middleware short-hand names.
+ *
+ * @return map[string]interface{}
+ */
+func (this *Router) GetMiddleware() map[string]interface{} {
+	return this.middleware
+}
+
+/**
+ * Register a short-hand name for a middleware.
+ *
+ * @param  string  name
+ * @param  interface{}  middleware
+ * @return this
+ */
+func (this *Router) AliasMiddleware(name string, middleware interface{}) *Router {
+	this.middleware[name] = middleware
+
+	return this
+}
+
+/**
+ * Check if a middlewareGroup with the given name exists.
+ *
+ * @param  string  name
+ * @return bool
+ */
+func (this *Router) HasMiddlewareGroup(name string) bool {
+	_, ok := this.middlewareGroups[name]
+	return ok
+}
+
+/**
+ * Get all of the defined middleware groups.
+ *
+ * @return map[string][]interface{}
+ */
+func (this *Router) GetMiddlewareGroups() map[string][]interface{} {
+	return this.middlewareGroups
+}
+
+/**
+ * Register a group of middleware.
+ *
+ * @param  string  name
+ * @param  []interface{}  middleware
+ * @return this
+ */
+func (this *Router) MiddlewareGroup(name string, middleware []interface{}) *Router {
+	this.middlewareGroups[name] = middleware
+
+	return this
+}
+
+/**
+ * Add a middleware to the beginning of a middleware group.
+ *
+ * If the middleware is already in the group, it will not be added again.
+ *
+ * @param  string  group
+ * @param  interface{}  middleware
+ * @return this
+ */
+func (this *Router) PrependMiddlewareToGroup(group string, middleware interface{}) *Router {
+	if _, ok := this.middlewareGroups[group]; ok && !this.groupContains(group, middleware) {
+		this.middlewareGroups[group] = append([]interface{}{middleware}, this.middlewareGroups[group]...)
+	}
+
+	return this
+}
+
+/**
+ * Add a middleware to the end of a middleware group.
+ *
+ * If the middleware is already in the group, it will not be added again.
+ *
+ * @param  string  group
+ * @param  interface{}  middleware
+ * @return this
+ */
+func (this *Router) PushMiddlewareToGroup(group string, middleware interface{}) *Router {
+	if !this.groupContains(group, middleware) {
+		this.middlewareGroups[group] = append(this.middlewareGroups[group], middleware)
+	}
+
+	return this
+}
+
+/**
+ * Determine if the middleware group contains the given middleware.
+ *
+ * @param  string  group
+ * @param  interface{}  middleware
+ * @return bool
+ */
+func (this *Router) groupContains(group string, middleware interface{}) bool {
+	name, ok := middleware.(string)
+	if !ok {
+		return false
+	}
+	for _, item := range this.middlewareGroups[group] {
+		if item == name {
+			return true
+		}
+	}
+	return false
 }
 
 /**
@@ -290,7 +709,13 @@ func (this *Router) runRouteWithinStack(route *Route, request *Http.Request) *Ht
  * @return \Illuminate\Http\Response|\Illuminate\Http\JsonResponse
  */
 func (this *Router) PrepareResponse(request *Http.Request, response *Http.Response) *Http.Response {
-	return this.ToResponse(request, response)
+	this.firePreparing(NewPreparingResponse(request, response))
+
+	response = this.ToResponse(request, response)
+
+	this.firePrepared(NewResponsePrepared(request, response))
+
+	return response
 }
 
 /**
@@ -303,3 +728,186 @@ func (this *Router) PrepareResponse(request *Http.Request, response *Http.Respon
 func (this *Router) ToResponse(request *Http.Request, response *Http.Response) *Http.Response {
 	return response.Prepare(request)
 }
+
+/**
+ * Set the event dispatcher the router events are sent to.
+ *
+ * @param  Events.Dispatcher  events
+ * @return void
+ */
+func (this *Router) SetEventDispatcher(events Events.Dispatcher) {
+	this.events = events
+}
+
+/**
+ * Register a route matched event listener.
+ *
+ * @param  func(*RouteMatched)  callback
+ * @return void
+ */
+func (this *Router) Matched(callback func(*RouteMatched)) {
+	this.matchedCallbacks = append(this.matchedCallbacks, callback)
+}
+
+/**
+ * Register a listener that runs before a response is prepared.
+ *
+ * @param  func(*PreparingResponse)  callback
+ * @return void
+ */
+func (this *Router) Preparing(callback func(*PreparingResponse)) {
+	this.preparingCallbacks = append(this.preparingCallbacks, callback)
+}
+
+/**
+ * Register a listener that runs after a response has been prepared.
+ *
+ * @param  func(*ResponsePrepared)  callback
+ * @return void
+ */
+func (this *Router) Prepared(callback func(*ResponsePrepared)) {
+	this.preparedCallbacks = append(this.preparedCallbacks, callback)
+}
+
+/**
+ * Call the route matched listeners.
+ *
+ * @param  *RouteMatched  event
+ * @return void
+ */
+func (this *Router) fireMatched(event *RouteMatched) {
+	for _, callback := range this.matchedCallbacks {
+		callback(event)
+	}
+
+	if this.events != nil {
+		this.events.Dispatch(event)
+	}
+}
+
+/**
+ * Call the listeners that run before a response is prepared.
+ *
+ * @param  *PreparingResponse  event
+ * @return void
+ */
+func (this *Router) firePreparing(event *PreparingResponse) {
+	for _, callback := range this.preparingCallbacks {
+		callback(event)
+	}
+
+	if this.events != nil {
+		this.events.Dispatch(event)
+	}
+}
+
+/**
+ * Call the listeners that run after a response has been prepared.
+ *
+ * @param  *ResponsePrepared  event
+ * @return void
+ */
+func (this *Router) firePrepared(event *ResponsePrepared) {
+	for _, callback := range this.preparedCallbacks {
+		callback(event)
+	}
+
+	if this.events != nil {
+		this.events.Dispatch(event)
+	}
+}
+
+/**
+ * Substitute the route bindings onto the route.
+ *
+ * @param  Http.Request  request
+ * @return void
+ *
+ * @throws Errors.ModelNotFoundException
+ */
+func (this *Router) SubstituteBindings(request *Http.Request) {
+	for key, value := range request.RouteParameters() {
+		if _value, ok := value.(string); ok {
+			if binder := this.GetBindingCallback(key); binder != nil {
+				request.SetRouteParameter(key, this.performBinding(binder, _value))
+			}
+		}
+	}
+}
+
+/**
+ * Call the binding callback for the given key.
+ *
+ * @param  Binder  binder
+ * @param  string  value
+ * @return interface{}
+ *
+ * @throws Errors.ModelNotFoundException
+ */
+func (this *Router) performBinding(binder Binder, value string) interface{} {
+	resolved, err := binder(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return resolved
+}
+
+/**
+ * Add a new route parameter binder.
+ *
+ * @param  string  key
+ * @param  func(string) (interface{}, error)  binder
+ * @return void
+ */
+func (this *Router) Bind(key string, binder func(string) (interface{}, error)) {
+	this.binders[strings.Replace(key, "-", "_", -1)] = Binder(binder)
+}
+
+/**
+ * Register a model binder for a wildcard.
+ *
+ * The resolver reports a missing model by returning a nil value or an
+ * Errors.ModelNotFoundException, in which case the optional callback is
+ * consulted before the request is aborted with a 404.
+ *
+ * @param  string  key
+ * @param  func(string) (interface{}, error)  resolver
+ * @param  func(string) (interface{}, error)  callback
+ * @return void
+ *
+ * @throws Errors.ModelNotFoundException
+ */
+func (this *Router) Model(key string, resolver func(string) (interface{}, error), callback ...func(string) (interface{}, error)) {
+	this.Bind(key, func(value string) (interface{}, error) {
+		// For model binders, we will attempt to retrieve the models using the resolver
+		// and if we find the model instance we will return it. Otherwise we will use
+		// the callback to determine what we should do when the model isn't found.
+		model, err := resolver(value)
+		if err == nil && model != nil {
+			return model, nil
+		}
+		if _, ok := err.(Errors.ModelNotFoundException); err != nil && !ok {
+			return nil, err
+		}
+
+		if len(callback) > 0 {
+			return callback[0](value)
+		}
+
+		return nil, Errors.NewModelNotFoundException(fmt.Sprintf("No query results for model [%s] %s.", key, value))
+	})
+}
+
+/**
+ * Get the binding callback for a given binding.
+ *
+ * @param  string  key
+ * @return Binder|nil
+ */
+func (this *Router) GetBindingCallback(key string) Binder {
+	if binder, ok := this.binders[strings.Replace(key, "-", "_", -1)]; ok {
+		return binder
+	}
+	return nil
+}
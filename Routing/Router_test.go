@@ -0,0 +1,38 @@
+package Routing
+
+import (
+	"testing"
+
+	"github.com/larisgo/framework/Events"
+	"github.com/larisgo/framework/Http"
+)
+
+func TestResponseIsPreparedOnce(t *testing.T) {
+	router := NewRouter(nil)
+	router.AliasMiddleware("header", func(request *Http.Request, next Http.Next, _ ...string) *Http.Response {
+		return next(request).Header("X-Middleware", "1")
+	})
+	router.Get("users", respond("users")).Middleware("header")
+
+	preparing, prepared := 0, 0
+	router.Preparing(func(*PreparingResponse) { preparing++ })
+	router.Prepared(func(*ResponsePrepared) { prepared++ })
+
+	events := Events.NewDispatcher(nil)
+	preparingEvents, preparedEvents := 0, 0
+	events.Listen(&PreparingResponse{}, func(*PreparingResponse) { preparingEvents++ })
+	events.Listen(&ResponsePrepared{}, func(*ResponsePrepared) { preparedEvents++ })
+	router.SetEventDispatcher(events)
+
+	response := router.Dispatch(newTestRequest("GET", "/users"))
+
+	if response.Headers.Get("X-Middleware") != "1" {
+		t.Errorf("expected the response to go through the middleware")
+	}
+	if preparing != 1 || prepared != 1 {
+		t.Errorf("expected the preparing and prepared callbacks to be called once, got %d and %d", preparing, prepared)
+	}
+	if preparingEvents != 1 || preparedEvents != 1 {
+		t.Errorf("expected the preparing and prepared events to be dispatched once, got %d and %d", preparingEvents, preparedEvents)
+	}
+}
@@ -0,0 +1,212 @@
+package Routing
+
+import (
+	"github.com/larisgo/framework/Http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+/**
+ * The file names of assets whose name holds a hash of their content, such as
+ * "app.3f2a9c1b.js" or "app-3f2a9c1b.css".
+ *
+ * @var *regexp.Regexp
+ */
+var fingerprintPattern = regexp.MustCompile(`(?i)[.-]([0-9a-f]{8,})\.[a-z0-9]+$`)
+
+/**
+ * The hidden files and directories that are never served, along with their
+ * variants such as ".env.local". Other dot files, such as the ".well-known"
+ * directory, are served like any file.
+ *
+ * @var []string
+ */
+var hiddenStaticFiles = []string{
+	".env", ".git", ".gitignore", ".gitattributes", ".gitmodules", ".hg", ".svn",
+	".htaccess", ".htpasswd", ".DS_Store", ".npmrc", ".ssh", ".idea", ".vscode",
+}
+
+/**
+ * The precompressed variants of a file, in the order of preference.
+ *
+ * @var [][2]string
+ */
+var precompressedEncodings = [][2]string{{"br", ".br"}, {"gzip", ".gz"}}
+
+type StaticController struct {
+	/**
+	 * The directory the files are served from.
+	 *
+	 * @var string
+	 */
+	directory string
+}
+
+/**
+ * Create a new static controller instance.
+ *
+ * @param  string  directory
+ * @return *StaticController
+ */
+func NewStaticController(directory string) *StaticController {
+	return &StaticController{directory: directory}
+}
+
+/**
+ * Serve the file of the directory the request path points to.
+ *
+ * A missing file is answered with a plain "404 Not Found" response, so
+ * requests for missing assets are not reported as exceptions.
+ *
+ * @param  Http.Request  request
+ * @return Http.Response
+ */
+func (this *StaticController) Handle(request *Http.Request) *Http.Response {
+	file, ok := this.resolve(request.Attributes.Get("staticPath"))
+	if !ok {
+		return Http.NewResponse("Not Found", Http.HTTP_NOT_FOUND)
+	}
+
+	response := Http.NewBinaryFileResponse(file, Http.HTTP_OK)
+
+	variant, encoding, varies := this.precompressed(request, file)
+	if variant != "" {
+		contentType := response.Headers.Get("Content-Type")
+		response.SetFile(variant).SetAutoLastModified()
+		response.Header("Content-Type", contentType)
+		response.Header("Content-Encoding", encoding)
+	}
+	if varies {
+		response.Header("Vary", "Accept-Encoding")
+	}
+
+	// The ETag of a precompressed variant carries its encoding, as variants
+	// of the same size and date still differ in content.
+	response.SetAutoEtag()
+	if variant != "" {
+		response.Header("ETag", strings.TrimSuffix(response.Headers.Get("ETag"), `"`)+"-"+encoding+`"`)
+	}
+
+	if this.isFingerprinted(file) {
+		response.Header("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		response.Header("Cache-Control", "public, no-cache")
+	}
+
+	return response
+}
+
+/**
+ * Resolve the file the given path points to within the directory.
+ *
+ * Paths escaping the directory, through ".." segments or symbolic links, and
+ * hidden files such as ".env" or ".git" are never served. A directory is
+ * served by its "index.html" file.
+ *
+ * @param  string  uri
+ * @return string, bool
+ */
+func (this *StaticController) resolve(uri string) (string, bool) {
+	if strings.ContainsAny(uri, "\x00\\") {
+		return "", false
+	}
+
+	for _, segment := range strings.Split(uri, "/") {
+		if segment == ".." || this.isHidden(segment) {
+			return "", false
+		}
+	}
+	uri = path.Clean("/" + uri)
+
+	root, err := filepath.EvalSymlinks(this.directory)
+	if err != nil {
+		return "", false
+	}
+
+	file, err := filepath.EvalSymlinks(filepath.Join(root, filepath.FromSlash(uri)))
+	if err != nil {
+		return "", false
+	}
+
+	if relative, err := filepath.Rel(root, file); err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	info, err := os.Stat(file)
+	if err == nil && info.IsDir() {
+		file = filepath.Join(file, "index.html")
+		info, err = os.Stat(file)
+	}
+	if err != nil || !info.Mode().IsRegular() {
+		return "", false
+	}
+
+	return file, true
+}
+
+/**
+ * Determine if the given path segment names a hidden file that is never
+ * served.
+ *
+ * @param  string  segment
+ * @return bool
+ */
+func (this *StaticController) isHidden(segment string) bool {
+	for _, hidden := range hiddenStaticFiles {
+		if strings.EqualFold(segment, hidden) || strings.HasPrefix(strings.ToLower(segment), strings.ToLower(hidden)+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+ * Find a precompressed variant of the file the client accepts. Whether the
+ * file has any variant is returned too, as the response then varies with
+ * the accepted encodings.
+ *
+ * @param  Http.Request  request
+ * @param  string  file
+ * @return string, string, bool
+ */
+func (this *StaticController) precompressed(request *Http.Request, file string) (string, string, bool) {
+	accepted := map[string]bool{}
+	for _, encoding := range request.GetEncodings() {
+		accepted[strings.ToLower(encoding)] = true
+	}
+
+	varies := false
+	for _, encoding := range precompressedEncodings {
+		if info, err := os.Stat(file + encoding[1]); err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		varies = true
+
+		if accepted[encoding[0]] || accepted["*"] {
+			return file + encoding[1], encoding[0], true
+		}
+	}
+
+	return "", "", varies
+}
+
+/**
+ * Determine if the file is a fingerprinted asset, which never changes under
+ * its URL and may be cached for good.
+ *
+ * Only a hash of the content in the file name counts, a query string such as
+ * "?v=2" may be left the same while the file changes. A run of digits alone is
+ * more likely a version or a date than a hash, so it does not count either.
+ *
+ * @param  string  file
+ * @return bool
+ */
+func (this *StaticController) isFingerprinted(file string) bool {
+	m := fingerprintPattern.FindStringSubmatch(filepath.Base(file))
+
+	return m != nil && strings.ContainsAny(m[1], "abcdefABCDEF")
+}
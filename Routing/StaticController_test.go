@@ -0,0 +1,94 @@
+package Routing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/larisgo/framework/Http"
+)
+
+func newStaticDirectory(t *testing.T) (string, func()) {
+	t.Helper()
+
+	root, err := ioutil.TempDir("", "static")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range map[string]string{
+		"public/app.js":                   "app",
+		"public/app.3f2a9c1b.js":          "hashed",
+		"public/app-20240101.js":          "dated",
+		"public/docs/index.html":          "docs",
+		"public/.well-known/security.txt": "security",
+		"public/.env":                     "SECRET=1",
+		"public/.env.local":               "SECRET=2",
+		"public/.git/config":              "[core]",
+		"public/assets/.DS_Store":         "finder",
+		"secret.txt":                      "outside",
+		"public-backup/app.js":            "sibling",
+	} {
+		file := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return filepath.Join(root, "public"), func() { os.RemoveAll(root) }
+}
+
+func serveStatic(directory string, target string, staticPath string) *Http.Response {
+	request := newTestRequest("GET", target)
+	request.Attributes.Add("staticPath", staticPath)
+
+	return NewStaticController(directory).Handle(request)
+}
+
+func TestStaticFilesAreServedFromTheDirectoryOnly(t *testing.T) {
+	directory, cleanup := newStaticDirectory(t)
+	defer cleanup()
+
+	for staticPath, status := range map[string]int{
+		"app.js":                   Http.HTTP_OK,
+		"docs":                     Http.HTTP_OK,
+		".well-known/security.txt": Http.HTTP_OK,
+		".env":                     Http.HTTP_NOT_FOUND,
+		".env.local":               Http.HTTP_NOT_FOUND,
+		".ENV":                     Http.HTTP_NOT_FOUND,
+		".git/config":              Http.HTTP_NOT_FOUND,
+		"assets/.DS_Store":         Http.HTTP_NOT_FOUND,
+		"missing.js":               Http.HTTP_NOT_FOUND,
+		"../secret.txt":            Http.HTTP_NOT_FOUND,
+		"docs/../../secret.txt":    Http.HTTP_NOT_FOUND,
+		"../public-backup/app.js":  Http.HTTP_NOT_FOUND,
+		"docs/..\\..\\secret.txt":  Http.HTTP_NOT_FOUND,
+		"docs/../app.js":           Http.HTTP_NOT_FOUND,
+	} {
+		if response := serveStatic(directory, "/", staticPath); response.Status() != status {
+			t.Errorf("%s: expected %d, got %d", staticPath, status, response.Status())
+		}
+	}
+}
+
+func TestOnlyContentHashedFilesAreCachedForGood(t *testing.T) {
+	directory, cleanup := newStaticDirectory(t)
+	defer cleanup()
+
+	for target, cacheControl := range map[[2]string]string{
+		{"/app.3f2a9c1b.js", "app.3f2a9c1b.js"}: "public, max-age=31536000, immutable",
+		{"/app-20240101.js", "app-20240101.js"}: "public, no-cache",
+		{"/app.js?v=2", "app.js"}:               "public, no-cache",
+		{"/app.js?id=3f2a9c1b", "app.js"}:       "public, no-cache",
+	} {
+		response := serveStatic(directory, target[0], target[1])
+
+		if actual := response.Headers.Get("Cache-Control"); actual != cacheControl {
+			t.Errorf("%s: expected Cache-Control %q, got %q", target[0], cacheControl, actual)
+		}
+	}
+}
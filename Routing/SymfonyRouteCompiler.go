@@ -274,7 +274,7 @@ func (this *SymfonyRouteCompiler) determineStaticPrefix(route *SymfonyRoute, tok
 	}
 
 	prefix := tokens[0][1]
-	if len(tokens) > 1 && len(tokens[1]) > 1 && tokens[1][1] != "/" && route.HasDefault(tokens[1][3]) {
+	if len(tokens) > 1 && len(tokens[1]) > 1 && tokens[1][1] != "/" && !route.HasDefault(tokens[1][3]) {
 		prefix += tokens[1][1]
 	}
 
@@ -0,0 +1,310 @@
+package Routing
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+type UrlGenerator struct {
+	/**
+	 * The route collection.
+	 *
+	 * @var *RouteCollection
+	 */
+	routes *RouteCollection
+
+	/**
+	 * The root URL used when there is no request to take it from.
+	 *
+	 * @var string
+	 */
+	rootUrl string
+
+	/**
+	 * The forced root URL, used even when there is a request.
+	 *
+	 * @var string
+	 */
+	forcedRoot string
+
+	/**
+	 * The forced scheme for URLs.
+	 *
+	 * @var string
+	 */
+	forceScheme string
+
+	lock sync.RWMutex
+}
+
+/**
+ * Create a new URL Generator instance.
+ *
+ * @param  *RouteCollection  routes
+ * @param  string  rootUrl
+ * @return *UrlGenerator
+ */
+func NewUrlGenerator(routes *RouteCollection, rootUrl ...string) (this *UrlGenerator) {
+	rootUrl = append(rootUrl, "")
+
+	this = &UrlGenerator{}
+	this.routes = routes
+	this.rootUrl = strings.TrimRight(rootUrl[0], "/")
+	return this
+}
+
+/**
+ * Generate a URL to the given path, absolute when the root URL is known
+ * from the request or the configuration.
+ *
+ * @param  string  path
+ * @param  Http.Request  request
+ * @return string
+ */
+func (this *UrlGenerator) To(path string, request ...*Http.Request) string {
+	// First we will check if the URL is already a valid URL. If it is we will not
+	// try to generate a new one but will simply return the URL as is, which is
+	// convenient since developers do not always have to check if it's valid.
+	if this.IsValidUrl(path) {
+		return path
+	}
+
+	return this.FormatRoot(request...) + "/" + strings.Trim(path, "/")
+}
+
+/**
+ * Generate a URL to the given named route.
+ *
+ * @param  string  name
+ * @param  map[string]interface{}  parameters
+ * @param  Http.Request  request
+ * @return string
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *UrlGenerator) Route(name string, parameters map[string]interface{}, request ...*Http.Request) string {
+	route := this.routes.GetByName(name)
+	if route == nil {
+		// Routes named after they were added are only in the look-up table once
+		// it is refreshed, so we will look through all of them as well.
+		for _, _route := range this.routes.GetRoutes() {
+			if _route.GetName() == name {
+				route = _route
+			}
+		}
+	}
+
+	if route == nil {
+		panic(Errors.NewInvalidArgumentException(fmt.Sprintf("Route [%s] not defined.", name)))
+	}
+
+	return this.ToRoute(route, parameters, request...)
+}
+
+/**
+ * Get the URL for a given route instance.
+ *
+ * @param  *Route  route
+ * @param  map[string]interface{}  parameters
+ * @param  Http.Request  request
+ * @return string
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *UrlGenerator) ToRoute(route *Route, parameters map[string]interface{}, request ...*Http.Request) string {
+	values := map[string]string{}
+	for key, value := range route.defaults {
+		values[key] = value
+	}
+	for key, value := range parameters {
+		values[key] = fmt.Sprint(value)
+	}
+
+	used := map[string]bool{}
+	missing := []string{}
+	replace := func(subject string, escape func(string) string) string {
+		return regexp.MustCompile(`(/?)\{(\w+)(\??)\}`).ReplaceAllStringFunc(subject, func(placeholder string) string {
+			m := regexp.MustCompile(`(/?)\{(\w+)(\??)\}`).FindStringSubmatch(placeholder)
+			used[m[2]] = true
+			if value := values[m[2]]; value != "" {
+				return m[1] + escape(value)
+			}
+			if m[3] == "" {
+				missing = append(missing, m[2])
+			}
+			return ""
+		})
+	}
+
+	domain := replace(route.GetDomain(), func(value string) string { return value })
+	path := replace(route.Uri(), url.PathEscape)
+
+	if len(missing) > 0 {
+		panic(Errors.NewInvalidArgumentException(fmt.Sprintf("Missing required parameters for [Route: %s] [URI: %s] [Missing parameters: %s].", route.GetName(), route.Uri(), strings.Join(missing, ", "))))
+	}
+
+	root := ""
+	if domain != "" {
+		root = this.formatScheme(route, request...) + domain + this.formatPort(request...)
+	} else {
+		root = this.FormatRoot(request...)
+		if route.Secure() && strings.HasPrefix(root, "http://") {
+			root = "https://" + strings.TrimPrefix(root, "http://")
+		}
+	}
+
+	uri := root + "/" + strings.Trim(path, "/")
+
+	// The parameters that are not in the URI of the route are passed along in
+	// the query string.
+	query := url.Values{}
+	for key := range parameters {
+		if !used[key] {
+			query.Set(key, values[key])
+		}
+	}
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+
+	return uri
+}
+
+/**
+ * Get the URL for the previous request.
+ *
+ * @param  Http.Request  request
+ * @param  string  fallback
+ * @return string
+ */
+func (this *UrlGenerator) Previous(request *Http.Request, fallback ...string) string {
+	if referrer := request.Headers.Get("Referer"); referrer != "" {
+		return referrer
+	}
+
+	if len(fallback) > 0 && fallback[0] != "" {
+		return this.To(fallback[0], request)
+	}
+
+	return this.To("/", request)
+}
+
+/**
+ * Get the base URL for the request, or the root URL when there is no
+ * request. It is empty when neither is known, making the URLs relative.
+ *
+ * @param  Http.Request  request
+ * @return string
+ */
+func (this *UrlGenerator) FormatRoot(request ...*Http.Request) string {
+	this.lock.RLock()
+	forcedRoot, forceScheme, rootUrl := this.forcedRoot, this.forceScheme, this.rootUrl
+	this.lock.RUnlock()
+
+	root := rootUrl
+	if forcedRoot != "" {
+		root = forcedRoot
+	} else if len(request) > 0 && request[0] != nil && request[0].GetHost() != "" {
+		root = request[0].Scheme() + "://" + request[0].GetHost() + this.formatPort(request...)
+	}
+
+	if forceScheme != "" && root != "" {
+		if i := strings.Index(root, "://"); i > -1 {
+			root = forceScheme + root[i:]
+		}
+	}
+
+	return root
+}
+
+/**
+ * Get the scheme for a route with a domain.
+ *
+ * @param  *Route  route
+ * @param  Http.Request  request
+ * @return string
+ */
+func (this *UrlGenerator) formatScheme(route *Route, request ...*Http.Request) string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	switch {
+	case route.Secure():
+		return "https://"
+	case route.HttpOnly():
+		return "http://"
+	case this.forceScheme != "":
+		return this.forceScheme + "://"
+	case len(request) > 0 && request[0] != nil:
+		return request[0].Scheme() + "://"
+	}
+
+	return "http://"
+}
+
+/**
+ * Get the port of the request, when it is not the default one of its scheme.
+ *
+ * @param  Http.Request  request
+ * @return string
+ */
+func (this *UrlGenerator) formatPort(request ...*Http.Request) string {
+	if len(request) == 0 || request[0] == nil {
+		return ""
+	}
+
+	port := request[0].Port()
+	if (request[0].Scheme() == "http" && port == 80) || (request[0].Scheme() == "https" && port == 443) {
+		return ""
+	}
+
+	return ":" + strconv.Itoa(port)
+}
+
+/**
+ * Determine if the given path is a valid URL.
+ *
+ * @param  string  path
+ * @return bool
+ */
+func (this *UrlGenerator) IsValidUrl(path string) bool {
+	for _, prefix := range []string{"#", "//", "mailto:", "tel:", "sms:", "http://", "https://"} {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/**
+ * Set the forced root URL.
+ *
+ * @param  string  root
+ * @return void
+ */
+func (this *UrlGenerator) ForceRootUrl(root string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.forcedRoot = strings.TrimRight(root, "/")
+}
+
+/**
+ * Force the scheme for URLs.
+ *
+ * @param  string  scheme
+ * @return void
+ */
+func (this *UrlGenerator) ForceScheme(scheme string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.forceScheme = strings.TrimSuffix(scheme, "://")
+}
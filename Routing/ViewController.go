@@ -0,0 +1,64 @@
+package Routing
+
+import (
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Errors"
+	"github.com/larisgo/framework/Http"
+	"github.com/larisgo/framework/View"
+	"strconv"
+)
+
+type ViewController struct {
+	/**
+	 * The container instance the view factory is resolved from.
+	 *
+	 * @var Container.Container
+	 */
+	container Container.Container
+}
+
+/**
+ * Create a new view controller instance.
+ *
+ * @param  Container.Container  container
+ * @return *ViewController
+ */
+func NewViewController(container Container.Container) *ViewController {
+	return &ViewController{container: container}
+}
+
+/**
+ * Render the view of the route with the given data and the route parameters.
+ *
+ * @param  Http.Request  request
+ * @param  map[string]interface{}  data
+ * @return Http.Response
+ *
+ * @throws Errors.RuntimeException
+ */
+func (this *ViewController) Handle(request *Http.Request, data map[string]interface{}) *Http.Response {
+	var factory *View.Factory
+	if this.container != nil {
+		factory, _ = this.container.Make("view").(*View.Factory)
+	}
+	if factory == nil {
+		panic(Errors.NewRuntimeException("The view factory [view] is not bound in the container."))
+	}
+
+	status, err := strconv.Atoi(request.Attributes.Get("status"))
+	if err != nil {
+		status = Http.HTTP_OK
+	}
+
+	parameters := map[string]interface{}{}
+	for key, value := range data {
+		parameters[key] = value
+	}
+	for key, value := range request.RouteParameters() {
+		if key != "view" && key != "status" {
+			parameters[key] = value
+		}
+	}
+
+	return Http.NewResponse(factory.Make(request.Attributes.Get("view"), parameters).Render(), status)
+}
@@ -125,6 +125,11 @@ func (this *arr) Has(array map[string]interface{}, keys []string) bool {
  */
 func (this *arr) Set(array *map[string]interface{}, key string, value interface{}) *map[string]interface{} {
 
+	if *array == nil {
+		*array = map[string]interface{}{}
+	}
+
+	current := *array
 	keys := strings.Split(key, ".")
 	for len(keys) > 1 {
 		key := keys[0]
@@ -133,14 +138,14 @@ func (this *arr) Set(array *map[string]interface{}, key string, value interface{
 		// If the key doesn't exist at this depth, we will just create an empty array
 		// to hold the next value, allowing us to create the arrays to hold final
 		// values at the correct depth. Then we'll keep digging into the array.
-		if v, ok := (*array)[key]; !ok {
-			(*array)[key] = map[string]interface{}{}
+		if v, ok := current[key]; !ok {
+			current[key] = map[string]interface{}{}
 		} else if _, tok := v.(map[string]interface{}); !tok {
-			(*array)[key] = map[string]interface{}{}
+			current[key] = map[string]interface{}{}
 		}
-		(*array) = (*array)[key].(map[string]interface{})
+		current = current[key].(map[string]interface{})
 	}
 
-	(*array)[keys[0]] = value
+	current[keys[0]] = value
 	return array
 }
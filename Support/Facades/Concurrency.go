@@ -0,0 +1,9 @@
+package Facades
+
+import (
+	ConcurrencyPool "github.com/larisgo/framework/Concurrency"
+)
+
+var Concurrency func() *ConcurrencyPool.Pool = func() *ConcurrencyPool.Pool {
+	return NewFacade("pool").Get().(*ConcurrencyPool.Pool)
+}
@@ -0,0 +1,9 @@
+package Facades
+
+import (
+	CookieJar "github.com/larisgo/framework/Cookie"
+)
+
+var Cookie func() *CookieJar.CookieJar = func() *CookieJar.CookieJar {
+	return NewFacade("cookie").Get().(*CookieJar.CookieJar)
+}
@@ -0,0 +1,9 @@
+package Facades
+
+import (
+	"github.com/larisgo/framework/Encryption"
+)
+
+var Crypt func() *Encryption.Encrypter = func() *Encryption.Encrypter {
+	return NewFacade("encrypter").Get().(*Encryption.Encrypter)
+}
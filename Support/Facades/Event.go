@@ -0,0 +1,9 @@
+package Facades
+
+import (
+	"github.com/larisgo/framework/Events"
+)
+
+var Event func() *Events.Dispatcher = func() *Events.Dispatcher {
+	return NewFacade("events").Get().(*Events.Dispatcher)
+}
@@ -0,0 +1,9 @@
+package Facades
+
+import (
+	QueueManager "github.com/larisgo/framework/Queue"
+)
+
+var Queue func() *QueueManager.QueueManager = func() *QueueManager.QueueManager {
+	return NewFacade("queue").Get().(*QueueManager.QueueManager)
+}
@@ -0,0 +1,9 @@
+package Facades
+
+import (
+	"github.com/larisgo/framework/Cache"
+)
+
+var RateLimiter func() *Cache.RateLimiter = func() *Cache.RateLimiter {
+	return NewFacade("limiter").Get().(*Cache.RateLimiter)
+}
@@ -0,0 +1,9 @@
+package Facades
+
+import (
+	"github.com/larisgo/framework/Routing"
+)
+
+var Response func() *Routing.ResponseFactory = func() *Routing.ResponseFactory {
+	return NewFacade("response.factory").Get().(*Routing.ResponseFactory)
+}
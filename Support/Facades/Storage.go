@@ -0,0 +1,9 @@
+package Facades
+
+import (
+	"github.com/larisgo/framework/Filesystem"
+)
+
+var Storage func() *Filesystem.FilesystemManager = func() *Filesystem.FilesystemManager {
+	return NewFacade("filesystem").Get().(*Filesystem.FilesystemManager)
+}
@@ -0,0 +1,9 @@
+package Facades
+
+import (
+	"github.com/larisgo/framework/Routing"
+)
+
+var URL func() *Routing.UrlGenerator = func() *Routing.UrlGenerator {
+	return NewFacade("url").Get().(*Routing.UrlGenerator)
+}
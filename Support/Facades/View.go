@@ -0,0 +1,9 @@
+package Facades
+
+import (
+	ViewFactory "github.com/larisgo/framework/View"
+)
+
+var View func() *ViewFactory.Factory = func() *ViewFactory.Factory {
+	return NewFacade("view").Get().(*ViewFactory.Factory)
+}
@@ -1,6 +1,7 @@
 package Support
 
 import (
+	"github.com/larisgo/framework/Console"
 	"github.com/larisgo/framework/Contracts/Foundation"
 )
 
@@ -23,3 +24,15 @@ func NewServiceProvider(app Foundation.Application) *ServiceProvider {
 func (this *ServiceProvider) IsDeferred() bool {
 	return this.Defer
 }
+
+/**
+ * Register the package's custom console commands.
+ *
+ * @param  interface{}  commands
+ * @return void
+ */
+func (this *ServiceProvider) Commands(commands ...interface{}) {
+	Console.Starting(func(artisan *Console.Application) {
+		artisan.ResolveCommands(commands...)
+	})
+}
@@ -0,0 +1,199 @@
+package View
+
+import (
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+type Factory struct {
+	/**
+	 * The array of active view paths.
+	 *
+	 * @var []string
+	 */
+	paths []string
+
+	/**
+	 * The extension of the view files.
+	 *
+	 * @var string
+	 */
+	extension string
+
+	/**
+	 * Data that should be available to all templates.
+	 *
+	 * @var map[string]interface{}
+	 */
+	shared map[string]interface{}
+
+	lock sync.RWMutex
+}
+
+/**
+ * Create a new view factory instance.
+ *
+ * @param  []string  paths
+ * @return *Factory
+ */
+func NewFactory(paths []string) (this *Factory) {
+	this = &Factory{}
+	this.paths = paths
+	this.extension = ".html"
+	this.shared = map[string]interface{}{}
+	return this
+}
+
+/**
+ * Get the evaluated view contents for the given view.
+ *
+ * @param  string  view
+ * @param  map[string]interface{}  data
+ * @return *View
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *Factory) Make(view string, data ...map[string]interface{}) *View {
+	// Next, we will create the view instance and call the view creator for the view
+	// which can set any data, etc. Then we will return the view instance back to
+	// the caller for rendering or performing other view manipulations on this.
+	return NewView(this, view, this.Find(view), this.gatherData(data...))
+}
+
+/**
+ * Determine if a given view exists.
+ *
+ * @param  string  view
+ * @return bool
+ */
+func (this *Factory) Exists(view string) bool {
+	_, ok := this.find(view)
+	return ok
+}
+
+/**
+ * Get the fully qualified location of the view.
+ *
+ * @param  string  name
+ * @return string
+ *
+ * @throws Errors.InvalidArgumentException
+ */
+func (this *Factory) Find(name string) string {
+	path, ok := this.find(name)
+	if !ok {
+		panic(Errors.NewInvalidArgumentException(fmt.Sprintf("View [%s] not found.", name)))
+	}
+	return path
+}
+
+/**
+ * Look for the view in the list of paths.
+ *
+ * @param  string  name
+ * @return string, bool
+ */
+func (this *Factory) find(name string) (string, bool) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	// View names use dots to separate directories, "emails.welcome" is found
+	// at "emails/welcome.html" below one of the view paths.
+	file := filepath.FromSlash(strings.Replace(name, ".", "/", -1)) + this.extension
+
+	for _, path := range this.paths {
+		if info, err := os.Stat(filepath.Join(path, file)); err == nil && !info.IsDir() {
+			return filepath.Join(path, file), true
+		}
+	}
+
+	return "", false
+}
+
+/**
+ * Add a location to the array of view locations.
+ *
+ * @param  string  location
+ * @return void
+ */
+func (this *Factory) AddLocation(location string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.paths = append(this.paths, location)
+}
+
+/**
+ * Get the active view paths.
+ *
+ * @return []string
+ */
+func (this *Factory) GetPaths() []string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	return append([]string{}, this.paths...)
+}
+
+/**
+ * Set the extension of the view files.
+ *
+ * @param  string  extension
+ * @return void
+ */
+func (this *Factory) SetExtension(extension string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.extension = "." + strings.TrimLeft(extension, ".")
+}
+
+/**
+ * Add a piece of shared data to the environment.
+ *
+ * @param  string  key
+ * @param  interface{}  value
+ * @return void
+ */
+func (this *Factory) Share(key string, value interface{}) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	this.shared[key] = value
+}
+
+/**
+ * Get all of the shared data for the environment.
+ *
+ * @return map[string]interface{}
+ */
+func (this *Factory) GetShared() map[string]interface{} {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	shared := map[string]interface{}{}
+	for key, value := range this.shared {
+		shared[key] = value
+	}
+	return shared
+}
+
+/**
+ * Merge the shared data with the data of the view.
+ *
+ * @param  map[string]interface{}  data
+ * @return map[string]interface{}
+ */
+func (this *Factory) gatherData(data ...map[string]interface{}) map[string]interface{} {
+	gathered := this.GetShared()
+	for _, items := range data {
+		for key, value := range items {
+			gathered[key] = value
+		}
+	}
+	return gathered
+}
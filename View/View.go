@@ -0,0 +1,127 @@
+package View
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/larisgo/framework/Errors"
+	"html/template"
+	"path/filepath"
+)
+
+type View struct {
+	/**
+	 * The view factory instance.
+	 *
+	 * @var *Factory
+	 */
+	factory *Factory
+
+	/**
+	 * The name of the view.
+	 *
+	 * @var string
+	 */
+	view string
+
+	/**
+	 * The path to the view file.
+	 *
+	 * @var string
+	 */
+	path string
+
+	/**
+	 * The array of view data.
+	 *
+	 * @var map[string]interface{}
+	 */
+	data map[string]interface{}
+}
+
+/**
+ * Create a new view instance.
+ *
+ * @param  *Factory  factory
+ * @param  string  view
+ * @param  string  path
+ * @param  map[string]interface{}  data
+ * @return *View
+ */
+func NewView(factory *Factory, view string, path string, data map[string]interface{}) (this *View) {
+	this = &View{}
+	this.factory = factory
+	this.view = view
+	this.path = path
+	this.data = data
+	return this
+}
+
+/**
+ * Get the string contents of the view.
+ *
+ * @return string
+ *
+ * @throws Errors.RuntimeException
+ */
+func (this *View) Render() string {
+	tpl, err := template.New(filepath.Base(this.path)).ParseFiles(this.path)
+	if err != nil {
+		panic(Errors.NewRuntimeException(fmt.Sprintf("Unable to parse view [%s]: %s", this.view, err.Error())))
+	}
+
+	var contents bytes.Buffer
+	if err := tpl.Execute(&contents, this.data); err != nil {
+		panic(Errors.NewRuntimeException(fmt.Sprintf("Unable to render view [%s]: %s", this.view, err.Error())))
+	}
+
+	return contents.String()
+}
+
+/**
+ * Add a piece of data to the view.
+ *
+ * @param  string  key
+ * @param  interface{}  value
+ * @return this
+ */
+func (this *View) With(key string, value interface{}) *View {
+	this.data[key] = value
+
+	return this
+}
+
+/**
+ * Get the array of view data.
+ *
+ * @return map[string]interface{}
+ */
+func (this *View) GetData() map[string]interface{} {
+	return this.data
+}
+
+/**
+ * Get the name of the view.
+ *
+ * @return string
+ */
+func (this *View) Name() string {
+	return this.view
+}
+
+/**
+ * Get the path to the view file.
+ *
+ * @return string
+ */
+func (this *View) GetPath() string {
+	return this.path
+}
+
+/**
+ * Get the string contents of the view.
+ *
+ * @return string
+ */
+func (this *View) String() string {
+	return this.Render()
+}
@@ -0,0 +1,67 @@
+package View
+
+import (
+	RepositoryContract "github.com/larisgo/framework/Contracts/Config"
+	"github.com/larisgo/framework/Contracts/Container"
+	"github.com/larisgo/framework/Contracts/Foundation"
+	"github.com/larisgo/framework/Support"
+)
+
+type ViewServiceProvider struct {
+	*Support.ServiceProvider
+}
+
+func NewViewServiceProvider(app Foundation.Application) (this *ViewServiceProvider) {
+	this = &ViewServiceProvider{ServiceProvider: Support.NewServiceProvider(app)}
+	return this
+}
+
+/**
+ * Register the service provider.
+ *
+ * @return void
+ */
+func (this *ViewServiceProvider) Register() {
+	this.registerFactory()
+}
+
+/**
+ * Register the view environment.
+ *
+ * @return void
+ */
+func (this *ViewServiceProvider) registerFactory() {
+	this.App.Singleton("view", func(app Container.Container) interface{} {
+		factory := NewFactory(this.getPaths())
+
+		// We will also set the application instance as shared data so the views
+		// can reach it, just like every other piece of data that is shared.
+		factory.Share("app", this.App)
+
+		return factory
+	})
+}
+
+/**
+ * Get the view paths from the "view.paths" configuration.
+ *
+ * @return []string
+ */
+func (this *ViewServiceProvider) getPaths() []string {
+	if config, ok := this.App.Make("config").(RepositoryContract.Repository); ok {
+		switch paths := config.Get("view.paths").(type) {
+		case []string:
+			return paths
+		case []interface{}:
+			_paths := []string{}
+			for _, path := range paths {
+				if _path, ok := path.(string); ok {
+					_paths = append(_paths, _path)
+				}
+			}
+			return _paths
+		}
+	}
+
+	return []string{this.App.ResourcePath("views")}
+}